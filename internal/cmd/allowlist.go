@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// systemAllowlist is always enforced when it exists, so operators who cannot
+// write to /etc cannot lift the restriction
+const systemAllowlist = "/etc/drillmeasure/allowlist.yaml"
+
+// allowlistEnv names an additional allowlist file
+const allowlistEnv = "DRILLMEASURE_ALLOWLIST"
+
+// allowlistLogFile receives violations for allowlists without a log setting
+const allowlistLogFile = "allowlist-violations.log"
+
+// allowlistViolation is a scenario command no allowlist entry matches
+type allowlistViolation struct {
+	Time      time.Time `json:"time"`
+	User      string    `json:"user,omitempty"`
+	Host      string    `json:"host,omitempty"`
+	Scenario  string    `json:"scenario"`
+	Allowlist string    `json:"allowlist"`
+	Field     string    `json:"field"`
+	Command   string    `json:"command"`
+	Reason    string    `json:"reason"`
+}
+
+// loadAllowlists returns the allowlists in effect, keyed by path. Without
+// any, commands are not restricted.
+func loadAllowlists() (map[string]*config.Allowlist, error) {
+	paths := []string{systemAllowlist}
+	if p := os.Getenv(allowlistEnv); p != "" {
+		paths = append(paths, p)
+	}
+
+	allowlists := map[string]*config.Allowlist{}
+	for i, p := range paths {
+		if _, err := os.Stat(p); i == 0 && errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		a, err := config.ParseAllowlist(p)
+		if err != nil {
+			return nil, err
+		}
+		allowlists[p] = a
+	}
+	return allowlists, nil
+}
+
+// checkAllowlist refuses a scenario with a command an allowlist does not
+// permit, or that would run through a shell, user, or execution target it
+// does not permit. With record set, violations are appended to the
+// allowlist's log.
+func checkAllowlist(scenario *config.Scenario, record bool) error {
+	allowlists, err := loadAllowlists()
+	if err != nil {
+		return err
+	}
+	if len(allowlists) == 0 {
+		return nil
+	}
+
+	steps := scenario.Steps()
+	fields := make([]string, 0, len(steps))
+	for field := range steps {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	host, _ := os.Hostname()
+	user := os.Getenv("USER")
+	now := time.Now().UTC()
+
+	var violations []allowlistViolation
+	var lines []string
+	for path, a := range allowlists {
+		var found []allowlistViolation
+		for _, field := range fields {
+			command := runner.StepCommand(steps[field])
+			reason := allowlistRefusal(a, scenario, steps[field], command)
+			if reason == "" {
+				continue
+			}
+			for _, secret := range scenario.SecretValues() {
+				command = strings.ReplaceAll(command, secret, "********")
+				reason = strings.ReplaceAll(reason, secret, "********")
+			}
+			found = append(found, allowlistViolation{
+				Time: now, User: user, Host: host, Scenario: scenario.Name,
+				Allowlist: path, Field: field, Command: command, Reason: reason,
+			})
+			lines = append(lines, fmt.Sprintf("  - %s: %s (%s: %s)", field, command, path, reason))
+		}
+		if record && len(found) > 0 {
+			if err := logAllowlistViolations(a, found); err != nil {
+				fmt.Fprintf(os.Stderr, "⚠️  Failed to log allowlist violations: %v\n", err)
+			}
+		}
+		violations = append(violations, found...)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	sort.Strings(lines)
+	return withExitCode(ExitAborted, fmt.Errorf("%d command(s) not allowed by the allowlist; nothing was run:\n%s", len(violations), strings.Join(lines, "\n")))
+}
+
+// allowlistRefusal returns why an allowlist refuses a step, or "" if it
+// permits it. The full argv the step runs is checked: the sudo wrapper for
+// run_as, the shell prefix, and the command itself, on the target it runs on.
+func allowlistRefusal(a *config.Allowlist, scenario *config.Scenario, step config.Command, command string) string {
+	execution, argv := runner.StepArgs(scenario.Execution, scenario.Shell, step)
+	if execution.IsRemote() && !a.AllowsExecution(execution) {
+		return fmt.Sprintf("execution target %s not allowed", execution.AllowlistTarget())
+	}
+	if step.RunAs != "" {
+		wrapper := runner.RunAsArgs(step.RunAs)
+		if !a.AllowsRunAs(step.RunAs) || !slices.Equal(argv[:len(wrapper)], wrapper) {
+			return fmt.Sprintf("run_as user %s not allowed", step.RunAs)
+		}
+		argv = argv[len(wrapper):]
+	}
+	if len(step.Argv) == 0 {
+		shell := argv[:len(argv)-1]
+		if !slices.Equal(shell, runner.TargetShellArgs(execution)) && !a.AllowsShell(shell) {
+			return fmt.Sprintf("shell %s not allowed", strings.Join(shell, " "))
+		}
+	}
+	if !a.Allows(command) {
+		return "command not allowed"
+	}
+	return ""
+}
+
+// logAllowlistViolations appends violations as JSON lines to the allowlist's
+// log, or to the reports directory
+func logAllowlistViolations(a *config.Allowlist, violations []allowlistViolation) error {
+	path := a.Log
+	if path == "" {
+		if err := os.MkdirAll(reportsDir, 0755); err != nil {
+			return err
+		}
+		path = filepath.Join(reportsDir, allowlistLogFile)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for _, v := range violations {
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var annotateCmd = &cobra.Command{
+	Use:   "annotate <run-dir>",
+	Short: "Add a reviewer note, remediation ticket, or sign-off to a stored run",
+	Long: `Record the outcome of an evidence review with the run it concerns. Each
+annotation is appended to annotations.json and shown in report.json and at the
+end of report.md with its author and time; the manifest is then rehashed.
+Signed-off runs, runs with a remediation ticket, and runs marked with --keep
+are never removed by prune.`,
+	Args: cobra.ExactArgs(1),
+	RunE: annotateRun,
+}
+
+var (
+	annotateNote    string
+	annotateFile    string
+	annotateTicket  string
+	annotateSignOff bool
+	annotateKeep    bool
+	annotateAuthor  string
+)
+
+func newAnnotateCmd() *cobra.Command {
+	annotateCmd.Flags().StringVar(&annotateNote, "note", "", "Note text")
+	annotateCmd.Flags().StringVar(&annotateFile, "file", "", "Read the note text from a file (- for stdin)")
+	annotateCmd.Flags().StringVar(&annotateTicket, "ticket", "", "Remediation ticket ID or URL raised from the review")
+	annotateCmd.Flags().BoolVar(&annotateSignOff, "sign-off", false, "Record that the reviewer accepts the run as evidence")
+	annotateCmd.Flags().BoolVar(&annotateKeep, "keep", false, "Retain the run however old it gets, e.g. as audit evidence")
+	annotateCmd.Flags().StringVar(&annotateAuthor, "author", "", "Reviewer (default: the current user)")
+	annotateCmd.MarkFlagsMutuallyExclusive("note", "file")
+	annotateCmd.MarkFlagsMutuallyExclusive("ticket", "sign-off", "keep")
+	return annotateCmd
+}
+
+func annotateRun(cmd *cobra.Command, args []string) error {
+	runDir := filepath.Clean(args[0])
+
+	text := annotateNote
+	if annotateFile != "" {
+		var raw []byte
+		var err error
+		if annotateFile == "-" {
+			raw, err = io.ReadAll(os.Stdin)
+		} else {
+			raw, err = os.ReadFile(annotateFile)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read note: %w", err)
+		}
+		text = string(raw)
+	}
+	if text == "" && annotateTicket == "" && !annotateSignOff && !annotateKeep {
+		return fmt.Errorf("nothing to add; pass --note, --file, --ticket, --sign-off, or --keep")
+	}
+	cmd.SilenceUsage = true
+
+	annotation := report.Annotation{
+		Time:   time.Now().Format(time.RFC3339),
+		Author: annotateAuthor,
+		Kind:   report.AnnotationNote,
+		Text:   text,
+		Ticket: annotateTicket,
+	}
+	switch {
+	case annotateSignOff:
+		annotation.Kind = report.AnnotationSignOff
+	case annotateTicket != "":
+		annotation.Kind = report.AnnotationTicket
+	case annotateKeep:
+		annotation.Kind = report.AnnotationKeep
+	}
+	if annotation.Author == "" {
+		if u, err := user.Current(); err == nil {
+			annotation.Author = u.Username
+		}
+	}
+
+	if err := report.AddAnnotation(runDir, annotation); err != nil {
+		return fmt.Errorf("failed to annotate run: %w", err)
+	}
+	fmt.Printf("✅ Added %s to %s\n", strings.ReplaceAll(annotation.Kind, "_", "-"), runDir)
+	return nil
+}
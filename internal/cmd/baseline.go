@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/history"
+	"github.com/drillmeasure/drillmeasure/internal/report"
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var baselineCmd = &cobra.Command{
+	Use:   "baseline <run-dir>",
+	Short: "Mark a run as the baseline of its scenario",
+	Long: `Mark a completed run as the baseline of its scenario in the run history.
+Later runs of the scenario report their RTA relative to the baseline's, and
+fail with exit code 6 if it grew by more than the scenario's
+max_rta_regression percentage (or run --max-rta-regression), so drills can
+gate CI on recovery getting slower. Marking another run replaces the
+baseline.`,
+	Args: cobra.ExactArgs(1),
+	RunE: setBaseline,
+}
+
+var baselineShowCmd = &cobra.Command{
+	Use:   "show [scenario]",
+	Short: "List the baseline run of each scenario",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  showBaselines,
+}
+
+var baselineClearCmd = &cobra.Command{
+	Use:   "clear <scenario>",
+	Short: "Remove a scenario's baseline",
+	Args:  cobra.ExactArgs(1),
+	RunE:  clearBaseline,
+}
+
+func newBaselineCmd() *cobra.Command {
+	for _, c := range []*cobra.Command{baselineCmd, baselineShowCmd, baselineClearCmd} {
+		registerDataDir(c)
+	}
+	baselineCmd.AddCommand(baselineShowCmd)
+	baselineCmd.AddCommand(baselineClearCmd)
+	return baselineCmd
+}
+
+func setBaseline(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	data, err := report.ReadReport(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read run: %w", err)
+	}
+	run, err := history.NewRun(args[0], data)
+	if err != nil {
+		return err
+	}
+	switch {
+	case run.Simulated:
+		return fmt.Errorf("%s is a simulated run and can't be a baseline", args[0])
+	case run.Incomplete:
+		return fmt.Errorf("%s is incomplete and can't be a baseline", args[0])
+	}
+	if rta, err := time.ParseDuration(run.RTA); err != nil || rta <= 0 {
+		return fmt.Errorf("%s had no downtime, so it has no RTA to compare against", args[0])
+	}
+
+	store, err := history.Open(historyLocation())
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	// Runs from before the history, or imported, are recorded first
+	if err := store.Record(run); err != nil {
+		return fmt.Errorf("failed to record run: %w", err)
+	}
+	if err := store.SetBaseline(run); err != nil {
+		return fmt.Errorf("failed to set baseline: %w", err)
+	}
+	fmt.Printf("✅ Baseline for %s set to %s (RTA %s)\n", run.Scenario, run.ReportPath, run.RTA)
+	return nil
+}
+
+func showBaselines(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	store, err := history.Open(historyLocation())
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	var runs []history.Run
+	if len(args) > 0 {
+		run, err := store.Baseline(args[0])
+		if err != nil {
+			return fmt.Errorf("failed to read history: %w", err)
+		}
+		if run != nil {
+			runs = append(runs, *run)
+		}
+	} else if runs, err = store.Baselines(); err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+	if len(runs) == 0 {
+		fmt.Printf("No baselines in %s\n", store.Path())
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SCENARIO\tDATE\tRTA\tRTO TARGET\tREPORT")
+	for _, r := range runs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Scenario, r.StartTime, r.RTA, r.RTOTarget, r.ReportPath)
+	}
+	return w.Flush()
+}
+
+func clearBaseline(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	store, err := history.Open(historyLocation())
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	cleared, err := store.ClearBaseline(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to clear baseline: %w", err)
+	}
+	if !cleared {
+		return fmt.Errorf("%s has no baseline", args[0])
+	}
+	fmt.Printf("✅ Cleared the baseline of %s\n", args[0])
+	return nil
+}
+
+// applyBaseline sets the result's baseline from the run history, so the
+// reports show the RTA relative to it. Simulated runs and the baseline run
+// itself are not compared.
+func applyBaseline(result *runner.DrillResult, outputDir string) {
+	gated := result.Scenario.MaxRTARegression != nil
+	if result.Simulated {
+		return
+	}
+	store, err := history.Open(historyLocation())
+	if err != nil {
+		// The run is not recorded either, which recordHistory warns about
+		if gated {
+			fmt.Printf("⚠️  RTA not compared with the baseline: %v\n", err)
+		}
+		return
+	}
+	run, err := store.Baseline(result.Scenario.Name)
+	if err != nil {
+		fmt.Printf("⚠️  RTA not compared with the baseline: %v\n", err)
+		return
+	}
+	if run == nil {
+		if gated {
+			fmt.Printf("⚠️  %s has no baseline run, so max_rta_regression is not checked; set one with 'drillmeasure baseline <run-dir>'\n", result.Scenario.Name)
+		}
+		return
+	}
+	if path, err := filepath.Abs(outputDir); err == nil && path == run.ReportPath {
+		return
+	}
+	rta, err := time.ParseDuration(run.RTA)
+	if err != nil {
+		fmt.Printf("⚠️  RTA not compared with the baseline: invalid baseline RTA %q\n", run.RTA)
+		return
+	}
+	start, _ := time.Parse(time.RFC3339, run.StartTime)
+	result.Baseline = &runner.Baseline{
+		ReportPath:    run.ReportPath,
+		StartTime:     start,
+		RTA:           rta,
+		MaxRegression: result.Scenario.MaxRTARegression,
+	}
+}
@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/drillmeasure/drillmeasure/internal/history"
+	"github.com/spf13/cobra"
+)
+
+var budgetCmd = &cobra.Command{
+	Use:   "budget",
+	Short: "Show each service's downtime against its annual availability budget",
+	Long: `For every SLA in the registry (--slas, default: $DRILLMEASURE_SLAS) with an
+availability, add up the downtime the run history records for its service
+this year, from drills and from incidents imported with 'budget import', and
+show how much of the downtime the availability allows is left. Every run
+with measured downtime counts, whether it passed or not; simulated runs
+don't.
+
+The monthly allowance spreads the budget left at the start of this month
+evenly over the rest of the year. With --planned, each service is checked
+for whether a game day expected to cause that much downtime fits in it.`,
+	Args: cobra.NoArgs,
+	RunE: showBudgets,
+}
+
+var budgetImportCmd = &cobra.Command{
+	Use:   "import <incidents-file>",
+	Short: "Import real incidents to count against downtime budgets",
+	Long: `Record real outages in the run history, so downtime budgets count them
+alongside drills. The file is a CSV with 'service' and 'start' columns,
+either 'downtime' (e.g. 45m) or 'end', and an optional 'description'
+column, or a YAML or JSON list of incidents with the same fields. Times are
+RFC 3339. Importing an incident again (same service and start) replaces it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: importIncidents,
+}
+
+var (
+	budgetSLAFile string
+	budgetYear    int
+	budgetPlanned time.Duration
+)
+
+func newBudgetCmd() *cobra.Command {
+	registerDataDir(budgetCmd)
+	registerDataDir(budgetImportCmd)
+	budgetCmd.Flags().StringVar(&budgetSLAFile, "slas", "", "SLA registry with the services' availability (default: $"+config.SLAsEnv+")")
+	budgetCmd.Flags().IntVar(&budgetYear, "year", 0, "Year to account for (default: the current year)")
+	budgetCmd.Flags().DurationVar(&budgetPlanned, "planned", 0, "Downtime a planned game day is expected to cause, checked against each budget")
+	budgetCmd.AddCommand(budgetImportCmd)
+	return budgetCmd
+}
+
+func showBudgets(cmd *cobra.Command, args []string) error {
+	slaFile := budgetSLAFile
+	if slaFile == "" {
+		slaFile = os.Getenv(config.SLAsEnv)
+	}
+	if slaFile == "" {
+		return fmt.Errorf("no SLA registry: pass --slas or set $%s", config.SLAsEnv)
+	}
+	cmd.SilenceUsage = true
+
+	registry, err := config.LoadSLARegistry(slaFile)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	year := budgetYear
+	if year == 0 {
+		year = now.Year()
+	}
+	from := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(1, 0, 0)
+
+	store, err := history.Open(historyLocation())
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	budgets, err := store.DowntimeBudgets(registry, from, to, now)
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+	if len(budgets) == 0 {
+		fmt.Printf("No SLA in %s sets an availability\n", slaFile)
+		return nil
+	}
+
+	// The rest of the year includes the current month; a past year has none left
+	monthsLeft := 0
+	switch {
+	case year == now.Year():
+		monthsLeft = 13 - int(now.Month())
+	case year > now.Year():
+		monthsLeft = 12
+	}
+
+	fmt.Printf("Downtime budgets for %d\n\n", year)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := "SERVICE\tSLA\tAVAILABILITY\tBUDGET\tDRILLS\tINCIDENTS\tREMAINING\tUSED\tTHIS MONTH\tMONTHLY ALLOWANCE"
+	if budgetPlanned > 0 {
+		header += "\tPLANNED " + formatBudgetDuration(budgetPlanned)
+	}
+	fmt.Fprintln(w, header)
+	exhausted, unaffordable := 0, 0
+	for _, b := range budgets {
+		remaining := b.Remaining()
+		if remaining <= 0 {
+			exhausted++
+		}
+		// This month's share of what was left at its start
+		var allowance time.Duration
+		if monthsLeft > 0 {
+			allowance = max(remaining+b.Month, 0) / time.Duration(monthsLeft)
+		}
+		allowanceText := "-"
+		if monthsLeft > 0 {
+			allowanceText = formatBudgetDuration(allowance)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%v%%\t%s\t%s (%d)\t%s (%d)\t%s\t%.0f%%\t%s\t%s",
+			b.SLA.Service, b.SLA.ID, b.SLA.Availability, formatBudgetDuration(b.Budget),
+			formatBudgetDuration(b.Drills), b.DrillCount, formatBudgetDuration(b.Incidents), b.IncidentCount,
+			formatBudgetDuration(remaining), b.UsedPercent(), formatBudgetDuration(b.Month), allowanceText)
+		if budgetPlanned > 0 {
+			fits := "✅ fits"
+			switch {
+			case budgetPlanned > remaining:
+				fits = "❌ exceeds remaining budget"
+				unaffordable++
+			case monthsLeft > 0 && b.Month+budgetPlanned > allowance:
+				fits = "⚠️  exceeds monthly allowance"
+			}
+			fmt.Fprintf(w, "\t%s", fits)
+		}
+		fmt.Fprintln(w)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if exhausted > 0 {
+		fmt.Printf("\n❌ %d service(s) have exhausted their downtime budget\n", exhausted)
+	}
+	if unaffordable > 0 {
+		fmt.Printf("\n❌ A game day causing %s of downtime would exceed %d service(s)' remaining budget\n",
+			formatBudgetDuration(budgetPlanned), unaffordable)
+	}
+	return nil
+}
+
+func importIncidents(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	incidents, err := history.ParseIncidents(args[0])
+	if err != nil {
+		return err
+	}
+	store, err := history.Open(historyLocation())
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	for _, incident := range incidents {
+		if err := store.RecordIncident(incident); err != nil {
+			return fmt.Errorf("failed to record incident: %w", err)
+		}
+	}
+
+	fmt.Printf("✅ Imported %d incident(s) into %s\n", len(incidents), store.Path())
+	return nil
+}
+
+// formatBudgetDuration renders a downtime to the second
+func formatBudgetDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/drillmeasure/drillmeasure/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle <run-dir>",
+	Short: "Pack a run into a verifiable evidence bundle",
+	Long: `Pack a run directory into a tar.gz evidence bundle. The bundle's
+bundle.json lists every file with its SHA256 hash and a top-level bundle hash
+computed from them; record the bundle hash with the audit evidence, then check
+the bundle with 'drillmeasure bundle verify' to show it is complete and
+unmodified.`,
+	Args: cobra.ExactArgs(1),
+	RunE: bundleRun,
+}
+
+var bundleVerifyCmd = &cobra.Command{
+	Use:   "verify <bundle>",
+	Short: "Check an evidence bundle is complete and unmodified",
+	Long: `Check every file of an evidence bundle against its bundle.json and
+recompute the bundle hash. Pass --hash with the bundle hash recorded when the
+bundle was made, so a bundle.json rewritten to match modified files is caught
+too.`,
+	Args: cobra.ExactArgs(1),
+	RunE: verifyBundle,
+}
+
+var (
+	bundleOutput       string
+	bundleExpectedHash string
+)
+
+func newBundleCmd() *cobra.Command {
+	bundleCmd.Flags().StringVarP(&bundleOutput, "output", "o", "", "Bundle file (default: <run-dir>.tar.gz)")
+	bundleVerifyCmd.Flags().StringVar(&bundleExpectedHash, "hash", "", "Bundle hash recorded when the bundle was made")
+	bundleCmd.AddCommand(bundleVerifyCmd)
+	return bundleCmd
+}
+
+func bundleRun(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	runDir := filepath.Clean(args[0])
+
+	out := bundleOutput
+	if out == "" {
+		out = runDir + ".tar.gz"
+	}
+	manifest, err := report.WriteBundle(runDir, out)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Bundled %d file(s) from %s into %s\n", len(manifest.Files), runDir, out)
+	fmt.Printf("Bundle hash (SHA256): %s\n", manifest.BundleSHA256)
+	fmt.Println("Record the bundle hash with the evidence to verify the bundle later.")
+	return nil
+}
+
+func verifyBundle(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	manifest, problems, err := report.VerifyBundle(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to verify bundle: %w", err)
+	}
+	if bundleExpectedHash != "" && bundleExpectedHash != manifest.BundleSHA256 {
+		problems = append(problems, fmt.Sprintf("bundle hash is %s, expected %s", manifest.BundleSHA256, bundleExpectedHash))
+	}
+
+	if len(problems) > 0 {
+		for _, p := range problems {
+			fmt.Printf("❌ %s\n", p)
+		}
+		return fmt.Errorf("%s failed verification with %d problem(s)", args[0], len(problems))
+	}
+
+	fmt.Printf("✅ %s is complete and unmodified: %d file(s) from %s\n", args[0], len(manifest.Files), manifest.RunDir)
+	fmt.Printf("Bundle hash (SHA256): %s\n", manifest.BundleSHA256)
+	if bundleExpectedHash == "" {
+		fmt.Println("Pass --hash with the recorded bundle hash to also check bundle.json was not replaced.")
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// checkpointFileName holds the state of a run in progress in its output directory
+const checkpointFileName = "checkpoint.json"
+
+// runCheckpoint is checkpoint.json: the runner's checkpoint plus what resume
+// needs to load the same scenario again
+type runCheckpoint struct {
+	ScenarioPath   string             `json:"scenario_path"`
+	ScenarioSHA256 string             `json:"scenario_sha256"`
+	ValuesFile     string             `json:"values_file,omitempty"`
+	IdempotencyKey string             `json:"idempotency_key,omitempty"`
+	WindowOverride bool               `json:"window_override,omitempty"`
+	Simulated      bool               `json:"simulated,omitempty"`
+	Iteration      int                `json:"iteration,omitempty"`
+	Iterations     int                `json:"iterations,omitempty"`
+	MaxRegression  *float64           `json:"max_rta_regression,omitempty"` // Set with run --max-rta-regression
+	Checkpoint     *runner.Checkpoint `json:"checkpoint"`
+}
+
+// saver returns a Hooks.OnCheckpoint callback that writes checkpoint.json to
+// outputDir with secret values masked
+func (c *runCheckpoint) saver(outputDir string, secrets []string) func(*runner.Checkpoint) {
+	warned := false
+	return func(checkpoint *runner.Checkpoint) {
+		c.Checkpoint = checkpoint
+		err := c.write(outputDir, secrets)
+		c.Checkpoint = nil
+		if err != nil && !warned {
+			warned = true
+			fmt.Printf("⚠️  Failed to write %s: %v\n", checkpointFileName, err)
+		}
+	}
+}
+
+func (c *runCheckpoint) write(outputDir string, secrets []string) error {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	content := string(raw)
+	for _, secret := range secrets {
+		content = strings.ReplaceAll(content, secret, "********")
+	}
+
+	// Write atomically so a crash never leaves a truncated checkpoint
+	path := filepath.Join(outputDir, checkpointFileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(content), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// readCheckpoint loads checkpoint.json from a run directory
+func readCheckpoint(runDir string) (*runCheckpoint, error) {
+	raw, err := os.ReadFile(filepath.Join(runDir, checkpointFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var c runCheckpoint
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(runDir, checkpointFileName), err)
+	}
+	if c.Checkpoint == nil {
+		return nil, fmt.Errorf("%s has no drill state", filepath.Join(runDir, checkpointFileName))
+	}
+	return &c, nil
+}
+
+// removeCheckpoint deletes a completed run's checkpoint, which its reports supersede
+func removeCheckpoint(runDir string) {
+	path := filepath.Join(runDir, checkpointFileName)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		fmt.Printf("⚠️  Failed to remove %s: %v\n", path, err)
+	}
+}
+
+// absPath makes path absolute so a run can be resumed from another directory
+func absPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/drillmeasure/drillmeasure/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <run-a> <run-b>",
+	Short: "Diff the results of two runs",
+	Long: `Compare two stored runs, e.g. the last drill before a remediation and the
+first one after it. The Markdown diff shows the RTA and its breakdown,
+per-phase timings, health check counts, and checks that passed in one run and
+failed in the other, with each change given as run B relative to run A.`,
+	Args: cobra.ExactArgs(2),
+	RunE: compareRuns,
+}
+
+var compareOutput string
+
+func newCompareCmd() *cobra.Command {
+	compareCmd.Flags().StringVarP(&compareOutput, "output", "o", "", "Write the comparison to a file instead of stdout")
+	return compareCmd
+}
+
+func compareRuns(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	var runs [2]report.RunComparison
+	for i, runDir := range args {
+		data, err := report.ReadReport(runDir)
+		if err != nil {
+			return fmt.Errorf("failed to read run: %w", err)
+		}
+		runs[i] = report.RunComparison{Label: filepath.Base(filepath.Clean(runDir)), Data: data}
+	}
+
+	content := report.GenerateComparisonMarkdownReport(runs[0], runs[1])
+	if compareOutput == "" {
+		fmt.Print(content)
+		return nil
+	}
+	if err := os.WriteFile(compareOutput, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write comparison: %w", err)
+	}
+	fmt.Printf("✅ Wrote comparison to %s\n", compareOutput)
+	return nil
+}
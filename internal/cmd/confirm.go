@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// checkAllowedWindow refuses to run outside the scenario's allowed_windows
+// unless --force is set, and reports whether the run overrides them
+func checkAllowedWindow(scenario *config.Scenario) (bool, error) {
+	if scenario.InAllowedWindow(time.Now()) {
+		return false, nil
+	}
+	if !forceWindow {
+		return false, withExitCode(ExitAborted, fmt.Errorf("outside the scenario's allowed windows (%s); pass --force to run anyway", scenario.DescribeAllowedWindows()))
+	}
+	fmt.Printf("⚠️  Running outside the allowed windows (%s) because of --force; this is recorded in the report\n\n", scenario.DescribeAllowedWindows())
+	return true, nil
+}
+
+// confirmDisruption shows what the disruption will do and where, and requires
+// the scenario name to be typed back before it runs
+func confirmDisruption(scenario *config.Scenario) error {
+	command := runner.StepCommand(scenario.DisruptCommand)
+	for _, secret := range scenario.SecretValues() {
+		command = strings.ReplaceAll(command, secret, "********")
+	}
+	execution := scenario.Execution
+	if scenario.DisruptCommand.Execution != nil {
+		execution = scenario.DisruptCommand.Execution
+	}
+
+	fmt.Printf("⚠️  This drill will disrupt %s\n", scenario.ServiceName())
+	fmt.Printf("   Command:     %s\n", command)
+	fmt.Printf("   Runs on:     %s\n", describeExecution(execution))
+	if accounts := scenario.CloudAccounts(); len(accounts) > 0 {
+		fmt.Printf("   Accounts:    %s\n", strings.Join(accounts, ", "))
+	}
+	if b := scenario.BlastRadius; b != nil {
+		if b.Environment != "" {
+			fmt.Printf("   Environment: %s\n", b.Environment)
+		}
+		if len(b.Services) > 0 {
+			fmt.Printf("   Affects:     %s\n", strings.Join(b.Services, ", "))
+		}
+		if b.Impact != "" {
+			fmt.Printf("   Impact:      %s\n", b.Impact)
+		}
+	}
+	fmt.Printf("\nType the scenario name (%s) to continue: ", scenario.Name)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Println()
+		return withExitCode(ExitAborted, fmt.Errorf("disruption not confirmed (no input; pass --yes to run non-interactively)"))
+	}
+	if strings.TrimSpace(answer) != scenario.Name {
+		return withExitCode(ExitAborted, fmt.Errorf("disruption not confirmed; nothing was run"))
+	}
+	fmt.Println()
+	return nil
+}
+
+// describeExecution names where a command runs
+func describeExecution(e *config.Execution) string {
+	if !e.IsRemote() {
+		return "local shell"
+	}
+	switch e.Type {
+	case "ssh":
+		return "ssh " + e.Target()
+	case "kubectl-exec":
+		target := "pod " + e.Pod
+		if e.Pod == "" {
+			target = "first pod matching " + e.Selector
+		}
+		if e.Namespace != "" {
+			target += " in namespace " + e.Namespace
+		}
+		if e.Context != "" {
+			target += " (context " + e.Context + ")"
+		}
+		return "kubectl-exec " + target
+	case "docker":
+		return "docker container " + e.Container
+	}
+	return e.Type
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// debugDirName is the debug bundle directory inside a run's output directory
+const debugDirName = "debug"
+
+// writeDebugBundle writes the execution trace and the context the drill ran in
+// (environment with secrets masked, system details, resolved scenario)
+func writeDebugBundle(outputDir string, tracer *runner.Tracer, scenario *config.Scenario, runErr error) error {
+	dir := filepath.Join(outputDir, debugDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	var trace strings.Builder
+	for _, event := range tracer.Events() {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		trace.Write(line)
+		trace.WriteByte('\n')
+	}
+
+	wd, _ := os.Getwd()
+	hostname, _ := os.Hostname()
+	system := map[string]interface{}{
+		"drillmeasure_version": version,
+		"drillmeasure_commit":  commit,
+		"go_version":           runtime.Version(),
+		"os":                   runtime.GOOS,
+		"arch":                 runtime.GOARCH,
+		"hostname":             hostname,
+		"working_directory":    wd,
+		"args":                 os.Args,
+		"path":                 filepath.SplitList(os.Getenv("PATH")),
+	}
+	if runErr != nil {
+		system["run_error"] = runErr.Error()
+	}
+
+	files := map[string]interface{}{
+		"environment.json": maskedEnvironment(),
+		"system.json":      system,
+		"scenario.json": map[string]interface{}{
+			"scenario":  scenario,
+			"variables": scenario.MaskedVariables(),
+		},
+	}
+	for name, v := range files {
+		raw, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		content := string(raw)
+		for _, secret := range scenario.SecretValues() {
+			content = strings.ReplaceAll(content, secret, "********")
+		}
+		if err := writeScanned(filepath.Join(dir, name), content); err != nil {
+			return err
+		}
+	}
+
+	return writeScanned(filepath.Join(dir, "trace.jsonl"), trace.String())
+}
+
+// writeScanned writes content after applying --secret-scan to it
+func writeScanned(path, content string) error {
+	content, err := scanReport(filepath.Join(debugDirName, filepath.Base(path)), content)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// maskedEnvironment returns the process environment with secret-looking values masked
+func maskedEnvironment() map[string]string {
+	env := map[string]string{}
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		if config.IsSecretName(name) || credentialEnvNames[name] {
+			value = "********"
+		}
+		env[name] = value
+	}
+	return env
+}
+
+// credentialEnvNames are environment variables that hold credentials without a telltale name
+var credentialEnvNames = map[string]bool{
+	"AWS_SESSION_TOKEN":     true,
+	"AWS_SECRET_ACCESS_KEY": true,
+	"GOVC_PASSWORD":         true,
+	"GOVC_URL":              true,
+	"PGPASSWORD":            true,
+	"MYSQL_PWD":             true,
+	"DATABASE_URL":          true,
+}
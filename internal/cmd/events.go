@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/drillmeasure/drillmeasure/internal/report"
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// eventsFileName is the drill's event stream in the output directory
+const eventsFileName = "events.jsonl"
+
+// eventLog appends each runner event to events.jsonl as it happens, so the
+// file can be tailed during long drills
+type eventLog struct {
+	f      *os.File
+	failed bool
+}
+
+// openEventLog opens events.jsonl in outputDir, appending so a resumed run
+// continues the stream
+func openEventLog(outputDir string) (*eventLog, error) {
+	f, err := os.OpenFile(filepath.Join(outputDir, eventsFileName), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event stream: %w", err)
+	}
+	return &eventLog{f: f}, nil
+}
+
+// write is a Hooks.OnEvent callback. Lines are redacted unless --secret-scan
+// is off, since the stream can't be held back once written.
+func (l *eventLog) write(event runner.Event) {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(event)
+	line := b.String()
+	if err == nil && secretScanMode != "off" {
+		line, _ = report.RedactSecrets(line)
+	}
+	if err == nil {
+		_, err = l.f.WriteString(line)
+	}
+	if err != nil && !l.failed {
+		l.failed = true
+		fmt.Printf("⚠️  Failed to write %s: %v\n", eventsFileName, err)
+	}
+}
+
+func (l *eventLog) Close() error {
+	return l.f.Close()
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+)
+
+// Process exit codes, so wrapper automation can branch on the drill outcome
+const (
+	ExitPass      = 0 // Drill completed and all targets were met
+	ExitRTOFailed = 2 // RTA exceeded the RTO target, switchback did not complete in time, or an assertion failed
+	ExitRPOFailed = 3 // RPO verification failed
+	ExitError     = 4 // Invalid scenario or the drill could not be executed
+	ExitAborted   = 5 // Drill was interrupted, or stopped before the disruption because it was not confirmed or not allowed
+	ExitRegressed = 6 // All targets were met, but the RTA regressed past the baseline run's by more than max_rta_regression
+)
+
+// exitError carries a specific process exit code alongside an error
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode attaches a process exit code to err
+func withExitCode(code int, err error) error {
+	return &exitError{code: code, err: err}
+}
+
+// ExitCode maps an error returned by Execute to a process exit code
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitPass
+	}
+
+	var ee *exitError
+	if errors.As(err, &ee) {
+		return ee.code
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return ExitAborted
+	}
+
+	return ExitError
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/history"
+	"github.com/drillmeasure/drillmeasure/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [scenario]",
+	Short: "List past runs from the run history",
+	Long: `List past runs recorded in the run history, most recent first, optionally
+only those of one scenario. Every run and resumed run is recorded in
+history.db in the data directory (--data-dir, $DRILLMEASURE_DATA_DIR, or
+~/.local/share/drillmeasure) with its scenario, timestamps, RTA, targets,
+pass/fail, and report path, so outcomes outlive the report folders. Requires
+the sqlite3 command-line shell.
+
+To share one history between operators and CI jobs, keep it in Postgres
+instead with --history-url or $DRILLMEASURE_HISTORY_URL (e.g.
+postgres://drillmeasure@db.internal/drills), which requires psql.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: listHistory,
+}
+
+var (
+	dataDir       string
+	historyURL    string
+	historyTags   []string
+	historyFrom   string
+	historyTo     string
+	historyResult string
+	historyLimit  int
+)
+
+// registerDataDir adds the --data-dir and --history-url flags to a command
+// that uses the run history
+func registerDataDir(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&dataDir, "data-dir", "", "Directory of the run history database (default: $"+history.DataDirEnv+" or ~/.local/share/drillmeasure)")
+	cmd.Flags().StringVar(&historyURL, "history-url", "", "Postgres URL of a shared run history, used instead of --data-dir (default: $"+history.URLEnv+")")
+}
+
+func newHistoryCmd() *cobra.Command {
+	registerDataDir(historyCmd)
+	historyCmd.Flags().StringArrayVar(&historyTags, "tag", nil, "Only list runs of scenarios with this tag (repeatable; runs must have every tag)")
+	historyCmd.Flags().StringVar(&historyFrom, "from", "", "Only list runs started on or after this day (YYYY-MM-DD)")
+	historyCmd.Flags().StringVar(&historyTo, "to", "", "Only list runs started on or before this day (YYYY-MM-DD)")
+	historyCmd.Flags().StringVar(&historyResult, "result", "", "Only list runs with this result: "+strings.Join(history.Results, ", "))
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "Number of runs to list (0 for all)")
+	return historyCmd
+}
+
+// historyLocation returns the Postgres URL or data directory of the run
+// history: the flags first, then $DRILLMEASURE_HISTORY_URL, then the default
+// data directory
+func historyLocation() string {
+	switch {
+	case historyURL != "":
+		return historyURL
+	case dataDir != "":
+		return dataDir
+	case os.Getenv(history.URLEnv) != "":
+		return os.Getenv(history.URLEnv)
+	}
+	return history.DefaultDataDir()
+}
+
+// recordHistory adds a finished run to the run history. Failing to record
+// it does not fail the drill.
+func recordHistory(outputDir string) {
+	data, err := report.ReadReport(outputDir)
+	if err == nil {
+		var run history.Run
+		run, err = history.NewRun(outputDir, data)
+		if err == nil {
+			var store *history.Store
+			store, err = history.Open(historyLocation())
+			if err == nil {
+				err = store.Record(run)
+			}
+		}
+	}
+	if err != nil {
+		fmt.Printf("⚠️  Run not recorded in history: %v\n", err)
+	}
+}
+
+func listHistory(cmd *cobra.Command, args []string) error {
+	filter := history.Filter{Tags: historyTags, Result: historyResult, Limit: historyLimit}
+	if len(args) > 0 {
+		filter.Scenario = args[0]
+	}
+	if historyFrom != "" {
+		from, err := time.Parse("2006-01-02", historyFrom)
+		if err != nil {
+			return fmt.Errorf("invalid --from date %q (expected YYYY-MM-DD)", historyFrom)
+		}
+		filter.From = from
+	}
+	if historyTo != "" {
+		to, err := time.Parse("2006-01-02", historyTo)
+		if err != nil {
+			return fmt.Errorf("invalid --to date %q (expected YYYY-MM-DD)", historyTo)
+		}
+		// --to is inclusive, so the range ends at the start of the following day
+		filter.To = to.AddDate(0, 0, 1)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.To.After(filter.From) {
+		return fmt.Errorf("--to must not be before --from")
+	}
+	if filter.Result != "" {
+		valid := false
+		for _, r := range history.Results {
+			valid = valid || filter.Result == r
+		}
+		if !valid {
+			return fmt.Errorf("invalid --result %q (expected %s)", filter.Result, strings.Join(history.Results, ", "))
+		}
+	}
+	cmd.SilenceUsage = true
+
+	store, err := history.Open(historyLocation())
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	runs, err := store.Runs(filter)
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+	if len(runs) == 0 {
+		fmt.Printf("No matching runs in %s\n", store.Path())
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATE\tSCENARIO\tRTA\tRTO TARGET\tRESULT\tREPORT")
+	for _, r := range runs {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", r.StartTime, r.Scenario, r.RTA, r.RTOTarget, r.Result(), r.ReportPath)
+	}
+	return w.Flush()
+}
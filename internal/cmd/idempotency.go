@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/drillmeasure/drillmeasure/internal/report"
+)
+
+// idempotencyDir holds one marker file per idempotency key, naming the run directory that owns it
+const idempotencyDir = ".idempotency"
+
+func idempotencyMarker(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(reportsDir, idempotencyDir, hex.EncodeToString(sum[:16]))
+}
+
+// lookupIdempotencyKey returns the run directory that already used key, or "" if none
+func lookupIdempotencyKey(key string) (string, error) {
+	data, err := os.ReadFile(idempotencyMarker(key))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read idempotency marker: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// claimIdempotencyKey atomically records outputDir as the owner of key
+func claimIdempotencyKey(key, outputDir string) error {
+	marker := idempotencyMarker(key)
+	if err := os.MkdirAll(filepath.Dir(marker), 0755); err != nil {
+		return fmt.Errorf("failed to create idempotency directory: %w", err)
+	}
+
+	f, err := os.OpenFile(marker, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("idempotency key %q was claimed by a concurrent invocation", key)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write idempotency marker: %w", err)
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(outputDir + "\n")
+	return err
+}
+
+// replayExistingRun reports the outcome of the run that already used the
+// idempotency key. It is judged from the run's stored result by the same
+// drillOutcome as a live run, so a replay exits with the original exit code.
+func replayExistingRun(key, runDir string) error {
+	result, err := report.ReadRawResult(runDir)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("a run with idempotency key %q is already in progress or did not complete: %s", key, runDir)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Idempotency key %q already used by %s; not running the drill again.\n", key, runDir)
+	fmt.Printf("RTA: %s (RTO target: %s)\n", formatDuration(result.RTA), formatDuration(result.RTOTarget))
+	fmt.Printf("\nReports generated in: %s\n", runDir)
+
+	if result.Interrupted {
+		return withExitCode(ExitAborted, fmt.Errorf("drill interrupted"))
+	}
+	if result.Incomplete {
+		return fmt.Errorf("drill execution failed before completion")
+	}
+	return drillOutcome(result)
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/drillmeasure/drillmeasure/internal/history"
+	"github.com/drillmeasure/drillmeasure/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <reports-dir>",
+	Short: "Backfill the run index and history from existing reports",
+	Long: `Copy every run directory with a report.json from <reports-dir> into the
+reports directory, upgrading reports written by older versions to the
+current schema, rebuild index.json, and record each run in the run history,
+so history, trend, status, and quarterly reports include drills run before
+the index existed. A migrated report.json is
+rewritten; the original is kept next to it as report.original.json.
+
+Runs already in the reports directory are not copied again but are still
+recorded in the history. Pass the reports directory itself to migrate its
+runs in place.`,
+	Args: cobra.ExactArgs(1),
+	RunE: importRuns,
+}
+
+var importReportsDir string
+
+func newImportCmd() *cobra.Command {
+	importCmd.Flags().StringVar(&importReportsDir, "dir", reportsDir, "Reports directory to import into")
+	registerDataDir(importCmd)
+	return importCmd
+}
+
+func importRuns(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	result, err := report.ImportRuns(args[0], importReportsDir)
+	if err != nil {
+		return fmt.Errorf("failed to import runs: %w", err)
+	}
+
+	store, err := history.Open(historyLocation())
+	if err != nil {
+		return fmt.Errorf("failed to open run history: %w", err)
+	}
+	for _, runDir := range result.RunDirs {
+		data, err := report.ReadReport(runDir)
+		if err != nil {
+			return fmt.Errorf("failed to record %s in history: %w", runDir, err)
+		}
+		run, err := history.NewRun(runDir, data)
+		if err == nil {
+			err = store.Record(run)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to record %s in history: %w", runDir, err)
+		}
+	}
+
+	fmt.Printf("✅ Imported %d run(s) into %s (%d migrated, %d already present)\n",
+		result.Imported, importReportsDir, result.Migrated, result.Skipped)
+	fmt.Printf("Recorded %d run(s) in %s\n", len(result.RunDirs), store.Path())
+	return nil
+}
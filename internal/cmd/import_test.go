@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/drillmeasure/drillmeasure/internal/history"
+	"github.com/drillmeasure/drillmeasure/internal/report"
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+func TestImportRecordsRunsInHistory(t *testing.T) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		t.Skip("sqlite3 is not installed")
+	}
+
+	src := t.TempDir()
+	runDir := filepath.Join(src, "2024-01-15-143022-db-failover")
+	if err := os.MkdirAll(runDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2024, 1, 15, 14, 30, 22, 0, time.UTC)
+	result := &runner.DrillResult{
+		Scenario:  &config.Scenario{Name: "db-failover", RTOTarget: "5m"},
+		StartTime: start,
+		EndTime:   start.Add(3 * time.Minute),
+		RTA:       2 * time.Minute,
+		RTOTarget: 5 * time.Minute,
+		RTOPassed: true,
+	}
+	content, err := report.GenerateJSONReport(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(runDir, "report.json"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	importReportsDir = t.TempDir()
+	dataDir = t.TempDir()
+	historyURL = ""
+	t.Cleanup(func() { importReportsDir, dataDir = reportsDir, "" })
+
+	if err := importRuns(importCmd, []string{src}); err != nil {
+		t.Fatalf("importRuns: %v", err)
+	}
+
+	store, err := history.Open(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runs, err := store.Runs(history.Filter{Scenario: "db-failover"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("history lists %d db-failover run(s), want 1", len(runs))
+	}
+	want, _ := filepath.Abs(filepath.Join(importReportsDir, filepath.Base(runDir)))
+	if runs[0].ReportPath != want || !runs[0].Passed {
+		t.Errorf("history run = %+v, want a passing run at %s", runs[0], want)
+	}
+}
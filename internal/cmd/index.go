@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/drillmeasure/drillmeasure/internal/report"
+)
+
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Manage the run index of the reports directory",
+	Long: `Manage the index.json file at the top of the reports directory.
+The index summarizes every run (scenario, timings, pass/fail) so other
+tools can discover drill results without opening each report.`,
+}
+
+var indexRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Regenerate index.json from all stored reports",
+	Args:  cobra.NoArgs,
+	RunE:  rebuildIndex,
+}
+
+var indexReportsDir string
+
+func newIndexCmd() *cobra.Command {
+	indexRebuildCmd.Flags().StringVar(&indexReportsDir, "dir", reportsDir, "Reports directory")
+	indexCmd.AddCommand(indexRebuildCmd)
+	return indexCmd
+}
+
+func rebuildIndex(cmd *cobra.Command, args []string) error {
+	count, err := report.RebuildIndex(indexReportsDir)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild index: %w", err)
+	}
+
+	fmt.Printf("✅ Indexed %d run(s) in %s/%s\n", count, indexReportsDir, report.IndexFileName)
+	return nil
+}
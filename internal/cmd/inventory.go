@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/drillmeasure/drillmeasure/internal/inventory"
+	"github.com/drillmeasure/drillmeasure/internal/report"
+)
+
+var inventoryCmd = &cobra.Command{
+	Use:   "inventory",
+	Short: "Track drill coverage against a service inventory",
+	Long: `Import the list of services that should have DR drills and report
+which of them have scenarios, when they were last drilled, and which have
+never been drilled.`,
+}
+
+var inventoryImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a service inventory (CSV, YAML/JSON, or Backstage catalog export)",
+	Args:  cobra.ExactArgs(1),
+	RunE:  importInventory,
+}
+
+var inventoryCoverageCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "Report drill coverage of the imported inventory",
+	Args:  cobra.NoArgs,
+	RunE:  inventoryCoverage,
+}
+
+var (
+	inventoryReportsDir  string
+	inventoryScenarioDir string
+	inventoryOutput      string
+)
+
+func newInventoryCmd() *cobra.Command {
+	inventoryCmd.PersistentFlags().StringVar(&inventoryReportsDir, "dir", reportsDir, "Reports directory")
+	inventoryCoverageCmd.Flags().StringVar(&inventoryScenarioDir, "scenarios", ".", "Directory searched for scenario files")
+	inventoryCoverageCmd.Flags().StringVarP(&inventoryOutput, "output", "o", "", "Write the report to a file instead of stdout")
+	inventoryCmd.AddCommand(inventoryImportCmd)
+	inventoryCmd.AddCommand(inventoryCoverageCmd)
+	return inventoryCmd
+}
+
+func importInventory(cmd *cobra.Command, args []string) error {
+	services, err := inventory.Parse(args[0])
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	inv := &inventory.Inventory{Source: args[0], Services: services}
+	if err := inventory.Save(inventoryReportsDir, inv); err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Imported %d service(s) into %s/%s\n", len(services), inventoryReportsDir, inventory.FileName)
+	return nil
+}
+
+func inventoryCoverage(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	inv, err := inventory.Load(inventoryReportsDir)
+	if err != nil {
+		return err
+	}
+
+	scenarios, err := config.FindScenarios(inventoryScenarioDir)
+	if err != nil {
+		return err
+	}
+
+	coverage, err := report.BuildCoverageReport(inventoryReportsDir, inv, scenarios)
+	if err != nil {
+		return fmt.Errorf("failed to build coverage report: %w", err)
+	}
+
+	content := report.GenerateCoverageMarkdownReport(coverage)
+	if inventoryOutput == "" {
+		fmt.Print(content)
+		return nil
+	}
+
+	if err := os.WriteFile(inventoryOutput, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	fmt.Printf("✅ Wrote coverage of %d service(s) to %s\n", len(coverage.Services), inventoryOutput)
+	return nil
+}
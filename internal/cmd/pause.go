@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// pauseFileName is the control file that holds a running drill before its
+// next phase for as long as it exists
+const pauseFileName = "pause"
+
+var pauseCmd = &cobra.Command{
+	Use:   "pause <run-dir>",
+	Short: "Hold a running drill before its next phase",
+	Long: `Pause the drill writing to run-dir before it enters its next phase, e.g. to
+inspect the system before recovery begins. The step in progress finishes
+first. Time paused while the service is down is excluded from the RTA
+compared against the RTO target, and every pause is shown in the report.`,
+	Args: cobra.ExactArgs(1),
+	RunE: pauseRun,
+}
+
+var continueCmd = &cobra.Command{
+	Use:   "continue <run-dir>",
+	Short: "Continue a paused drill",
+	Args:  cobra.ExactArgs(1),
+	RunE:  continueRun,
+}
+
+func newPauseCmd() *cobra.Command {
+	return pauseCmd
+}
+
+func newContinueCmd() *cobra.Command {
+	return continueCmd
+}
+
+func pauseRun(cmd *cobra.Command, args []string) error {
+	runDir := filepath.Clean(args[0])
+	cmd.SilenceUsage = true
+
+	if _, err := os.Stat(filepath.Join(runDir, checkpointFileName)); err != nil {
+		return fmt.Errorf("%s has no drill in progress", runDir)
+	}
+	if err := writePauseFile(runDir, "drillmeasure pause"); err != nil {
+		return err
+	}
+	fmt.Printf("⏸️  The drill in %s will pause before its next phase\n", runDir)
+	fmt.Printf("Run 'drillmeasure continue %s' to continue\n", runDir)
+	return nil
+}
+
+func continueRun(cmd *cobra.Command, args []string) error {
+	runDir := filepath.Clean(args[0])
+	cmd.SilenceUsage = true
+
+	err := os.Remove(filepath.Join(runDir, pauseFileName))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("the drill in %s is not paused", runDir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to continue: %w", err)
+	}
+	fmt.Printf("▶️  The drill in %s will continue within %s\n", runDir, time.Second)
+	return nil
+}
+
+// writePauseFile records who paused the drill and why
+func writePauseFile(runDir, reason string) error {
+	holder := runner.NewLockHolder("")
+	who := holder.Host
+	if holder.User != "" {
+		who = holder.User + "@" + holder.Host
+	}
+	content := fmt.Sprintf("paused by %s at %s (%s)\n", who, time.Now().UTC().Format(time.RFC3339), reason)
+	if err := os.WriteFile(filepath.Join(runDir, pauseFileName), []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to pause: %w", err)
+	}
+	return nil
+}
+
+// validatePauseBefore checks --pause-before against the drill's phases
+func validatePauseBefore(phases []string) error {
+	known := runner.Phases()
+	for _, phase := range phases {
+		found := false
+		for _, k := range known {
+			found = found || k == phase
+		}
+		if !found {
+			return fmt.Errorf("invalid --pause-before phase %q (expected one of %s)", phase, strings.Join(known, ", "))
+		}
+	}
+	return nil
+}
+
+// pauseControl returns a Hooks.PauseRequested callback that pauses while the
+// pause file exists in outputDir, creating it on reaching each phase in before
+func pauseControl(outputDir string, before []string) func(string) bool {
+	path := filepath.Join(outputDir, pauseFileName)
+	pending := map[string]bool{}
+	for _, phase := range before {
+		pending[phase] = true
+	}
+	// The first poll that finds the file starts the pause; instructions follow
+	// the runner's announcement on the next one
+	polls := 0
+
+	return func(phase string) bool {
+		if pending[phase] {
+			delete(pending, phase)
+			if err := writePauseFile(outputDir, "--pause-before "+phase); err != nil {
+				fmt.Printf("⚠️  %v\n", err)
+			}
+		}
+
+		if _, err := os.Stat(path); err != nil {
+			polls = 0
+			return false
+		}
+		polls++
+		if polls == 2 {
+			fmt.Printf("   Run 'drillmeasure continue %s' (or delete %s) to continue\n", outputDir, path)
+		}
+		return true
+	}
+}
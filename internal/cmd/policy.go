@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// policyQuery is the Rego rule set a scenario must not trigger
+const policyQuery = "data.drillmeasure.deny"
+
+// policyEnv lists default policy paths, separated like PATH
+const policyEnv = "DRILLMEASURE_POLICY"
+
+// policyFlags holds the --policy flag shared by run and validate
+type policyFlags struct {
+	paths []string
+}
+
+func (f *policyFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&f.paths, "policy", nil, "Rego policy file or directory the scenario must satisfy (repeatable; default: $"+policyEnv+")")
+}
+
+func (f *policyFlags) resolve() []string {
+	if len(f.paths) > 0 {
+		return f.paths
+	}
+	var paths []string
+	for _, p := range filepath.SplitList(os.Getenv(policyEnv)) {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// policyViolation is one result of a deny rule: a message, or an object with
+// the message and the rule that produced it
+type policyViolation struct {
+	Msg  string `json:"msg"`
+	Rule string `json:"rule"`
+}
+
+func (v policyViolation) String() string {
+	if v.Rule == "" {
+		return v.Msg
+	}
+	return fmt.Sprintf("[%s] %s", v.Rule, v.Msg)
+}
+
+// check evaluates the scenario against the policies with opa and fails if
+// any deny rule matches
+func (f *policyFlags) check(scenario *config.Scenario) error {
+	paths := f.resolve()
+	if len(paths) == 0 {
+		return nil
+	}
+
+	input, err := policyInput(scenario)
+	if err != nil {
+		return fmt.Errorf("failed to prepare policy input: %w", err)
+	}
+
+	args := []string{"eval", "--format", "json", "--stdin-input"}
+	for _, p := range paths {
+		args = append(args, "--data", p)
+	}
+	args = append(args, policyQuery)
+
+	cmd := exec.Command("opa", args...)
+	cmd.Stdin = bytes.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("policy evaluation failed: %s", msg)
+		}
+		return fmt.Errorf("policy evaluation failed (is opa installed?): %w", err)
+	}
+
+	violations, err := parsePolicyResult(out)
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	lines := make([]string, len(violations))
+	for i, v := range violations {
+		lines[i] = "  - " + v.String()
+	}
+	return fmt.Errorf("scenario violates %d policy rule(s):\n%s", len(violations), strings.Join(lines, "\n"))
+}
+
+// policyInput is the scenario as policies see it: its YAML fields, after
+// variable substitution, with secret values masked
+func policyInput(scenario *config.Scenario) ([]byte, error) {
+	raw, err := yaml.Marshal(scenario)
+	if err != nil {
+		return nil, err
+	}
+	content := string(raw)
+	for _, secret := range scenario.SecretValues() {
+		content = strings.ReplaceAll(content, secret, "********")
+	}
+
+	var input map[string]interface{}
+	if err := yaml.Unmarshal([]byte(content), &input); err != nil {
+		return nil, err
+	}
+	if input == nil {
+		input = map[string]interface{}{}
+	}
+	if hc, ok := input["health_check"].(map[string]interface{}); ok {
+		delete(hc, "token")
+	}
+	input["service"] = scenario.ServiceName()
+	return json.Marshal(input)
+}
+
+// parsePolicyResult reads the deny set from opa eval output
+func parsePolicyResult(out []byte) ([]policyViolation, error) {
+	var result struct {
+		Result []struct {
+			Expressions []struct {
+				Value []json.RawMessage `json:"value"`
+			} `json:"expressions"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse opa output: %w", err)
+	}
+	if len(result.Result) == 0 || len(result.Result[0].Expressions) == 0 {
+		return nil, fmt.Errorf("the policies define no %s rule", policyQuery)
+	}
+
+	var violations []policyViolation
+	for _, raw := range result.Result[0].Expressions[0].Value {
+		var v policyViolation
+		if err := json.Unmarshal(raw, &v.Msg); err != nil {
+			if err := json.Unmarshal(raw, &v); err != nil || v.Msg == "" {
+				v = policyViolation{Msg: string(raw)}
+			}
+		}
+		violations = append(violations, v)
+	}
+	return violations, nil
+}
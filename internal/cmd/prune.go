@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/drillmeasure/drillmeasure/internal/history"
+	"github.com/drillmeasure/drillmeasure/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove old run directories and history rows under a retention policy",
+	Long: `Remove the run directories in the reports directory that a retention policy
+no longer requires, with their rows of the run history. Rows of runs whose
+directories are not in the reports directory, such as runs recorded in a
+shared history by other hosts, are left alone. A run is pruned only
+when it is outside every limit set: it is not among its scenario's
+--keep-last most recent runs, and it started more than --older-than ago
+(a number of days such as 180d, or a duration).
+
+Without flags, the policy comes from --policy (default:
+$DRILLMEASURE_RETENTION), a YAML file with a default rule and per-scenario
+overrides:
+
+  keep_last: 10
+  older_than: 180d
+  scenarios:
+    payments-db-failover:
+      older_than: 730d
+
+Baseline runs are never pruned, nor are runs a reviewer signed off, raised a
+remediation ticket from, or marked with 'annotate --keep'. The run index is
+rebuilt afterwards. Use --dry-run to list what would be removed.`,
+	Args: cobra.NoArgs,
+	RunE: pruneRuns,
+}
+
+var (
+	pruneReportsDir string
+	prunePolicyFile string
+	pruneKeepLast   int
+	pruneOlderThan  string
+	pruneDryRun     bool
+)
+
+func newPruneCmd() *cobra.Command {
+	registerDataDir(pruneCmd)
+	pruneCmd.Flags().StringVar(&pruneReportsDir, "dir", reportsDir, "Reports directory")
+	pruneCmd.Flags().StringVar(&prunePolicyFile, "policy", "", "Retention policy file (default: $"+config.RetentionEnv+")")
+	pruneCmd.Flags().IntVar(&pruneKeepLast, "keep-last", 0, "Always keep this many of each scenario's most recent runs")
+	pruneCmd.Flags().StringVar(&pruneOlderThan, "older-than", "", "Only prune runs older than this, e.g. 180d")
+	pruneCmd.Flags().BoolVar(&pruneDryRun, "dry-run", false, "List the runs that would be pruned without removing them")
+	pruneCmd.MarkFlagsMutuallyExclusive("policy", "keep-last")
+	pruneCmd.MarkFlagsMutuallyExclusive("policy", "older-than")
+	return pruneCmd
+}
+
+// prunableRun is a run directory in the reports directory
+type prunableRun struct {
+	path      string // Absolute run directory
+	scenario  string
+	start     time.Time
+	inHistory bool
+}
+
+func pruneRuns(cmd *cobra.Command, args []string) error {
+	policy, err := retentionPolicy()
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	runs := map[string]*prunableRun{}
+	entries, err := os.ReadDir(pruneReportsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read reports directory: %w", err)
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		runDir := filepath.Join(pruneReportsDir, e.Name())
+		data, err := report.ReadReport(runDir)
+		if err != nil || data.Scenario == nil {
+			// Not a finished run, e.g. one still in progress
+			continue
+		}
+		start, err := time.Parse(time.RFC3339, data.StartTime)
+		if err != nil {
+			continue
+		}
+		path, err := filepath.Abs(runDir)
+		if err != nil {
+			return err
+		}
+		runs[path] = &prunableRun{path: path, scenario: data.Scenario.Name, start: start}
+	}
+
+	// The history is required even to prune directories, since it knows the baselines
+	protected := map[string]bool{}
+	store, err := history.Open(historyLocation())
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	recorded, err := store.Runs(history.Filter{})
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+	for _, r := range recorded {
+		// A row whose directory is not here may be another host's run in a
+		// shared history, so only rows of local directories are pruned
+		if run, ok := runs[r.ReportPath]; ok {
+			run.inHistory = true
+		}
+	}
+	baselines, err := store.Baselines()
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+	for _, b := range baselines {
+		protected[b.ReportPath] = true
+	}
+	for path := range runs {
+		flagged, err := report.Flagged(path)
+		if err != nil {
+			return fmt.Errorf("failed to read annotations of %s: %w", path, err)
+		}
+		if flagged {
+			protected[path] = true
+		}
+	}
+
+	byScenario := map[string][]*prunableRun{}
+	for _, run := range runs {
+		byScenario[run.scenario] = append(byScenario[run.scenario], run)
+	}
+	now := time.Now()
+	var pruned []*prunableRun
+	kept := 0
+	for scenario, scenarioRuns := range byScenario {
+		sort.Slice(scenarioRuns, func(i, j int) bool { return scenarioRuns[i].start.After(scenarioRuns[j].start) })
+		rule := policy.Rule(scenario)
+		for i, run := range scenarioRuns {
+			if i < rule.KeepLast || (rule.OlderThan != "" && run.start.After(now.Add(-rule.GetOlderThan()))) {
+				continue
+			}
+			if protected[run.path] {
+				kept++
+				continue
+			}
+			pruned = append(pruned, run)
+		}
+	}
+	if len(pruned) == 0 {
+		fmt.Println("Nothing to prune")
+		return nil
+	}
+	sort.Slice(pruned, func(i, j int) bool { return pruned[i].start.Before(pruned[j].start) })
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATE\tSCENARIO\tREPORT")
+	for _, run := range pruned {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", run.start.Format(time.RFC3339), run.scenario, run.path)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if pruneDryRun {
+		fmt.Printf("\nWould prune %d run(s); %d flagged or baseline run(s) kept\n", len(pruned), kept)
+		return nil
+	}
+
+	// History rows go only once their directories are gone, so a failed
+	// removal leaves the history describing what is still on disk
+	var paths []string
+	var removeErr error
+	dirs := 0
+	for _, run := range pruned {
+		if err := os.RemoveAll(run.path); err != nil {
+			removeErr = fmt.Errorf("failed to remove %s: %w", run.path, err)
+			break
+		}
+		dirs++
+		if run.inHistory {
+			paths = append(paths, run.path)
+		}
+	}
+	if err := store.DeleteRuns(paths); err != nil {
+		return fmt.Errorf("failed to prune history: %w", err)
+	}
+	if dirs > 0 {
+		if _, err := report.RebuildIndex(pruneReportsDir); err != nil {
+			return fmt.Errorf("failed to rebuild index: %w", err)
+		}
+	}
+	if removeErr != nil {
+		return removeErr
+	}
+
+	fmt.Printf("\n✅ Pruned %d run(s) (%d history rows); %d flagged or baseline run(s) kept\n",
+		len(pruned), len(paths), kept)
+	return nil
+}
+
+// retentionPolicy returns the policy the flags set, or else the policy file's
+func retentionPolicy() (*config.RetentionPolicy, error) {
+	if pruneKeepLast != 0 || pruneOlderThan != "" {
+		policy := &config.RetentionPolicy{RetentionRule: config.RetentionRule{KeepLast: pruneKeepLast, OlderThan: pruneOlderThan}}
+		if err := policy.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid retention flags: %w", err)
+		}
+		return policy, nil
+	}
+
+	path := prunePolicyFile
+	if path == "" {
+		path = os.Getenv(config.RetentionEnv)
+	}
+	if path == "" {
+		return nil, fmt.Errorf("no retention policy: pass --keep-last and/or --older-than, or --policy (or set $%s)", config.RetentionEnv)
+	}
+	return config.LoadRetentionPolicy(path)
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/drillmeasure/drillmeasure/internal/report"
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// repeatSettings returns how many times to run the drill and the pause
+// between runs, from --repeat and --cool-down or the scenario's repeat block
+func repeatSettings(cmd *cobra.Command, scenario *config.Scenario) (int, time.Duration, error) {
+	iterations := 1
+	pause := config.DefaultCoolDown
+	if scenario.Repeat != nil {
+		iterations = scenario.Repeat.Count
+		// Validated with the scenario
+		pause, _ = scenario.Repeat.GetCoolDown()
+	}
+	if cmd.Flags().Changed("repeat") {
+		if repeatCount < 1 {
+			return 0, 0, fmt.Errorf("--repeat must be at least 1")
+		}
+		iterations = repeatCount
+	}
+	if cmd.Flags().Changed("cool-down") {
+		if coolDown < 0 {
+			return 0, 0, fmt.Errorf("--cool-down must not be negative")
+		}
+		pause = coolDown
+	}
+	return iterations, pause, nil
+}
+
+// runRepeated runs the drill iterations times with a cool-down between runs,
+// then writes a summary of the RTA across them. It returns the first failed
+// run's error, and stops early if a run could not complete.
+func runRepeated(scenario *config.Scenario, scenarioPath string, windowOverride bool, iterations int, pause time.Duration) error {
+	var runs []report.RepeatRun
+	var firstErr error
+	for i := 1; i <= iterations; i++ {
+		if i > 1 {
+			fmt.Printf("\nCooling down for %s before run %d of %d...\n", formatDuration(pause), i, iterations)
+			if err := waitCoolDown(pause); err != nil {
+				firstErr = withExitCode(ExitAborted, fmt.Errorf("repeated drill interrupted during cool-down"))
+				break
+			}
+		}
+		fmt.Printf("\n=== Run %d of %d ===\n\n", i, iterations)
+
+		outputDir, result, err := runIteration(scenario, scenarioPath, windowOverride, i, iterations)
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("run %d of %d: %w", i, iterations, err)
+		}
+		if result == nil {
+			break
+		}
+		runs = append(runs, report.RepeatRun{RunDir: filepath.Base(outputDir), Result: result, Passed: err == nil})
+		if result.Incomplete {
+			break
+		}
+	}
+
+	if len(runs) == 0 {
+		return firstErr
+	}
+	summary := report.SummarizeRepeat(scenario.Name, iterations, pause, runs)
+	summaryDir, err := writeRepeatSummary(scenario, summary)
+	if err != nil {
+		return err
+	}
+	if junitPath != "" {
+		results := make([]*runner.DrillResult, len(runs))
+		for i, run := range runs {
+			results[i] = run.Result
+		}
+		if err := writeJUnitReport(junitPath, results...); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("\nRepeated drill: %d of %d run(s), %d passed\n", len(runs), iterations, summary.Passed)
+	if summary.Measured > 0 {
+		fmt.Printf("RTA: min %s, median %s, p95 %s, max %s\n",
+			formatDuration(summary.MinRTA), formatDuration(summary.MedianRTA), formatDuration(summary.P95RTA), formatDuration(summary.MaxRTA))
+	}
+	fmt.Printf("Summary written to: %s\n", summaryDir)
+	return firstErr
+}
+
+// waitCoolDown sleeps between repeated runs, returning an error if the
+// series is interrupted
+func waitCoolDown(d time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// writeRepeatSummary writes the Markdown and JSON summary of a repeated
+// series to its own directory under the reports directory
+func writeRepeatSummary(scenario *config.Scenario, summary *report.RepeatSummary) (string, error) {
+	dir, err := createOutputDirectory(scenario.Name + " repeat")
+	if err != nil {
+		return "", fmt.Errorf("failed to create summary directory: %w", err)
+	}
+
+	md, err := scanReport("summary.md", report.GenerateRepeatMarkdownReport(summary))
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "summary.md"), []byte(md), 0644); err != nil {
+		return "", fmt.Errorf("failed to write repeat summary: %w", err)
+	}
+
+	js, err := report.GenerateRepeatJSONReport(summary)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate repeat summary: %w", err)
+	}
+	js, err = scanReport("summary.json", js)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "summary.json"), []byte(js), 0644); err != nil {
+		return "", fmt.Errorf("failed to write repeat summary: %w", err)
+	}
+	return dir, nil
+}
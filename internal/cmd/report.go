@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/drillmeasure/drillmeasure/internal/report"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report [run-dir]",
+	Short: "Regenerate a run's reports or aggregate stored runs",
+	Long: `Regenerate a run's reports from the drill result stored with it
+(result.json), e.g. to pick up a report fix or add an HTML report, without
+re-running the drill. --format selects the reports to write: md, json, html.
+
+With --anonymize, write a copy of the run's report that can be shared
+externally instead: hostnames, IP addresses, account IDs, command bodies, and
+command output are removed, while timings, output hashes, and pass/fail
+results are kept.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: regenerateReports,
+}
+
+var reportQuarterlyCmd = &cobra.Command{
+	Use:   "quarterly",
+	Short: "Summarize all runs in a period for compliance evidence",
+	Long: `Aggregate every run in the reports directory index started between
+--from and --to (inclusive) into a single Markdown document: coverage per
+service, pass rates, the worst recovery times, and unresolved failures.`,
+	Args: cobra.NoArgs,
+	RunE: generateQuarterlyReport,
+}
+
+var reportBadgesCmd = &cobra.Command{
+	Use:   "badges",
+	Short: "Write or serve an SVG status badge for each scenario",
+	Long: `Write an SVG shield per scenario (e.g. "RTO drill | passing, 3m12s") from
+the latest run in the reports directory index. Publish the output directory,
+or run with --serve to answer GET /badges/<scenario>.svg from the index as it
+stands, to embed drill status in READMEs and dashboards.`,
+	Args: cobra.NoArgs,
+	RunE: generateBadges,
+}
+
+var (
+	reportReportsDir string
+	reportFrom       string
+	reportTo         string
+	reportOutput     string
+	badgesOutputDir  string
+	badgesServe      string
+	anonymize        bool
+	anonymizedDir    string
+	reportFormats    []string
+)
+
+// Report formats report --format can write
+var reportFormatNames = []string{"md", "json", "html"}
+
+func newReportCmd() *cobra.Command {
+	reportCmd.Flags().StringSliceVar(&reportFormats, "format", []string{"md", "json"}, "Reports to write: md, json, html")
+	reportCmd.Flags().BoolVar(&anonymize, "anonymize", false, "Write an anonymized copy of the run's report for external sharing")
+	reportCmd.Flags().StringVarP(&anonymizedDir, "output-dir", "o", "", "Directory for the anonymized report (default: <run-dir>/anonymized)")
+
+	reportQuarterlyCmd.Flags().StringVar(&reportReportsDir, "dir", reportsDir, "Reports directory")
+	reportQuarterlyCmd.Flags().StringVar(&reportFrom, "from", "", "First day of the period (YYYY-MM-DD)")
+	reportQuarterlyCmd.Flags().StringVar(&reportTo, "to", "", "Last day of the period (YYYY-MM-DD)")
+	reportQuarterlyCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "Write the report to a file instead of stdout")
+	reportQuarterlyCmd.MarkFlagRequired("from")
+	reportQuarterlyCmd.MarkFlagRequired("to")
+	reportCmd.AddCommand(reportQuarterlyCmd)
+
+	reportBadgesCmd.Flags().StringVar(&reportReportsDir, "dir", reportsDir, "Reports directory")
+	reportBadgesCmd.Flags().StringVarP(&badgesOutputDir, "output-dir", "o", "", "Badge directory (default: <dir>/badges)")
+	reportBadgesCmd.Flags().StringVar(&badgesServe, "serve", "", "Serve badges over HTTP on this address (e.g. :8080) instead of writing them")
+	reportCmd.AddCommand(reportBadgesCmd)
+	return reportCmd
+}
+
+func generateQuarterlyReport(cmd *cobra.Command, args []string) error {
+	from, err := time.Parse("2006-01-02", reportFrom)
+	if err != nil {
+		return fmt.Errorf("invalid --from date %q (expected YYYY-MM-DD)", reportFrom)
+	}
+	to, err := time.Parse("2006-01-02", reportTo)
+	if err != nil {
+		return fmt.Errorf("invalid --to date %q (expected YYYY-MM-DD)", reportTo)
+	}
+	if to.Before(from) {
+		return fmt.Errorf("--to must not be before --from")
+	}
+	cmd.SilenceUsage = true
+
+	// --to is inclusive, so the period ends at the start of the following day
+	period, err := report.BuildPeriodReport(reportReportsDir, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return fmt.Errorf("failed to aggregate runs: %w", err)
+	}
+
+	content := report.GeneratePeriodMarkdownReport(period)
+	if reportOutput == "" {
+		fmt.Print(content)
+		return nil
+	}
+
+	if err := os.WriteFile(reportOutput, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	fmt.Printf("✅ Summarized %d run(s) in %s\n", period.Runs, reportOutput)
+	return nil
+}
+
+func generateBadges(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+	if badgesServe != "" {
+		return serveBadges(badgesServe)
+	}
+
+	badges, err := report.Badges(reportReportsDir)
+	if err != nil {
+		return fmt.Errorf("failed to read runs: %w", err)
+	}
+
+	outDir := badgesOutputDir
+	if outDir == "" {
+		outDir = filepath.Join(reportReportsDir, "badges")
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create badge directory: %w", err)
+	}
+
+	for scenario, svg := range badges {
+		path := filepath.Join(outDir, sanitizeFileName(scenario)+".svg")
+		if err := os.WriteFile(path, []byte(svg), 0644); err != nil {
+			return fmt.Errorf("failed to write badge: %w", err)
+		}
+	}
+	fmt.Printf("✅ Wrote %d badge(s) to %s\n", len(badges), outDir)
+	return nil
+}
+
+// serveBadges answers GET /badges/<scenario>.svg with the scenario's badge,
+// rendered from the index on every request so it follows new runs, until the
+// process is interrupted
+func serveBadges(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/badges/", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		name, ok := strings.CutSuffix(strings.TrimPrefix(req.URL.Path, "/badges/"), ".svg")
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+
+		badges, err := report.Badges(reportReportsDir)
+		if err != nil {
+			http.Error(w, "failed to read runs", http.StatusInternalServerError)
+			return
+		}
+		for scenario, svg := range badges {
+			if sanitizeFileName(scenario) == name {
+				// Image proxies such as GitHub's must not keep a stale status
+				w.Header().Set("Content-Type", "image/svg+xml")
+				w.Header().Set("Cache-Control", "no-cache, max-age=0")
+				io.WriteString(w, svg)
+				return
+			}
+		}
+		http.NotFound(w, req)
+	})
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	fmt.Printf("Serving badges from %s on http://%s/badges/<scenario>.svg\n", reportReportsDir, listener.Addr())
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("badge server failed: %w", err)
+	}
+	return nil
+}
+
+func regenerateReports(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return cmd.Help()
+	}
+	formats := map[string]bool{}
+	for _, f := range reportFormats {
+		f = strings.ToLower(strings.TrimSpace(f))
+		valid := false
+		for _, name := range reportFormatNames {
+			valid = valid || f == name
+		}
+		if !valid {
+			return fmt.Errorf("invalid --format %q (expected %s)", f, strings.Join(reportFormatNames, ", "))
+		}
+		formats[f] = true
+	}
+	cmd.SilenceUsage = true
+
+	runDir := args[0]
+	if anonymize {
+		return anonymizeRun(runDir, formats)
+	}
+
+	result, err := report.ReadRawResult(runDir)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%s has no %s; runs recorded before results were kept cannot be regenerated", runDir, report.ResultFileName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read run: %w", err)
+	}
+	annotations, err := report.ReadAnnotations(runDir)
+	if err != nil {
+		return fmt.Errorf("failed to read annotations: %w", err)
+	}
+
+	md, err := scanReport("report.md", report.GenerateMarkdownReport(result))
+	if err != nil {
+		return err
+	}
+	md = report.AnnotateMarkdown(md, annotations)
+
+	jsonReport, err := report.GenerateJSONReport(result)
+	if err != nil {
+		return fmt.Errorf("failed to generate JSON report: %w", err)
+	}
+	data, err := report.ParseReport([]byte(jsonReport))
+	if err != nil {
+		return fmt.Errorf("failed to generate JSON report: %w", err)
+	}
+	data.Annotations = annotations
+
+	written, err := writeReportFormats(runDir, formats, data, md)
+	if err != nil {
+		return err
+	}
+	if err := report.RefreshAttestation(runDir); err != nil {
+		return fmt.Errorf("failed to update attestation: %w", err)
+	}
+	if err := report.RefreshManifest(runDir); err != nil {
+		return fmt.Errorf("failed to update manifest: %w", err)
+	}
+	if formats["json"] {
+		if err := report.UpdateIndex(filepath.Dir(runDir), runDir); err != nil {
+			fmt.Printf("⚠️  Failed to update %s/%s: %v\n", filepath.Dir(runDir), report.IndexFileName, err)
+		}
+	}
+	fmt.Printf("✅ Regenerated %s in %s\n", strings.Join(written, ", "), runDir)
+	return nil
+}
+
+func anonymizeRun(runDir string, formats map[string]bool) error {
+	data, err := report.ReadReport(runDir)
+	if err != nil {
+		return fmt.Errorf("failed to read run: %w", err)
+	}
+	report.AnonymizeReport(data)
+
+	outDir := anonymizedDir
+	if outDir == "" {
+		outDir = filepath.Join(runDir, "anonymized")
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if _, err := writeReportFormats(outDir, formats, data, report.GenerateAnonymizedMarkdownReport(data)); err != nil {
+		return err
+	}
+	fmt.Printf("✅ Wrote anonymized report to %s\n", outDir)
+	return nil
+}
+
+// writeReportFormats writes the selected reports to dir and returns their file names
+func writeReportFormats(dir string, formats map[string]bool, data *report.ReportData, md string) ([]string, error) {
+	var written []string
+	if formats["md"] {
+		if err := os.WriteFile(filepath.Join(dir, "report.md"), []byte(md), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write report: %w", err)
+		}
+		written = append(written, "report.md")
+	}
+	if formats["json"] {
+		content, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode report: %w", err)
+		}
+		jsonReport, err := scanReport("report.json", string(content))
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(filepath.Join(dir, "report.json"), []byte(jsonReport), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write report: %w", err)
+		}
+		written = append(written, "report.json")
+	}
+	if formats["html"] {
+		if err := os.WriteFile(filepath.Join(dir, report.HTMLFileName), []byte(report.GenerateHTMLReport(md)), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write report: %w", err)
+		}
+		written = append(written, report.HTMLFileName)
+	}
+	return written, nil
+}
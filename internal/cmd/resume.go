@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/drillmeasure/drillmeasure/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume <run-dir>",
+	Short: "Resume a drill whose controller stopped mid-run",
+	Long: `Continue a drill from the checkpoint.json in its run directory, keeping the
+measurements taken so far and writing the reports into the same directory.
+
+The phase in progress when the controller stopped is repeated, except that
+disrupt_command and the switchback command are never issued again; if the
+recover command had started, it may run a second time. Scenario variables
+set with --set must be passed again; --values defaults to the file the run
+was started with. The scenario file must not have changed since.`,
+	Args: cobra.ExactArgs(1),
+	RunE: resumeRun,
+}
+
+var resumeVariables variableFlags
+
+func newResumeCmd() *cobra.Command {
+	resumeVariables.register(resumeCmd)
+	resumeCmd.Flags().StringVar(&secretScanMode, "secret-scan", "redact", "Scan reports for secrets before writing: redact, fail, or off")
+	resumeCmd.Flags().IntVar(&compressThreshold, "compress-threshold", 256*1024, "Store stdout/stderr larger than this many bytes gzip-compressed in the output directory (0 disables)")
+	resumeCmd.Flags().BoolVar(&forceWindow, "force", false, "Run outside the scenario's allowed_windows if the disruption had not been issued")
+	resumeCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Run the disruption without asking for confirmation if it had not been issued")
+	resumeCmd.Flags().DurationVar(&heartbeat, "heartbeat", 0, "Print a timestamped status line at this interval while waiting for recovery (e.g. 30s, for CI inactivity timeouts)")
+	resumeCmd.Flags().StringSliceVar(&pauseBefore, "pause-before", nil, "Pause before entering these phases (e.g. recover) until 'drillmeasure continue'")
+	resumeCmd.Flags().StringVar(&onInterrupt, "on-interrupt", "recover", "On Ctrl-C or SIGTERM: recover (run recover_command), ask, or skip")
+	registerDataDir(resumeCmd)
+	return resumeCmd
+}
+
+func resumeRun(cmd *cobra.Command, args []string) error {
+	runDir := filepath.Clean(args[0])
+	cmd.SilenceUsage = true
+
+	switch secretScanMode {
+	case "redact", "fail", "off":
+	default:
+		return fmt.Errorf("invalid --secret-scan mode %q (expected redact, fail, or off)", secretScanMode)
+	}
+	switch onInterrupt {
+	case "recover", "ask", "skip":
+	default:
+		return fmt.Errorf("invalid --on-interrupt mode %q (expected recover, ask, or skip)", onInterrupt)
+	}
+	if err := validatePauseBefore(pauseBefore); err != nil {
+		return err
+	}
+
+	state, err := readCheckpoint(runDir)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%s has no %s to resume from (the run completed or was started by an older version)", runDir, checkpointFileName)
+	}
+	if err != nil {
+		return err
+	}
+
+	if resumeVariables.valuesFile == "" {
+		resumeVariables.valuesFile = state.ValuesFile
+	}
+	scenario, err := resumeVariables.loadScenario(state.ScenarioPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse scenario: %w", err)
+	}
+	if err := scenario.Validate(); err != nil {
+		return fmt.Errorf("scenario validation failed: %w", err)
+	}
+	if err := checkAllowlist(scenario, true); err != nil {
+		return err
+	}
+
+	raw, err := os.ReadFile(state.ScenarioPath)
+	if err != nil {
+		return fmt.Errorf("failed to read scenario: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	if hash := hex.EncodeToString(sum[:]); hash != state.ScenarioSHA256 {
+		return fmt.Errorf("scenario %s changed since the run started (sha256 %s, expected %s)", state.ScenarioPath, hash, state.ScenarioSHA256)
+	}
+
+	inputs := []report.InputFile{{Role: "scenario", Path: state.ScenarioPath}}
+	if resumeVariables.valuesFile != "" {
+		inputs = append(inputs, report.InputFile{Role: "values", Path: resumeVariables.valuesFile})
+	}
+	if scenario.Controls != nil {
+		inputs = append(inputs, report.InputFile{Role: "controls", Path: scenario.Controls.Path})
+	}
+	if scenario.SLADefinition != nil {
+		inputs = append(inputs, report.InputFile{Role: "sla", Path: scenario.SLADefinition.Path})
+	}
+	copiedInputs, err := report.CopyInputs(runDir, inputs, scenario.SecretValues())
+	if err != nil {
+		return fmt.Errorf("failed to copy scenario inputs: %w", err)
+	}
+
+	checkpoint := state.Checkpoint
+	if state.MaxRegression != nil {
+		scenario.MaxRTARegression = state.MaxRegression
+	}
+	if !state.Simulated {
+		lock, err := acquireRunLock(scenario)
+		if err != nil {
+			return err
+		}
+		defer lock.Release()
+	}
+
+	if !checkpoint.Disrupted() && !state.Simulated {
+		override, err := checkAllowedWindow(scenario)
+		if err != nil {
+			return err
+		}
+		state.WindowOverride = override
+		if !assumeYes {
+			if err := confirmDisruption(scenario); err != nil {
+				return err
+			}
+		}
+	}
+	fmt.Printf("Resuming scenario: %s\n", scenario.Name)
+	fmt.Printf("Checkpoint: %s during %s\n", checkpoint.Time.Format("2006-01-02 15:04:05 MST"), checkpoint.Phase)
+	if !checkpoint.Disrupted() {
+		fmt.Println("The disruption had not been issued; running the drill from the start")
+	}
+	fmt.Printf("Output directory: %s\n\n", runDir)
+
+	_, err = executeDrill(scenario, runDir, copiedInputs, state, checkpoint)
+	return err
+}
@@ -31,5 +31,21 @@ func init() {
 	rootCmd.AddCommand(newRunCmd())
 	rootCmd.AddCommand(newValidateCmd())
 	rootCmd.AddCommand(newVersionCmd())
+	rootCmd.AddCommand(newIndexCmd())
+	rootCmd.AddCommand(newReportCmd())
+	rootCmd.AddCommand(newInventoryCmd())
+	rootCmd.AddCommand(newImportCmd())
+	rootCmd.AddCommand(newResumeCmd())
+	rootCmd.AddCommand(newPauseCmd())
+	rootCmd.AddCommand(newContinueCmd())
+	rootCmd.AddCommand(newAnnotateCmd())
+	rootCmd.AddCommand(newBundleCmd())
+	rootCmd.AddCommand(newHistoryCmd())
+	rootCmd.AddCommand(newCompareCmd())
+	rootCmd.AddCommand(newTrendCmd())
+	rootCmd.AddCommand(newBaselineCmd())
+	rootCmd.AddCommand(newStatusCmd())
+	rootCmd.AddCommand(newBudgetCmd())
+	rootCmd.AddCommand(newPruneCmd())
 }
 
@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -25,20 +30,80 @@ The tool will:
 5. Execute post-snapshot commands (if present)
 6. Verify RPO (if configured)
 7. Collect factor logs
-8. Generate Markdown and JSON reports`,
+8. Generate Markdown, JSON, and JUnit XML reports`,
 	Args: cobra.ExactArgs(1),
 	RunE: runScenario,
 }
 
+// reportsDir is where timestamped run directories are created
+const reportsDir = "reports"
+
+// locksDir holds file run locks under the reports directory
+const locksDir = ".locks"
+
+var (
+	runVariables      variableFlags
+	runPolicies       policyFlags
+	secretScanMode    string
+	compressThreshold int
+	idempotencyKey    string
+	debugRun          bool
+	onInterrupt       string
+	assumeYes         bool
+	forceWindow       bool
+	simulateRun       bool
+	pauseBefore       []string
+	heartbeat         time.Duration
+	repeatCount       int
+	coolDown          time.Duration
+	junitPath         string
+	metricsFile       string
+	maxRTARegression  float64
+)
+
 func newRunCmd() *cobra.Command {
+	runVariables.register(runCmd)
+	runPolicies.register(runCmd)
+	runCmd.Flags().StringVar(&secretScanMode, "secret-scan", "redact", "Scan reports for secrets before writing: redact, fail, or off")
+	runCmd.Flags().IntVar(&compressThreshold, "compress-threshold", 256*1024, "Store stdout/stderr larger than this many bytes gzip-compressed in the output directory (0 disables)")
+	runCmd.Flags().StringVar(&idempotencyKey, "idempotency-key", "", "Skip execution and return the existing result if a run with this key already exists")
+	runCmd.Flags().BoolVar(&debugRun, "debug", false, "Write an execution trace and environment details to a debug bundle in the output directory")
+	runCmd.Flags().BoolVar(&forceWindow, "force", false, "Run outside the scenario's allowed_windows (recorded in the report)")
+	runCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Run the disruption without asking for confirmation (for CI)")
+	runCmd.Flags().BoolVar(&simulateRun, "simulate", false, "Report the outcomes declared in the scenario's simulate block instead of running any command")
+	runCmd.Flags().DurationVar(&heartbeat, "heartbeat", 0, "Print a timestamped status line at this interval while waiting for recovery (e.g. 30s, for CI inactivity timeouts)")
+	runCmd.Flags().StringSliceVar(&pauseBefore, "pause-before", nil, "Pause before entering these phases (e.g. recover) until 'drillmeasure continue'")
+	runCmd.Flags().StringVar(&onInterrupt, "on-interrupt", "recover", "On Ctrl-C or SIGTERM after the disruption: recover (run recover_command), ask, or skip")
+	runCmd.Flags().IntVar(&repeatCount, "repeat", 0, "Run the drill this many times and summarize RTA across the runs (default: the scenario's repeat.count, or 1)")
+	runCmd.Flags().DurationVar(&coolDown, "cool-down", 0, "Pause between repeated runs (default: the scenario's repeat.cool_down, or 1m)")
+	runCmd.Flags().Float64Var(&maxRTARegression, "max-rta-regression", 0, "Fail with exit code 6 if the RTA exceeds the scenario's baseline run's by more than this percentage (default: the scenario's max_rta_regression)")
+	runCmd.Flags().StringVar(&metricsFile, "metrics-file", "", "Also write the Prometheus metrics to this path, e.g. in node_exporter's textfile collector directory")
+	registerDataDir(runCmd)
+	runCmd.Flags().StringVar(&junitPath, "junit", "", "Also write the JUnit XML report to this path, for CI test summaries (every run of a repeated drill in one file)")
 	return runCmd
 }
 
 func runScenario(cmd *cobra.Command, args []string) error {
 	scenarioPath := args[0]
+	// Arguments are valid; failures from here on are drill outcomes, not usage errors
+	cmd.SilenceUsage = true
 
 	// Parse scenario
-	scenario, err := config.ParseScenario(scenarioPath)
+	switch secretScanMode {
+	case "redact", "fail", "off":
+	default:
+		return fmt.Errorf("invalid --secret-scan mode %q (expected redact, fail, or off)", secretScanMode)
+	}
+	switch onInterrupt {
+	case "recover", "ask", "skip":
+	default:
+		return fmt.Errorf("invalid --on-interrupt mode %q (expected recover, ask, or skip)", onInterrupt)
+	}
+	if err := validatePauseBefore(pauseBefore); err != nil {
+		return err
+	}
+
+	scenario, err := runVariables.loadScenario(scenarioPath)
 	if err != nil {
 		return fmt.Errorf("failed to parse scenario: %w", err)
 	}
@@ -47,72 +112,533 @@ func runScenario(cmd *cobra.Command, args []string) error {
 	if err := scenario.Validate(); err != nil {
 		return fmt.Errorf("scenario validation failed: %w", err)
 	}
+	if err := runPolicies.check(scenario); err != nil {
+		return err
+	}
+	if err := checkAllowlist(scenario, true); err != nil {
+		return err
+	}
+
+	iterations, pause, err := repeatSettings(cmd, scenario)
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("max-rta-regression") {
+		if maxRTARegression < 0 {
+			return fmt.Errorf("--max-rta-regression must not be negative")
+		}
+		scenario.MaxRTARegression = &maxRTARegression
+	}
+	if iterations > 1 && idempotencyKey != "" {
+		return fmt.Errorf("--idempotency-key can't be combined with repeated runs")
+	}
+
+	if idempotencyKey != "" {
+		existingDir, err := lookupIdempotencyKey(idempotencyKey)
+		if err != nil {
+			return err
+		}
+		if existingDir != "" {
+			return replayExistingRun(idempotencyKey, existingDir)
+		}
+	}
+
+	// A simulation disrupts nothing, so it needs no window, lock, or confirmation
+	var windowOverride bool
+	if !simulateRun {
+		windowOverride, err = checkAllowedWindow(scenario)
+		if err != nil {
+			return err
+		}
+
+		lock, err := acquireRunLock(scenario)
+		if err != nil {
+			return err
+		}
+		defer lock.Release()
+
+		if !assumeYes {
+			if err := confirmDisruption(scenario); err != nil {
+				return err
+			}
+		}
+	}
 
 	fmt.Printf("Running scenario: %s\n", scenario.Name)
+	if simulateRun {
+		fmt.Println("🧪 Simulation: commands are not executed; outcomes come from the scenario's simulate block")
+	}
 	if scenario.Description != "" {
 		fmt.Printf("Description: %s\n", scenario.Description)
 	}
 	fmt.Println()
 
+	if iterations > 1 {
+		return runRepeated(scenario, scenarioPath, windowOverride, iterations, pause)
+	}
+	_, result, err := runIteration(scenario, scenarioPath, windowOverride, 0, 0)
+	if junitPath != "" && result != nil {
+		if jerr := writeJUnitReport(junitPath, result); jerr != nil && err == nil {
+			err = jerr
+		}
+	}
+	return err
+}
+
+// runIteration creates an output directory for one run of the drill and
+// executes it; iteration and iterations are zero unless the run is repeated
+func runIteration(scenario *config.Scenario, scenarioPath string, windowOverride bool, iteration, iterations int) (string, *runner.DrillResult, error) {
 	// Create output directory
-	outputDir, err := createOutputDirectory(scenario.Name)
+	dirName := scenario.Name
+	if iterations > 1 {
+		dirName = fmt.Sprintf("%s %d", scenario.Name, iteration)
+	}
+	outputDir, err := createOutputDirectory(dirName)
 	if err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+		return "", nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	if idempotencyKey != "" {
+		if err := claimIdempotencyKey(idempotencyKey, outputDir); err != nil {
+			os.Remove(outputDir)
+			return "", nil, err
+		}
 	}
 
 	fmt.Printf("Output directory: %s\n\n", outputDir)
 
+	// Keep the exact inputs with the evidence so the run can be reproduced
+	inputs := []report.InputFile{{Role: "scenario", Path: scenarioPath}}
+	if runVariables.valuesFile != "" {
+		inputs = append(inputs, report.InputFile{Role: "values", Path: runVariables.valuesFile})
+	}
+	if scenario.Controls != nil {
+		inputs = append(inputs, report.InputFile{Role: "controls", Path: scenario.Controls.Path})
+	}
+	if scenario.SLADefinition != nil {
+		inputs = append(inputs, report.InputFile{Role: "sla", Path: scenario.SLADefinition.Path})
+	}
+	copiedInputs, err := report.CopyInputs(outputDir, inputs, scenario.SecretValues())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to copy scenario inputs: %w", err)
+	}
+
+	state := &runCheckpoint{
+		ScenarioPath:   absPath(scenarioPath),
+		ScenarioSHA256: copiedInputs[0].SourceHash(),
+		ValuesFile:     absPath(runVariables.valuesFile),
+		IdempotencyKey: idempotencyKey,
+		WindowOverride: windowOverride,
+		Simulated:      simulateRun,
+		Iteration:      iteration,
+		Iterations:     iterations,
+		MaxRegression:  scenario.MaxRTARegression,
+	}
+	result, err := executeDrill(scenario, outputDir, copiedInputs, state, nil)
+	return outputDir, result, err
+}
+
+// executeDrill runs or resumes a drill in outputDir, then writes its reports
+// and maps the outcome to an exit code. The result is nil if the drill could
+// not be run at all.
+func executeDrill(scenario *config.Scenario, outputDir string, copiedInputs []report.ManifestFile, state *runCheckpoint, resume *runner.Checkpoint) (*runner.DrillResult, error) {
+	events, err := openEventLog(outputDir)
+	if err != nil {
+		return nil, err
+	}
+	defer events.Close()
+
 	// Create runner and execute
 	r := runner.NewRunner()
-	ctx := context.Background()
+	r.SetHooks(runner.Hooks{
+		OnInterrupt:    confirmInterruptRecovery,
+		OnCheckpoint:   state.saver(outputDir, scenario.SecretValues()),
+		PauseRequested: pauseControl(outputDir, pauseBefore),
+		OnEvent:        events.write,
+	})
+	if state.Simulated {
+		r.EnableSimulation()
+	}
+	r.SetHeartbeat(heartbeat)
+
+	// The first Ctrl-C or SIGTERM cancels the drill so it can recover and
+	// write partial reports; a second one exits immediately
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	var tracer *runner.Tracer
+	if debugRun {
+		tracer = r.EnableTracing()
+	}
 
 	fmt.Println("Starting drill execution...")
-	fmt.Println("(This may take a while - health checks run every 5 seconds until service recovers)")
+	interval := "5 seconds"
+	if scenario.ProbeInterval != "" {
+		interval = scenario.ProbeInterval
+	}
+	fmt.Printf("(This may take a while - health checks run every %s until service recovers)\n", interval)
 	fmt.Println("Note: Terraform operations may take 2-5 minutes. Please be patient...")
-	result, err := r.Run(ctx, scenario)
-	if err != nil {
-		return fmt.Errorf("drill execution failed: %w", err)
+	var result *runner.DrillResult
+	if resume != nil {
+		result, err = r.Resume(ctx, scenario, resume)
+	} else {
+		result, err = r.Run(ctx, scenario)
+	}
+	if tracer != nil {
+		if bundleErr := writeDebugBundle(outputDir, tracer, scenario, err); bundleErr != nil {
+			fmt.Printf("⚠️  Failed to write debug bundle: %v\n", bundleErr)
+		} else {
+			fmt.Printf("Debug bundle written to %s\n", filepath.Join(outputDir, debugDirName))
+		}
 	}
+	if err != nil && result == nil {
+		return nil, fmt.Errorf("drill execution failed: %w", err)
+	}
+
+	result.IdempotencyKey = state.IdempotencyKey
+	result.WindowOverride = state.WindowOverride
+	result.Iteration = state.Iteration
+	result.Iterations = state.Iterations
+	applyBaseline(result, outputDir)
 
 	// Generate reports
 	if err := generateReports(result, outputDir); err != nil {
-		return fmt.Errorf("failed to generate reports: %w", err)
+		return result, fmt.Errorf("failed to generate reports: %w", err)
+	}
+
+	if metricsFile != "" {
+		if result.Simulated {
+			fmt.Printf("Simulated run; not writing %s\n", metricsFile)
+		} else if err := writeMetricsFile(metricsFile, result); err != nil {
+			return result, err
+		}
+	}
+
+	if err := report.WriteManifest(outputDir, copiedInputs); err != nil {
+		return result, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	if err := report.UpdateIndex(filepath.Dir(outputDir), outputDir); err != nil {
+		fmt.Printf("⚠️  Failed to update %s/%s: %v\n", filepath.Dir(outputDir), report.IndexFileName, err)
+	}
+	recordHistory(outputDir)
+
+	// An incomplete run keeps its checkpoint so it can still be resumed
+	if !result.Incomplete {
+		removeCheckpoint(outputDir)
+		os.Remove(filepath.Join(outputDir, pauseFileName))
+	}
+
+	if result.Incomplete {
+		fmt.Printf("\nPartial reports generated in: %s\n", outputDir)
+		if result.Interrupted {
+			return result, withExitCode(ExitAborted, fmt.Errorf("drill interrupted: %w", err))
+		}
+		return result, fmt.Errorf("drill execution failed: %w", err)
 	}
 
 	// Print summary
 	fmt.Println("Drill completed!")
-	if result.RTOStartTime.IsZero() {
+	if result.Simulated {
+		fmt.Println("🧪 Simulated run: no commands were executed")
+	}
+	if result.BackupStale {
+		fmt.Printf("Backup freshness: ❌ FAIL (RPO target: %s) - disruption was not run\n", formatDuration(result.RPOTarget))
+	} else if result.GuardFailed {
+		fmt.Println("Guards: ❌ FAIL - disruption was not run")
+	} else if result.RTOStartTime.IsZero() {
 		// Service never went down
 		fmt.Printf("Result: Disruption did not cause downtime - ✅ PASS (service remained healthy)\n")
 	} else {
-		fmt.Printf("RTA: %s (RTO target: %s) - ", formatDuration(result.RTA), formatDuration(result.RTOTarget))
+		if result.MaxFailedProbes != nil {
+			fmt.Printf("Failed probes: %d (max: %d), RTA: %s - ", result.FailedProbes, *result.MaxFailedProbes, formatDuration(result.RTA))
+		} else {
+			fmt.Printf("RTA: %s (RTO target: %s) - ", formatDuration(result.RTA), formatDuration(result.RTOTarget))
+		}
+		if paused := result.PausedDowntime(); paused > 0 && result.DowntimeGrace > 0 {
+			fmt.Printf("%s counted after %s grace and %s paused - ", formatDuration(result.CountedRTA()), formatDuration(result.DowntimeGrace), formatDuration(paused))
+		} else if paused > 0 {
+			fmt.Printf("%s counted after %s paused - ", formatDuration(result.CountedRTA()), formatDuration(paused))
+		} else if result.DowntimeGrace > 0 {
+			fmt.Printf("%s counted after %s grace - ", formatDuration(result.CountedRTA()), formatDuration(result.DowntimeGrace))
+		}
 		if result.RTOPassed {
 			fmt.Println("✅ PASS")
+		} else if result.Aborted {
+			fmt.Println("❌ ABORTED")
 		} else {
 			fmt.Println("❌ FAIL")
 		}
 	}
 
-	if result.RPOTarget > 0 {
+	if result.BaselineCompared() {
+		printBaselineComparison(result)
+	}
+
+	if parts := breakdownParts(result); len(parts) > 0 {
+		fmt.Printf("Breakdown: %s\n", strings.Join(parts, ", "))
+	}
+
+	if budget, ok := result.ErrorBudget(); ok {
+		fmt.Printf("Error budget: %d/%d probes failed (%.1f%%), ~%.2f user-facing error minutes\n",
+			budget.Failed, budget.Probes, budget.FailedPercent(), budget.ErrorMinutes())
+	}
+
+	for _, s := range result.ProbeLatencyStats() {
+		if s.Span == runner.LatencyAll || s.Span == runner.LatencyAfterRecovery {
+			fmt.Printf("Health check latency (%s): p50 %s, p99 %s, max %s\n",
+				strings.ReplaceAll(s.Span, "_", " "), formatDuration(s.P50), formatDuration(s.P99), formatDuration(s.Max))
+		}
+	}
+
+	if !result.DegradedStartTime.IsZero() {
+		fmt.Printf("Degraded: %s (latency over budget, not counted in RTA)\n", formatDuration(result.DegradedDuration))
+	}
+
+	if result.Scenario.Degraded != nil {
+		checks, durations := result.HealthStateTimes()
+		fmt.Printf("Health states: degraded %s (%d checks), down %s (%d checks); degraded time is not counted in RTA\n",
+			formatDuration(durations[runner.StateDegraded]), checks[runner.StateDegraded],
+			formatDuration(durations[runner.StateDown]), checks[runner.StateDown])
+	}
+
+	if result.Switchback != nil {
+		fmt.Printf("Switchback: %s", formatDuration(result.SwitchbackTime))
+		if result.SwitchbackTarget > 0 {
+			fmt.Printf(" (target: %s)", formatDuration(result.SwitchbackTarget))
+		}
+		if result.SwitchbackPassed {
+			fmt.Println(" - ✅ PASS")
+		} else {
+			fmt.Println(" - ❌ FAIL")
+		}
+	}
+
+	if result.RPOTarget > 0 && !result.OnlyRPOChecks() {
 		fmt.Printf("RPO: ")
+		if result.RPOMeasured() {
+			fmt.Printf("%s data loss (RPO target: %s) - ", formatDuration(result.RPOActual), formatDuration(result.RPOTarget))
+		}
+		if result.MissingRows != nil {
+			fmt.Printf("%d missing row(s) (max: %d) - ", *result.MissingRows, result.MaxMissingRows)
+		}
 		if result.RPOPassed {
 			fmt.Println("✅ PASS")
 		} else {
 			fmt.Println("❌ FAIL")
 		}
+	} else if result.MissingRows != nil {
+		fmt.Printf("Missing rows: %d (max: %d)", *result.MissingRows, result.MaxMissingRows)
+		if result.RPOPassed {
+			fmt.Println(" - ✅ PASS")
+		} else {
+			fmt.Println(" - ❌ FAIL")
+		}
+	}
+
+	if stable := result.DataStableAt(); !stable.IsZero() && result.Disrupt != nil {
+		fmt.Printf("Data stable: %s after the disruption\n", formatDuration(max(stable.Sub(result.Disrupt.Timestamp), 0)))
+	}
+
+	if rc := result.RestoreCheck; rc != nil {
+		fmt.Printf("Restore: %s", formatDuration(rc.RestoreTime))
+		if rc.Target > 0 {
+			fmt.Printf(" (target: %s)", formatDuration(rc.Target))
+		}
+		if rc.Passed {
+			fmt.Println(" - ✅ PASS")
+		} else {
+			fmt.Printf(" - ❌ FAIL (%s)\n", rc.Reason)
+		}
+	}
+
+	if len(result.ConsistencyChecks) > 0 {
+		if result.ConsistencyPassed() {
+			fmt.Printf("Consistency checks: %d passed - ✅ PASS\n", len(result.ConsistencyChecks))
+		} else {
+			fmt.Println("Consistency checks: ❌ FAIL")
+		}
+	}
+
+	for i := range result.Metrics {
+		m := &result.Metrics[i]
+		fmt.Printf("Metric %s: %s → %s", m.Name, formatMetricValue(m.Before), formatMetricValue(m.After))
+		if delta, ok := m.Delta(); ok {
+			fmt.Printf(" (%+g)", delta)
+		}
+		fmt.Println()
+	}
+
+	for _, a := range result.Assertions {
+		switch {
+		case a.Passed:
+			fmt.Printf("Assertion %s: ✅ PASS\n", a.Expression)
+		case a.Error != "":
+			fmt.Printf("Assertion %s: ❌ FAIL (%s)\n", a.Expression, a.Error)
+		default:
+			fmt.Printf("Assertion %s: ❌ FAIL\n", a.Expression)
+		}
+	}
+
+	for _, c := range result.RPOChecks {
+		if c.Passed {
+			fmt.Printf("RPO (%s): ✅ PASS\n", c.Name)
+		} else {
+			fmt.Printf("RPO (%s): ❌ FAIL (%s)\n", c.Name, c.Reason)
+		}
 	}
 
 	fmt.Printf("\nReports generated in: %s\n", outputDir)
+	return result, drillOutcome(result)
+}
+
+// printBaselineComparison prints the RTA relative to the baseline run's
+func printBaselineComparison(result *runner.DrillResult) {
+	b := result.Baseline
+	fmt.Printf("Baseline: RTA %s vs %s (%s)", formatDuration(result.RTA), formatDuration(b.RTA), b.StartTime.Format("2006-01-02"))
+	change, ok := b.RTAChange(result.RTA)
+	if !ok {
+		fmt.Println()
+		return
+	}
+	fmt.Printf(", %+.1f%%", change)
+	switch {
+	case b.MaxRegression == nil:
+		fmt.Println()
+	case result.BaselineRegressed():
+		fmt.Printf(" (max: +%g%%) - ❌ REGRESSED\n", *b.MaxRegression)
+	default:
+		fmt.Printf(" (max: +%g%%) - ✅ PASS\n", *b.MaxRegression)
+	}
+}
+
+// formatMetricValue formats a metric reading for the console
+func formatMetricValue(s *runner.MetricSample) string {
+	if s == nil || s.Value == "" {
+		return "?"
+	}
+	return s.Value
+}
+
+// breakdownParts describes the measurable parts of the outage for the console
+func breakdownParts(result *runner.DrillResult) []string {
+	var parts []string
+	if d, ok := result.TimeToDetect(); ok {
+		parts = append(parts, "detect "+formatDuration(d))
+	}
+	if d, ok := result.RecoveryExecution(); ok {
+		parts = append(parts, "recover "+formatDuration(d))
+	}
+	if d, ok := result.TimeToValidate(); ok {
+		parts = append(parts, "validate "+formatDuration(max(d, 0)))
+	}
+	return parts
+}
+
+// drillOutcome returns the error, with its exit code, that a completed drill ends with
+func drillOutcome(result *runner.DrillResult) error {
+	if result.BackupStale {
+		return withExitCode(ExitRPOFailed, fmt.Errorf("latest backup does not meet the RPO target"))
+	}
+	if result.GuardFailed {
+		return withExitCode(ExitAborted, fmt.Errorf("a guard failed; the disruption was not run"))
+	}
+	if result.Aborted {
+		return withExitCode(ExitRTOFailed, fmt.Errorf("drill aborted: service still down after %s (abort_after)", formatDuration(result.AbortAfter)))
+	}
+	if !result.RTOStartTime.IsZero() && !result.RTOPassed && result.MaxFailedProbes != nil {
+		return withExitCode(ExitRTOFailed, fmt.Errorf("failed probe budget exceeded (%d failed, max: %d)", result.FailedProbes, *result.MaxFailedProbes))
+	}
+	if !result.RTOStartTime.IsZero() && !result.RTOPassed {
+		return withExitCode(ExitRTOFailed, fmt.Errorf("RTO target not met (RTA: %s, target: %s)", formatDuration(result.CountedRTA()), formatDuration(result.RTOTarget)))
+	}
+	if result.RPOTarget > 0 && !result.RPOPassed && result.RPOMeasured() && result.RPOActual > result.RPOTarget {
+		return withExitCode(ExitRPOFailed, fmt.Errorf("RPO target not met (data loss: %s, target: %s)", formatDuration(result.RPOActual), formatDuration(result.RPOTarget)))
+	}
+	if result.MissingRows != nil && *result.MissingRows > result.MaxMissingRows {
+		return withExitCode(ExitRPOFailed, fmt.Errorf("RPO target not met (missing rows: %d, max: %d)", *result.MissingRows, result.MaxMissingRows))
+	}
+	for _, c := range result.RPOChecks {
+		if !c.Passed {
+			return withExitCode(ExitRPOFailed, fmt.Errorf("RPO check %q failed: %s", c.Name, c.Reason))
+		}
+	}
+	for _, c := range result.ConsistencyChecks {
+		if !c.Passed {
+			return withExitCode(ExitRPOFailed, fmt.Errorf("consistency check %q failed: %s", c.Name, c.Reason))
+		}
+	}
+	if rc := result.RestoreCheck; rc != nil && !rc.Passed {
+		if !rc.DataVerified() {
+			return withExitCode(ExitRPOFailed, fmt.Errorf("backup restore check failed: %s", rc.Reason))
+		}
+		return withExitCode(ExitRTOFailed, fmt.Errorf("backup restore too slow (restore time: %s, target: %s)", formatDuration(rc.RestoreTime), formatDuration(rc.Target)))
+	}
+	if (result.RPOTarget > 0 || result.MissingRows != nil) && !result.RPOPassed {
+		return withExitCode(ExitRPOFailed, fmt.Errorf("RPO verification failed"))
+	}
+	if result.Switchback != nil && !result.SwitchbackPassed {
+		return withExitCode(ExitRTOFailed, fmt.Errorf("switchback not completed (switchback time: %s)", formatDuration(result.SwitchbackTime)))
+	}
+	for _, a := range result.Assertions {
+		if !a.Passed {
+			return withExitCode(ExitRTOFailed, fmt.Errorf("assertion failed: %s", a.Expression))
+		}
+	}
+	if result.BaselineRegressed() {
+		change, _ := result.Baseline.RTAChange(result.RTA)
+		return withExitCode(ExitRegressed, fmt.Errorf("RTA regressed %.1f%% past the baseline (RTA: %s, baseline: %s, max: %g%%)",
+			change, formatDuration(result.RTA), formatDuration(result.Baseline.RTA), *result.Baseline.MaxRegression))
+	}
 
 	return nil
 }
 
+// acquireRunLock takes the scenario's run lock, refusing to start while
+// another drill against the same service is running
+func acquireRunLock(scenario *config.Scenario) (*runner.RunLock, error) {
+	lock, err := runner.AcquireLock(context.Background(), scenario, filepath.Join(reportsDir, locksDir), runner.NewLockHolder(scenario.Name))
+	var locked *runner.LockedError
+	if errors.As(err, &locked) {
+		return nil, withExitCode(ExitAborted, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire run lock: %w", err)
+	}
+	return lock, nil
+}
+
+// confirmInterruptRecovery decides, per --on-interrupt, whether an interrupted
+// drill runs recover_command
+func confirmInterruptRecovery(phase string) bool {
+	switch onInterrupt {
+	case "skip":
+		fmt.Println("Skipping recover_command (--on-interrupt skip); the environment may still be disrupted")
+		return false
+	case "ask":
+		fmt.Print("Run recover_command now? [Y/n] ")
+		answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && answer == "" {
+			fmt.Println()
+			return false
+		}
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		return answer == "" || answer == "y" || answer == "yes"
+	default:
+		return true
+	}
+}
+
 // createOutputDirectory creates a timestamped output directory
 func createOutputDirectory(scenarioName string) (string, error) {
 	timestamp := time.Now().Format("2006-01-02-150405")
 	safeName := sanitizeFileName(scenarioName)
 	dirName := fmt.Sprintf("%s-%s", timestamp, safeName)
-	outputDir := filepath.Join("reports", dirName)
+	outputDir := filepath.Join(reportsDir, dirName)
 
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return "", err
@@ -147,8 +673,43 @@ func formatDuration(d time.Duration) string {
 
 // generateReports creates both Markdown and JSON reports
 func generateReports(result *runner.DrillResult, outputDir string) error {
+	if err := report.TruncateOutputs(result, outputDir, scanReport); err != nil {
+		return fmt.Errorf("failed to store command outputs: %w", err)
+	}
+	if err := report.CompressLargeOutputs(result, outputDir, compressThreshold, scanReport); err != nil {
+		return fmt.Errorf("failed to store command outputs: %w", err)
+	}
+	if err := report.CollectArtifacts(result, outputDir); err != nil {
+		return fmt.Errorf("failed to collect artifacts: %w", err)
+	}
+	if err := report.WriteSystemMetrics(result, outputDir); err != nil {
+		return err
+	}
+	if err := report.WriteCSVExports(result, outputDir); err != nil {
+		return err
+	}
+	if err := report.WriteHealthChart(result, outputDir); err != nil {
+		return err
+	}
+
+	// Keep the raw result so reports can be regenerated without re-running
+	rawResult, err := report.GenerateRawResult(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode drill result: %w", err)
+	}
+	rawResult, err = scanReport(report.ResultFileName, rawResult)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, report.ResultFileName), []byte(rawResult), 0644); err != nil {
+		return fmt.Errorf("failed to write drill result: %w", err)
+	}
+
 	// Generate Markdown report
-	mdReport := report.GenerateMarkdownReport(result)
+	mdReport, err := scanReport("report.md", report.GenerateMarkdownReport(result))
+	if err != nil {
+		return err
+	}
 	mdPath := fmt.Sprintf("%s/report.md", outputDir)
 	if err := os.WriteFile(mdPath, []byte(mdReport), 0644); err != nil {
 		return fmt.Errorf("failed to write markdown report: %w", err)
@@ -159,11 +720,85 @@ func generateReports(result *runner.DrillResult, outputDir string) error {
 	if err != nil {
 		return fmt.Errorf("failed to generate JSON report: %w", err)
 	}
+	jsonReport, err = scanReport("report.json", jsonReport)
+	if err != nil {
+		return err
+	}
 	jsonPath := fmt.Sprintf("%s/report.json", outputDir)
 	if err := os.WriteFile(jsonPath, []byte(jsonReport), 0644); err != nil {
 		return fmt.Errorf("failed to write JSON report: %w", err)
 	}
 
+	// Generate Prometheus metrics
+	promPath := filepath.Join(outputDir, report.PromFileName)
+	if err := os.WriteFile(promPath, []byte(report.GeneratePromMetrics(result)), 0644); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+
+	// Generate JUnit XML report
+	if err := writeJUnitReport(filepath.Join(outputDir, report.JUnitFileName), result); err != nil {
+		return err
+	}
+
+	// Generate the in-toto attestation last, as it hashes the reports
+	if err := report.WriteAttestation(result, outputDir, version); err != nil {
+		return fmt.Errorf("failed to write attestation: %w", err)
+	}
+
+	return nil
+}
+
+// writeJUnitReport writes the JUnit XML report of one or more runs to path
+func writeJUnitReport(path string, results ...*runner.DrillResult) error {
+	junit, err := report.GenerateJUnitReport(results...)
+	if err != nil {
+		return fmt.Errorf("failed to generate JUnit report: %w", err)
+	}
+	junit, err = scanReport(filepath.Base(path), junit)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(junit), 0644); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return nil
+}
+
+// writeMetricsFile replaces path with the drill's Prometheus metrics. The
+// file is renamed into place so the textfile collector never reads it half
+// written.
+func writeMetricsFile(path string, result *runner.DrillResult) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(report.GeneratePromMetrics(result)), 0644); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
 	return nil
 }
 
+// scanReport checks report content for likely secrets according to --secret-scan
+func scanReport(name, content string) (string, error) {
+	if secretScanMode == "off" {
+		return content, nil
+	}
+
+	redacted, findings := report.RedactSecrets(content)
+	if len(findings) == 0 {
+		return content, nil
+	}
+
+	for _, f := range findings {
+		fmt.Printf("⚠️  Possible secret in %s (line %d, rule %s)\n", name, f.Line, f.Rule)
+	}
+
+	if secretScanMode == "fail" {
+		return "", fmt.Errorf("%d possible secret(s) found in %s; not writing report (use --secret-scan=redact to redact them)", len(findings), name)
+	}
+
+	fmt.Printf("⚠️  Redacted %d possible secret(s) in %s\n", len(findings), name)
+	return redacted, nil
+}
+
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/drillmeasure/drillmeasure/internal/history"
+	"github.com/spf13/cobra"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show when each scenario was last drilled and which are overdue",
+	Long: `List the scenarios found under --scenarios with when each was last drilled,
+according to the run history, and when its next drill is due under its
+frequency (e.g. frequency: quarterly). Scenarios that are past due or have
+never been drilled are listed first, as overdue or never drilled. Simulated
+and incomplete runs don't count as drills; failed ones do, with their result
+shown.
+
+With --slas (default: $DRILLMEASURE_SLAS), the SLAs in the registry are
+listed too, with the scenarios that validate each and when one last did;
+SLAs no drill has validated are reported as never drilled.`,
+	Args: cobra.NoArgs,
+	RunE: showStatus,
+}
+
+var (
+	statusScenarioDir string
+	statusOverdue     bool
+	statusSLAFile     string
+)
+
+func newStatusCmd() *cobra.Command {
+	registerDataDir(statusCmd)
+	statusCmd.Flags().StringVar(&statusScenarioDir, "scenarios", ".", "Directory searched for scenario files")
+	statusCmd.Flags().BoolVar(&statusOverdue, "overdue", false, "Only list scenarios that are overdue or have never been drilled")
+	statusCmd.Flags().StringVar(&statusSLAFile, "slas", "", "SLA registry to list the drill status of (default: $"+config.SLAsEnv+")")
+	return statusCmd
+}
+
+// statusOrder sorts the scenarios needing a drill first
+var statusOrder = map[string]int{
+	history.StatusOverdue:      0,
+	history.StatusNeverDrilled: 1,
+	history.StatusOK:           2,
+	history.StatusNoFrequency:  3,
+}
+
+func showStatus(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	found, err := config.FindScenarios(statusScenarioDir)
+	if err != nil {
+		return err
+	}
+	// The history is kept by scenario name, so a scenario in several files is listed once
+	var scenarios []*config.Scenario
+	seen := map[string]bool{}
+	for _, f := range found {
+		if !seen[f.Scenario.Name] {
+			seen[f.Scenario.Name] = true
+			scenarios = append(scenarios, f.Scenario)
+		}
+	}
+	if len(scenarios) == 0 {
+		fmt.Printf("No scenarios found in %s\n", statusScenarioDir)
+		return nil
+	}
+
+	store, err := history.Open(historyLocation())
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	now := time.Now()
+	statuses, err := store.Status(scenarios, now)
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statusOrder[statuses[i].Status] < statusOrder[statuses[j].Status]
+	})
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SCENARIO\tFREQUENCY\tLAST DRILL\tRESULT\tNEXT DUE\tSTATUS")
+	needed := 0
+	for _, st := range statuses {
+		overdue := st.Status == history.StatusOverdue || st.Status == history.StatusNeverDrilled
+		if overdue {
+			needed++
+		}
+		if statusOverdue && !overdue {
+			continue
+		}
+		frequency, lastDrill, result, due := st.Scenario.Frequency, "never", "-", "-"
+		if frequency == "" {
+			frequency = "-"
+		}
+		if st.LastDrill != nil {
+			lastDrill, result = st.LastDrill.StartTime, st.LastDrill.Result()
+		}
+		if !st.Due.IsZero() {
+			due = st.Due.Format("2006-01-02")
+		}
+		status := st.Status
+		if st.Status == history.StatusOverdue {
+			status = fmt.Sprintf("%s by %d days", status, int(now.Sub(st.Due).Hours()/24))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", st.Scenario.Name, frequency, lastDrill, result, due, status)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if needed > 0 {
+		fmt.Printf("\n⚠️  %d scenario(s) overdue or never drilled\n", needed)
+	}
+
+	slaFile := statusSLAFile
+	if slaFile == "" {
+		slaFile = os.Getenv(config.SLAsEnv)
+	}
+	if slaFile == "" {
+		return nil
+	}
+	registry, err := config.LoadSLARegistry(slaFile)
+	if err != nil {
+		return err
+	}
+	return showSLAStatus(store, registry, scenarios)
+}
+
+// showSLAStatus lists the SLAs in the registry with when a drill last
+// validated each
+func showSLAStatus(store *history.Store, registry *config.SLARegistry, scenarios []*config.Scenario) error {
+	statuses, err := store.SLAStatus(registry, scenarios)
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SLA\tSERVICE\tRTO\tRPO\tSCENARIOS\tLAST DRILL\tRESULT")
+	never := 0
+	for _, st := range statuses {
+		if st.LastDrill == nil {
+			never++
+		} else if statusOverdue {
+			continue
+		}
+		service, rpo, drilledBy, lastDrill, result := st.SLA.Service, st.SLA.RPO, "-", "never", "-"
+		if service == "" {
+			service = "-"
+		}
+		if rpo == "" {
+			rpo = "-"
+		}
+		if len(st.Scenarios) > 0 {
+			drilledBy = strings.Join(st.Scenarios, ",")
+		}
+		if st.LastDrill != nil {
+			lastDrill, result = st.LastDrill.StartTime, st.LastDrill.Result()
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", st.SLA.ID, service, st.SLA.RTO, rpo, drilledBy, lastDrill, result)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if never > 0 {
+		fmt.Printf("\n⚠️  %d SLA(s) never drilled\n", never)
+	}
+	return nil
+}
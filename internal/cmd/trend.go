@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/history"
+	"github.com/drillmeasure/drillmeasure/internal/report"
+	"github.com/spf13/cobra"
+)
+
+var trendCmd = &cobra.Command{
+	Use:   "trend <scenario>",
+	Short: "Show the RTA trend across a scenario's runs",
+	Long: `Show the RTA of every run of a scenario in the run history, oldest first,
+as a sparkline and a table, with a line fitted through it. A trend is
+flagged as worsening when the fitted RTA grew by 10% or more, even while the
+runs still meet their RTO target, with how many more runs it takes to exceed
+the target at that rate. Simulated and incomplete runs are left out.
+
+Use --html to also write the trend as an HTML page with a chart.`,
+	Args: cobra.ExactArgs(1),
+	RunE: showTrend,
+}
+
+var (
+	trendLimit int
+	trendHTML  string
+)
+
+func newTrendCmd() *cobra.Command {
+	registerDataDir(trendCmd)
+	trendCmd.Flags().IntVarP(&trendLimit, "limit", "n", 0, "Only include this many of the most recent runs (0 for all)")
+	trendCmd.Flags().StringVar(&trendHTML, "html", "", "Also write the trend, with a chart, as an HTML page to this file")
+	return trendCmd
+}
+
+func showTrend(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	store, err := history.Open(historyLocation())
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	runs, err := store.Runs(history.Filter{Scenario: args[0]})
+	if err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+
+	// Runs come most recent first; the trend is oldest first
+	var trendRuns []report.TrendRun
+	var recorded []history.Run
+	skipped := 0
+	for i := len(runs) - 1; i >= 0; i-- {
+		r := runs[i]
+		start, errStart := time.Parse(time.RFC3339, r.StartTime)
+		rta, errRTA := time.ParseDuration(r.RTA)
+		if r.Simulated || r.Incomplete || errStart != nil || errRTA != nil {
+			skipped++
+			continue
+		}
+		target, _ := time.ParseDuration(r.RTOTarget)
+		trendRuns = append(trendRuns, report.TrendRun{
+			StartTime:  start,
+			RTA:        rta,
+			RTOTarget:  target,
+			Passed:     r.Passed,
+			ReportPath: r.ReportPath,
+		})
+		recorded = append(recorded, r)
+	}
+	if trendLimit > 0 && len(trendRuns) > trendLimit {
+		trendRuns = trendRuns[len(trendRuns)-trendLimit:]
+		recorded = recorded[len(recorded)-trendLimit:]
+	}
+	if len(trendRuns) == 0 {
+		fmt.Printf("No measured runs of %s in %s\n", args[0], store.Path())
+		return nil
+	}
+
+	trend := report.AnalyzeTrend(args[0], trendRuns)
+	fmt.Printf("RTA trend for %s (%d runs", args[0], len(trendRuns))
+	if skipped > 0 {
+		fmt.Printf(", %d simulated or incomplete left out", skipped)
+	}
+	fmt.Printf(")\n\n  %s\n\n%s\n\n", trend.Sparkline(), trend.Summary())
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tDATE\tRTA\tRTO TARGET\tRESULT\tREPORT")
+	for i, r := range recorded {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", i+1, r.StartTime, r.RTA, r.RTOTarget, r.Result(), r.ReportPath)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if trendHTML != "" {
+		if err := os.WriteFile(trendHTML, []byte(report.GenerateTrendHTMLReport(trend)), 0644); err != nil {
+			return fmt.Errorf("failed to write trend: %w", err)
+		}
+		fmt.Printf("\n✅ Wrote trend chart to %s\n", trendHTML)
+	}
+	return nil
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-	"github.com/drillmeasure/drillmeasure/internal/config"
 )
 
 var validateCmd = &cobra.Command{
@@ -14,12 +13,21 @@ var validateCmd = &cobra.Command{
 This command checks:
 - YAML syntax validity
 - Presence of required fields
-- Valid duration formats for RTO, RPO, and delays`,
+- Valid duration formats for RTO, RPO, and delays
+- Compliance with Rego policies given with --policy (requires opa)
+- Commands permitted by the command allowlist, if one is configured`,
 	Args: cobra.ExactArgs(1),
 	RunE: validateScenario,
 }
 
+var (
+	validateVariables variableFlags
+	validatePolicies  policyFlags
+)
+
 func newValidateCmd() *cobra.Command {
+	validateVariables.register(validateCmd)
+	validatePolicies.register(validateCmd)
 	return validateCmd
 }
 
@@ -27,7 +35,7 @@ func validateScenario(cmd *cobra.Command, args []string) error {
 	scenarioPath := args[0]
 
 	// Parse scenario
-	scenario, err := config.ParseScenario(scenarioPath)
+	scenario, err := validateVariables.loadScenario(scenarioPath)
 	if err != nil {
 		return fmt.Errorf("validation failed: %w", err)
 	}
@@ -37,12 +45,36 @@ func validateScenario(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("validation failed: %w", err)
 	}
 
+	if err := validatePolicies.check(scenario); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	if err := checkAllowlist(scenario, false); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
 	fmt.Printf("✅ Scenario file is valid: %s\n", scenarioPath)
 	fmt.Printf("   Name: %s\n", scenario.Name)
-	fmt.Printf("   RTO Target: %s\n", scenario.RTOTarget)
+	if scenario.SLADefinition != nil {
+		fmt.Printf("   SLA: %s (from %s)\n", scenario.SLA, scenario.SLADefinition.Path)
+	}
+	if scenario.RTOTarget != "" {
+		fmt.Printf("   RTO Target: %s\n", scenario.RTOTarget)
+	}
+	if len(scenario.AllowedWindows) > 0 {
+		fmt.Printf("   Allowed Windows: %s\n", scenario.DescribeAllowedWindows())
+	}
+	if scenario.TargetSource != nil {
+		fmt.Printf("   Target Source: %s (resolved at run time)\n", scenario.TargetSource)
+	}
+	if scenario.HealthCheck != nil {
+		fmt.Printf("   Health Check: built-in %s probe\n", scenario.HealthCheck.Type)
+	}
 	if scenario.RPOTarget != "" {
 		fmt.Printf("   RPO Target: %s\n", scenario.RPOTarget)
 	}
+	for _, account := range scenario.CloudAccounts() {
+		fmt.Printf("   %s\n", account)
+	}
 
 	return nil
 }
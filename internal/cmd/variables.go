@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// variableFlags holds the --set and --values flags shared by run and validate
+type variableFlags struct {
+	set        []string
+	valuesFile string
+}
+
+func (f *variableFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&f.set, "set", nil, "Set a scenario variable (name=value, repeatable)")
+	cmd.Flags().StringVar(&f.valuesFile, "values", "", "YAML file of scenario variable values")
+}
+
+// resolve merges the values file with --set overrides, which take precedence
+func (f *variableFlags) resolve() (map[string]string, error) {
+	values := map[string]string{}
+
+	if f.valuesFile != "" {
+		fileValues, err := config.ParseValuesFile(f.valuesFile)
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range fileValues {
+			values[name] = value
+		}
+	}
+
+	for _, kv := range f.set {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --set value %q (expected name=value)", kv)
+		}
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+// loadScenario parses a scenario file with variables resolved from the flags
+func (f *variableFlags) loadScenario(scenarioPath string) (*config.Scenario, error) {
+	values, err := f.resolve()
+	if err != nil {
+		return nil, err
+	}
+	return config.ParseScenarioWithVariables(scenarioPath, values)
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Allowlist restricts which commands a drill may run, for teams that let
+// less-privileged operators run drills
+type Allowlist struct {
+	Prefixes []string `yaml:"prefixes,omitempty"` // Commands starting with one of these are allowed, if the rest has no shell control characters
+	Patterns []string `yaml:"patterns,omitempty"` // Regexes a whole command may match
+	Shells   []string `yaml:"shells,omitempty"`   // Shells a scenario may select besides the target's default: bash or sh
+	RunAs    []string `yaml:"run_as,omitempty"`   // Users commands may run as via sudo
+	Targets  []string `yaml:"targets,omitempty"`  // Remote execution targets, as type:target (see Execution.AllowlistTarget)
+	Log      string   `yaml:"log,omitempty"`      // File violations are appended to
+
+	patterns []*regexp.Regexp
+}
+
+// shellControl matches characters that could chain or redirect another
+// command after an allowed prefix
+var shellControl = regexp.MustCompile("[;&|`$<>\\n\\r()]")
+
+// allowlistShells are the shells whose syntax shellControl covers; other
+// interpreters cannot be permitted because an allowed prefix could be followed
+// by code in their own syntax
+var allowlistShells = map[string]bool{"bash": true, "sh": true}
+
+// ParseAllowlist reads a command allowlist file
+func ParseAllowlist(path string) (*Allowlist, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read allowlist: %w", err)
+	}
+
+	var a Allowlist
+	if err := yaml.Unmarshal(data, &a); err != nil {
+		return nil, fmt.Errorf("failed to parse allowlist %s: %w", path, err)
+	}
+	for _, p := range a.Patterns {
+		re, err := regexp.Compile("^(?:" + p + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowlist pattern %q: %w", p, err)
+		}
+		a.patterns = append(a.patterns, re)
+	}
+	for _, name := range a.Shells {
+		if !allowlistShells[name] {
+			return nil, fmt.Errorf("allowlist %s permits shell %q; only bash and sh can be checked against prefixes", path, name)
+		}
+	}
+	return &a, nil
+}
+
+// Allows reports whether command may run. Patterns must match the whole
+// command; a prefix match is rejected if the remainder could run something else.
+func (a *Allowlist) Allows(command string) bool {
+	for _, re := range a.patterns {
+		if re.MatchString(command) {
+			return true
+		}
+	}
+	for _, prefix := range a.Prefixes {
+		if strings.HasPrefix(command, prefix) && !shellControl.MatchString(command[len(prefix):]) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsShell reports whether prefix is the argv of a shell the allowlist
+// permits. An argv shell must match a permitted shell exactly.
+func (a *Allowlist) AllowsShell(prefix []string) bool {
+	for _, name := range a.Shells {
+		if slices.Equal(prefix, shellArgs[name]) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsRunAs reports whether commands may run as user
+func (a *Allowlist) AllowsRunAs(user string) bool {
+	return slices.Contains(a.RunAs, user)
+}
+
+// AllowsExecution reports whether commands may run on the execution target
+func (a *Allowlist) AllowsExecution(e *Execution) bool {
+	return slices.Contains(a.Targets, e.AllowlistTarget())
+}
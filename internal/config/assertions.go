@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AssertionVariables are the values an assertion can read, with what each
+// one means. Durations are in seconds; compare them with duration("5m").
+var AssertionVariables = map[string]string{
+	"rta":                "Downtime counted against the RTO target",
+	"rto_target":         "RTO target",
+	"rto_passed":         "Whether the RTO target was met",
+	"went_down":          "Whether the disruption caused downtime",
+	"failed_probes":      "Failed health checks during the outage",
+	"failed_percent":     "Share of health checks that failed from the disruption to recovery",
+	"error_minutes":      "Estimated user-facing error minutes",
+	"probe_latency":      "Health check latency p50, p90, p99, and max, by span: all, before_downtime, downtime, after_recovery",
+	"time_to_detect":     "Disruption to the first failed health check",
+	"recovery_execution": "How long recover_command ran",
+	"time_to_validate":   "recover_command finishing to the first healthy check",
+	"degraded":           "Time healthy but over the latency budget",
+	"time_degraded":      "Time health checks found the service degraded, with a degraded block",
+	"rpo_actual":         "Measured data loss",
+	"rpo_target":         "RPO target",
+	"rpo_passed":         "Whether RPO verification passed",
+	"missing_rows":       "Missing rows printed by verify_command",
+	"switchback_time":    "Time to fail back to the original primary",
+	"aborted":            "Whether the drill aborted",
+	"errors":             "Number of errors recorded during the drill",
+	"metrics":            "Custom metrics after recovery, by name",
+	"metrics_before":     "Custom metrics before the disruption, by name",
+	"metrics_change":     "Change in each numeric custom metric, by name",
+}
+
+func (s *Scenario) validateAssertions() error {
+	metrics := map[string]bool{}
+	for _, m := range s.Metrics {
+		metrics[m.Name] = true
+	}
+	for i, source := range s.Assertions {
+		field := fmt.Sprintf("assertions[%d]", i)
+		if strings.TrimSpace(source) == "" {
+			return fmt.Errorf("'%s' is empty", field)
+		}
+		expr, err := ParseExpression(source)
+		if err != nil {
+			return fmt.Errorf("invalid '%s': %w", field, err)
+		}
+		// Catch typos before the drill runs rather than in the report
+		for _, path := range expr.Variables() {
+			root, rest, _ := strings.Cut(path, ".")
+			if _, ok := AssertionVariables[root]; !ok {
+				return fmt.Errorf("'%s' reads unknown variable %q", field, root)
+			}
+			if strings.HasPrefix(root, "metrics") && rest != "" {
+				name, _, _ := strings.Cut(rest, ".")
+				if !metrics[name] {
+					return fmt.Errorf("'%s' reads metric %q, which is not in 'metrics'", field, name)
+				}
+			}
+		}
+	}
+	return nil
+}
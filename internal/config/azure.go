@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Built-in Azure actions for scenario steps
+const (
+	AzureVMDeallocate     = "vm_deallocate"
+	AzureVMRestart        = "vm_restart"
+	AzureVMStart          = "vm_start"
+	AzureSQLFailoverGroup = "sql_failover_group"
+	AzureMonitorMetrics   = "monitor_metrics"
+	AzureActivityLog      = "activity_log"
+)
+
+// AzureAction is a built-in Azure disruptor, recovery step, or factor collector
+type AzureAction struct {
+	Action          string `yaml:"action" json:"action"`
+	Subscription    string `yaml:"subscription,omitempty" json:"subscription,omitempty"`         // Default: the az CLI's active subscription
+	ResourceGroup   string `yaml:"resource_group,omitempty" json:"resource_group,omitempty"`     // vm_*, sql_failover_group, activity_log
+	Name            string `yaml:"name,omitempty" json:"name,omitempty"`                         // vm_*: VM name; sql_failover_group: Failover group name
+	Server          string `yaml:"server,omitempty" json:"server,omitempty"`                     // sql_failover_group: Secondary server to promote
+	Resource        string `yaml:"resource,omitempty" json:"resource,omitempty"`                 // monitor_metrics: Resource ID
+	Metric          string `yaml:"metric,omitempty" json:"metric,omitempty"`                     // monitor_metrics: Metric name
+	Window          string `yaml:"window,omitempty" json:"window,omitempty"`                     // monitor_metrics, activity_log: Look-back period (default: 1h)
+	ManagedIdentity bool   `yaml:"managed_identity,omitempty" json:"managed_identity,omitempty"` // Log in with the host's managed identity first
+	ClientID        string `yaml:"client_id,omitempty" json:"client_id,omitempty"`               // Client ID of a user-assigned managed identity
+}
+
+// validate checks the fields required by the action
+func (a *AzureAction) validate() error {
+	switch a.Action {
+	case AzureVMDeallocate, AzureVMRestart, AzureVMStart:
+		if a.ResourceGroup == "" || a.Name == "" {
+			return fmt.Errorf("action %q requires 'resource_group' and 'name'", a.Action)
+		}
+	case AzureSQLFailoverGroup:
+		if a.ResourceGroup == "" || a.Server == "" || a.Name == "" {
+			return fmt.Errorf("action %q requires 'resource_group', 'server', and 'name'", a.Action)
+		}
+	case AzureMonitorMetrics:
+		if a.Resource == "" || a.Metric == "" {
+			return fmt.Errorf("action %q requires 'resource' and 'metric'", a.Action)
+		}
+	case AzureActivityLog:
+		if a.ResourceGroup == "" {
+			return fmt.Errorf("action %q requires 'resource_group'", a.Action)
+		}
+	case "":
+		return fmt.Errorf("required field 'action' is missing")
+	default:
+		return fmt.Errorf("unknown action %q", a.Action)
+	}
+
+	if a.Window != "" {
+		if _, err := time.ParseDuration(a.Window); err != nil {
+			return fmt.Errorf("invalid 'window' duration: %w", err)
+		}
+	}
+	if a.ClientID != "" && !a.ManagedIdentity {
+		return fmt.Errorf("'client_id' requires 'managed_identity: true'")
+	}
+	return nil
+}
+
+// GetWindow returns the look-back period, defaulting to one hour
+func (a *AzureAction) GetWindow() time.Duration {
+	if a.Window == "" {
+		return time.Hour
+	}
+	d, _ := time.ParseDuration(a.Window)
+	return d
+}
@@ -0,0 +1,52 @@
+package config
+
+// CloudEnv returns the environment variables that pin commands to the
+// scenario's cloud accounts, so a drill cannot run against whatever account
+// the operator's shell happens to be using
+func (s *Scenario) CloudEnv() []string {
+	var env []string
+	if s.AWSProfile != "" {
+		env = append(env, "AWS_PROFILE="+s.AWSProfile)
+	}
+	if s.GCloudProject != "" {
+		env = append(env, "CLOUDSDK_CORE_PROJECT="+s.GCloudProject, "GOOGLE_CLOUD_PROJECT="+s.GCloudProject)
+	}
+	if s.AzureSubscription != "" {
+		env = append(env, "AZURE_SUBSCRIPTION_ID="+s.AzureSubscription, "ARM_SUBSCRIPTION_ID="+s.AzureSubscription)
+	}
+	return env
+}
+
+// CloudAccounts describes the pinned cloud accounts and cluster, e.g. "AWS Profile: prod"
+func (s *Scenario) CloudAccounts() []string {
+	var accounts []string
+	if s.AWSProfile != "" {
+		accounts = append(accounts, "AWS Profile: "+s.AWSProfile)
+	}
+	if s.GCloudProject != "" {
+		accounts = append(accounts, "GCP Project: "+s.GCloudProject)
+	}
+	if s.AzureSubscription != "" {
+		accounts = append(accounts, "Azure Subscription: "+s.AzureSubscription)
+	}
+	if s.Kubeconfig != "" {
+		accounts = append(accounts, "Kubeconfig: "+s.Kubeconfig)
+	}
+	if s.KubeContext != "" {
+		accounts = append(accounts, "Kube Context: "+s.KubeContext)
+	}
+	return accounts
+}
+
+// applyCloudDefaults sets the scenario's project and subscription on built-in
+// actions that do not name their own, so reports show the account each used
+func (s *Scenario) applyCloudDefaults() {
+	for _, c := range s.steps() {
+		if c.GCP != nil && c.GCP.Project == "" {
+			c.GCP.Project = s.GCloudProject
+		}
+		if c.Azure != nil && c.Azure.Subscription == "" {
+			c.Azure.Subscription = s.AzureSubscription
+		}
+	}
+}
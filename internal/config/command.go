@@ -0,0 +1,132 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Command is a scenario step. In YAML it is either a plain command string, an
+// argv sequence run without a shell, or a mapping with the command under 'run'
+// or 'argv' (or a built-in action) plus per-step options.
+type Command struct {
+	Run             string         `yaml:"run,omitempty" json:"run,omitempty"`
+	Argv            []string       `yaml:"argv,omitempty" json:"argv,omitempty"` // Program and arguments, run without a shell
+	GCP             *GCPAction     `yaml:"gcp,omitempty" json:"gcp,omitempty"`
+	Azure           *AzureAction   `yaml:"azure,omitempty" json:"azure,omitempty"`
+	VSphere         *VSphereAction `yaml:"vsphere,omitempty" json:"vsphere,omitempty"`
+	Execution       *Execution     `yaml:"execution,omitempty" json:"execution,omitempty"` // Overrides the scenario's execution
+	Shell           *Shell         `yaml:"shell,omitempty" json:"shell,omitempty"`         // Overrides the scenario's shell
+	RunAs           string         `yaml:"run_as,omitempty" json:"run_as,omitempty"`       // User to run the command as, via sudo
+	ExpectExitCodes []int          `yaml:"expect_exit_codes,omitempty" json:"expect_exit_codes,omitempty"`
+	ExpectStdout    []string       `yaml:"expect_stdout,omitempty" json:"expect_stdout,omitempty"` // Regexes stdout must match, reported apart from exit codes
+	MaxOutput       int            `yaml:"max_output,omitempty" json:"max_output,omitempty"`       // Overrides the scenario's max_output for this command
+}
+
+// UnmarshalYAML accepts both the string and mapping forms
+func (c *Command) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&c.Run)
+	}
+	if node.Kind == yaml.SequenceNode {
+		return node.Decode(&c.Argv)
+	}
+
+	type plain Command
+	var p plain
+	if err := node.Decode(&p); err != nil {
+		return err
+	}
+	*c = Command(p)
+	return nil
+}
+
+// MarshalJSON keeps simple commands as plain strings in JSON reports
+func (c Command) MarshalJSON() ([]byte, error) {
+	if len(c.ExpectExitCodes) == 0 && len(c.Argv) == 0 && c.GCP == nil && c.Azure == nil && c.VSphere == nil && c.Execution == nil && c.Shell == nil && c.RunAs == "" && len(c.ExpectStdout) == 0 && c.MaxOutput == 0 {
+		return json.Marshal(c.Run)
+	}
+
+	type plain Command
+	return json.Marshal(plain(c))
+}
+
+// UnmarshalJSON accepts both forms written by MarshalJSON
+func (c *Command) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &c.Run)
+	}
+
+	type plain Command
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*c = Command(p)
+	return nil
+}
+
+// IsSet reports whether the step has a command to run
+func (c Command) IsSet() bool {
+	return c.Run != "" || len(c.Argv) > 0 || c.GCP != nil || c.Azure != nil || c.VSphere != nil
+}
+
+// validate checks per-step options of the named field
+func (c Command) validate(field string) error {
+	if !c.IsSet() && len(c.ExpectExitCodes) > 0 {
+		return fmt.Errorf("'%s' sets expect_exit_codes but has no 'run' command", field)
+	}
+	if !c.IsSet() && len(c.ExpectStdout) > 0 {
+		return fmt.Errorf("'%s' sets expect_stdout but has no 'run' command", field)
+	}
+	for i, pattern := range c.ExpectStdout {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid '%s.expect_stdout[%d]': %w", field, i, err)
+		}
+	}
+	actions := 0
+	for _, set := range []bool{c.Run != "", len(c.Argv) > 0, c.GCP != nil, c.Azure != nil, c.VSphere != nil} {
+		if set {
+			actions++
+		}
+	}
+	if actions > 1 {
+		return fmt.Errorf("'%s' may set only one of 'run', 'argv', 'gcp', 'azure', and 'vsphere'", field)
+	}
+	if c.GCP != nil {
+		if err := c.GCP.validate(); err != nil {
+			return fmt.Errorf("invalid '%s.gcp': %w", field, err)
+		}
+	}
+	if c.Azure != nil {
+		if err := c.Azure.validate(); err != nil {
+			return fmt.Errorf("invalid '%s.azure': %w", field, err)
+		}
+	}
+	if c.VSphere != nil {
+		if err := c.VSphere.validate(); err != nil {
+			return fmt.Errorf("invalid '%s.vsphere': %w", field, err)
+		}
+	}
+	if len(c.Argv) > 0 && c.Shell != nil {
+		return fmt.Errorf("'%s' sets 'shell', which does not apply to 'argv' commands", field)
+	}
+	if err := c.Shell.validate(field + ".shell"); err != nil {
+		return err
+	}
+	if c.Execution != nil {
+		if err := c.Execution.Validate(); err != nil {
+			return fmt.Errorf("invalid '%s.execution': %w", field, err)
+		}
+	}
+	if c.MaxOutput < 0 {
+		return fmt.Errorf("'%s.max_output' must not be negative", field)
+	}
+	if c.RunAs != "" && (strings.HasPrefix(c.RunAs, "-") || strings.ContainsAny(c.RunAs, " \t\n")) {
+		return fmt.Errorf("invalid '%s.run_as' user %q", field, c.RunAs)
+	}
+	return nil
+}
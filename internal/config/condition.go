@@ -0,0 +1,61 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Condition is a numeric threshold such as "< 0.01" or ">= 3"
+type Condition struct {
+	Operator  string
+	Threshold float64
+}
+
+// conditionOperators is ordered so two-character operators match first
+var conditionOperators = []string{"<=", ">=", "==", "!=", "<", ">"}
+
+// ParseCondition parses a threshold expression of the form "<op> <number>"
+func ParseCondition(expr string) (Condition, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return Condition{}, fmt.Errorf("condition is empty")
+	}
+
+	for _, op := range conditionOperators {
+		if !strings.HasPrefix(expr, op) {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(expr[len(op):]), 64)
+		if err != nil {
+			return Condition{}, fmt.Errorf("invalid threshold in %q: %w", expr, err)
+		}
+		return Condition{Operator: op, Threshold: value}, nil
+	}
+
+	return Condition{}, fmt.Errorf("condition %q must start with one of <, <=, >, >=, ==, !=", expr)
+}
+
+// Holds reports whether value satisfies the condition
+func (c Condition) Holds(value float64) bool {
+	switch c.Operator {
+	case "<":
+		return value < c.Threshold
+	case "<=":
+		return value <= c.Threshold
+	case ">":
+		return value > c.Threshold
+	case ">=":
+		return value >= c.Threshold
+	case "==":
+		return value == c.Threshold
+	case "!=":
+		return value != c.Threshold
+	}
+	return false
+}
+
+// String returns the condition in its expression form
+func (c Condition) String() string {
+	return fmt.Sprintf("%s %s", c.Operator, strconv.FormatFloat(c.Threshold, 'g', -1, 64))
+}
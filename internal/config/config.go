@@ -3,6 +3,10 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -12,30 +16,201 @@ import (
 type Scenario struct {
 	Name              string        `yaml:"name"`
 	Description       string        `yaml:"description,omitempty"`
+	BlastRadius       *BlastRadius  `yaml:"blast_radius,omitempty"`  // What the disruption affects, shown before it runs
+	AllowedWindows    []MaintenanceWindow `yaml:"allowed_windows,omitempty"`  // When the drill may disrupt (default: any time)
+	Guards            []Guard       `yaml:"guards,omitempty"`  // Preconditions checked immediately before the disruption
+	Lock              *Lock         `yaml:"lock,omitempty"`  // Run lock against overlapping drills (default: a file lock keyed by service)
+	Simulate          *Simulation   `yaml:"simulate,omitempty"`  // Command outcomes reported by run --simulate
+	Service           string        `yaml:"service,omitempty"`  // Inventory service this scenario drills (default: name)
+	Tags              []string      `yaml:"tags,omitempty"`  // Labels for filtering run history, e.g. team or environment
+	Frequency         string        `yaml:"frequency,omitempty"`  // How often the scenario must be drilled, e.g. quarterly or 45d
+	SLA               string        `yaml:"sla,omitempty"`  // ID of the SLA in sla_file the drill validates; sets rto_target and rpo_target
+	SLAFile           string        `yaml:"sla_file,omitempty"`  // SLA registry (default: $DRILLMEASURE_SLAS)
+	SLADefinition     *SLA          `yaml:"-"`  // Loaded from sla_file
 	RTOTarget         string        `yaml:"rto_target"`
+	TargetSource      *TargetSource `yaml:"target_source,omitempty"`  // Resolves rto_target and rpo_target at run time; values it returns override the scenario's
+	ExpectedDowntimeGrace string    `yaml:"expected_downtime_grace,omitempty"`  // Downtime accepted by design, excluded from the RTO comparison
+	MaxFailedProbes   *int          `yaml:"max_failed_probes,omitempty"`  // Pass/fail on failed health checks instead of RTA; rto_target then only bounds the wait
+	AbortAfter        string        `yaml:"abort_after,omitempty"`  // Keep measuring past rto_target until this long after the service went down, then abort (duration, or a multiple of rto_target such as "2x")
+	AbortCommand      Command       `yaml:"abort_command,omitempty"`  // Rollback run when the drill aborts (default: recover_command)
+	MaxRTARegression  *float64      `yaml:"max_rta_regression,omitempty"`  // Percent the RTA may exceed the scenario's baseline run's before the drill fails
 	RPOTarget         string        `yaml:"rpo_target,omitempty"`
-	DisruptCommand    string        `yaml:"disrupt_command"`
-	RecoverCommand    string        `yaml:"recover_command,omitempty"`
-	HealthCheckCommand string        `yaml:"health_check_command"`
+	SeedCommand       Command       `yaml:"seed_command,omitempty"`  // Writes known test data repeatedly until the disruption
+	SeedInterval      string        `yaml:"seed_interval,omitempty"`  // Time between seed_command runs (default: 1s)
+	DisruptCommand    Command       `yaml:"disrupt_command"`
+	RecoverCommand    Command       `yaml:"recover_command,omitempty"`
+	HealthCheckCommand string        `yaml:"health_check_command,omitempty"`
+	HealthCheck       *HealthCheck  `yaml:"health_check,omitempty"`
 	PostDisruptDelay  string        `yaml:"post_disrupt_delay,omitempty"`
+	ProbeInterval     string        `yaml:"probe_interval,omitempty"`  // Time between health checks (default: 5s, minimum: 100ms)
+	ProbeConcurrency  int           `yaml:"probe_concurrency,omitempty"`  // Health checks allowed in flight at once (default: 1)
+	HealthyAfter      int           `yaml:"healthy_after,omitempty"`  // Consecutive passing health checks needed to declare recovery (default: 1)
 	RPOCheck          *RPOCheck     `yaml:"rpo_check,omitempty"`
+	RPOChecks         []NamedRPOCheck `yaml:"rpo_checks,omitempty"`  // Per-data-store RPO checks, each with its own target
+	RestoreCheck      *RestoreCheck `yaml:"restore_check,omitempty"`  // Restores a backup into a scratch target before the disruption
+	ConsistencyChecks []ConsistencyCheck `yaml:"consistency_checks,omitempty"`  // Data checks run after recovery, each reported individually
+	Metrics           []Metric      `yaml:"metrics,omitempty"`  // Values read from command output before the disruption and after recovery
+	Assertions        []string      `yaml:"assertions,omitempty"`  // Expressions over the result that must all hold for the drill to pass
 	Factors           *Factors      `yaml:"factors,omitempty"`
+	LatencyBudget     *LatencyBudget `yaml:"latency_budget,omitempty"`
+	Degraded          *Degraded     `yaml:"degraded,omitempty"`  // Health checks that mean the service is up but impaired
+	Switchback        *Switchback   `yaml:"switchback,omitempty"`
+	Repeat            *Repeat       `yaml:"repeat,omitempty"`  // Runs the drill several times with a cool-down between runs
+	Execution         *Execution    `yaml:"execution,omitempty"`  // Where commands run (default: local)
+	AWSProfile        string        `yaml:"aws_profile,omitempty"`  // AWS_PROFILE for commands and the cloudwatch probe
+	GCloudProject     string        `yaml:"gcloud_project,omitempty"`  // Project for commands and gcp actions
+	AzureSubscription string        `yaml:"azure_subscription,omitempty"`  // Subscription for commands and azure actions
+	Kubeconfig        string        `yaml:"kubeconfig,omitempty"`  // KUBECONFIG for commands and kubectl-exec
+	KubeContext       string        `yaml:"kube_context,omitempty"`  // Context for commands and kubectl-exec (default: the kubeconfig's current context)
+	HostsFile         string        `yaml:"hosts_file,omitempty"`  // Host inventory for execution groups
+	Hosts             *HostInventory `yaml:"-" json:"-"`  // Loaded from hosts_file
+	ControlsFile      string        `yaml:"controls_file,omitempty"`  // Maps drill outcomes to compliance controls (default: $DRILLMEASURE_CONTROLS)
+	Controls          *ControlMapping `yaml:"-" json:"-"`  // Loaded from controls_file
+	Shell             *Shell        `yaml:"shell,omitempty"`  // Interpreter for commands (default: bash, or powershell on Windows)
+	Variables         map[string]string `yaml:"variables,omitempty" json:"-"`
+	SecretVariables   []string      `yaml:"secret_variables,omitempty"`
+	Redact            *Redact       `yaml:"redact,omitempty"`  // Secrets removed from command output before it is stored
+	MaxOutput         int           `yaml:"max_output,omitempty"`  // Bytes of each command's stdout and stderr kept in reports (default: unlimited)
+	SpoolOutput       bool          `yaml:"spool_output,omitempty"`  // Keep the full output of truncated commands compressed in the output directory
+	Artifacts         []string      `yaml:"artifacts,omitempty"`  // File globs copied into the output directory after the drill
+}
+
+// minProbeInterval is the shortest supported probe_interval
+const minProbeInterval = 100 * time.Millisecond
+
+// HealthCheck configures a built-in health probe used instead of health_check_command
+type HealthCheck struct {
+	Type           string `yaml:"type"`
+	URL            string `yaml:"url,omitempty"`
+	ExpectedStatus int    `yaml:"expected_status,omitempty"`
+	BodyRegex      string `yaml:"body_regex,omitempty"`
+	Host           string `yaml:"host,omitempty"`
+	Port           int    `yaml:"port,omitempty"`
+	Query          string `yaml:"query,omitempty"`
+	Condition      string `yaml:"condition,omitempty"`
+	Namespace      string            `yaml:"namespace,omitempty"`
+	Metric         string            `yaml:"metric,omitempty"`
+	Statistic      string            `yaml:"statistic,omitempty"`
+	Dimensions     map[string]string `yaml:"dimensions,omitempty"`
+	Period         string            `yaml:"period,omitempty"`
+	Region         string            `yaml:"region,omitempty"`
+	Listen         string            `yaml:"listen,omitempty"`
+	Path           string            `yaml:"path,omitempty"`
+	Token          string            `yaml:"token,omitempty" json:"-"`
+	Timeout        string `yaml:"timeout,omitempty"`
+}
+
+// LatencyBudget classifies a healthy but slow service as degraded
+type LatencyBudget struct {
+	Percentile float64 `yaml:"percentile"`          // e.g. 95 for p95
+	Max        string  `yaml:"max"`                 // Maximum acceptable latency at the percentile
+	Window     int     `yaml:"window,omitempty"`    // Number of recent probes evaluated (default: 5)
+	MaxWait    string  `yaml:"max_wait,omitempty"`  // How long to keep probing while degraded (default: rto_target)
+}
+
+// BlastRadius describes what a disruption affects, for the confirmation prompt
+type BlastRadius struct {
+	Environment string   `yaml:"environment,omitempty"`  // e.g. production
+	Services    []string `yaml:"services,omitempty"`  // Services expected to be affected
+	Impact      string   `yaml:"impact,omitempty"`  // Expected user-facing impact, e.g. "writes fail for up to 30s"
+}
+
+// Switchback fails back to the original primary after recovery, timed as a second metric
+type Switchback struct {
+	Command Command `yaml:"command"`
+	Target  string  `yaml:"target,omitempty"`  // Maximum acceptable switchback time; without it the time is only reported
 }
 
 // RPOCheck contains commands for RPO measurement
 type RPOCheck struct {
-	PreSnapshot  string `yaml:"pre_snapshot,omitempty"`
-	PostSnapshot string `yaml:"post_snapshot,omitempty"`
-	VerifyCommand string `yaml:"verify_command,omitempty"`
+	BackupFreshness *BackupFreshness `yaml:"backup_freshness,omitempty"`
+	PreSnapshot  Command `yaml:"pre_snapshot,omitempty"`
+	PostSnapshot Command `yaml:"post_snapshot,omitempty"`
+	VerifyCommand Command `yaml:"verify_command,omitempty"`
+	Timestamp    *SnapshotTimestamp `yaml:"timestamp,omitempty"`  // Measures data loss from the latest write time in the snapshots
+	Markers      *Markers `yaml:"markers,omitempty"`  // Measures data loss from marker records written up to the disruption
+	VerifyOutput *VerifyOutput `yaml:"verify_output,omitempty"`  // Reads the data loss verify_command prints
+	SnapshotInterval string `yaml:"snapshot_interval,omitempty"`  // Also run post_snapshot this often from the disruption until recovery
+}
+
+// GetSnapshotInterval returns how often post_snapshot runs during the drill,
+// or zero if it only runs once after recovery
+func (r *RPOCheck) GetSnapshotInterval() (time.Duration, error) {
+	if r.SnapshotInterval == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(r.SnapshotInterval)
+}
+
+// VerifyOutput reads a number from verify_command output: seconds of data
+// loss, compared against rpo_target, or missing rows, compared against max_rows
+type VerifyOutput struct {
+	Unit    string `yaml:"unit,omitempty"`      // seconds or rows (default: seconds)
+	Regex   string `yaml:"regex,omitempty"`     // Extracts the number: the first capture group, else the whole match (default: the last non-empty line)
+	MaxRows int    `yaml:"max_rows,omitempty"`  // rows: the most missing rows that still pass (default: 0)
+}
+
+// Rows reports whether verify_command prints missing rows rather than seconds
+func (v *VerifyOutput) Rows() bool {
+	return v.Unit == "rows"
+}
+
+func (v *VerifyOutput) validate(field string) error {
+	switch v.Unit {
+	case "", "seconds", "rows":
+	default:
+		return fmt.Errorf("invalid '%s' unit %q (expected seconds or rows)", field, v.Unit)
+	}
+	if !v.Rows() && v.MaxRows != 0 {
+		return fmt.Errorf("invalid '%s': 'max_rows' requires unit rows", field)
+	}
+	if v.MaxRows < 0 {
+		return fmt.Errorf("invalid '%s': 'max_rows' must not be negative", field)
+	}
+	if v.Regex != "" {
+		if _, err := regexp.Compile(v.Regex); err != nil {
+			return fmt.Errorf("invalid '%s' regex: %w", field, err)
+		}
+	}
+	return nil
+}
+
+// SnapshotTimestamp reads the latest write time from pre_snapshot and
+// post_snapshot output; writes after the post-snapshot time were lost
+type SnapshotTimestamp struct {
+	Regex  string `yaml:"regex,omitempty"`   // Extracts the time: the first capture group, else the whole match (default: the last non-empty line)
+	Format string `yaml:"format,omitempty"`  // Go time layout, unix, or unix_ms (default: RFC 3339 or Unix seconds)
+}
+
+func (t *SnapshotTimestamp) validate(field string) error {
+	if t.Regex != "" {
+		if _, err := regexp.Compile(t.Regex); err != nil {
+			return fmt.Errorf("invalid '%s' regex: %w", field, err)
+		}
+	}
+	return nil
+}
+
+// BackupFreshness checks the age of the latest backup against the RPO target before disruption
+type BackupFreshness struct {
+	Command Command `yaml:"command"`            // Prints the latest backup time (RFC 3339 or Unix seconds)
+	Timeout string  `yaml:"timeout,omitempty"`  // Time box for the command (default: 1m)
 }
 
 // Factors contains commands to collect influencing factors/logs
 type Factors struct {
-	LogCommands []string `yaml:"log_commands,omitempty"`
+	LogCommands   []Command      `yaml:"log_commands,omitempty"`
+	SystemMetrics *SystemMetrics `yaml:"system_metrics,omitempty"` // Host metrics sampled throughout the drill
 }
 
-// ParseScenario reads and parses a YAML scenario file
+// ParseScenario reads and parses a YAML scenario file, resolving any
+// ${{ name }} references from the scenario's variables
 func ParseScenario(filePath string) (*Scenario, error) {
+	return ParseScenarioWithVariables(filePath, nil)
+}
+
+// parseScenarioFile reads and unmarshals a YAML scenario file as written
+func parseScenarioFile(filePath string) (*Scenario, error) {
 	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read scenario file: %w", err)
@@ -55,12 +230,36 @@ func (s *Scenario) Validate() error {
 		return fmt.Errorf("required field 'name' is missing")
 	}
 
-	if s.RTOTarget == "" {
+	for i, tag := range s.Tags {
+		if strings.TrimSpace(tag) == "" || strings.Contains(tag, ",") {
+			return fmt.Errorf("invalid 'tags[%d]': tags must be non-empty and contain no commas", i)
+		}
+	}
+	if s.SLA != "" {
+		if err := s.validateSLA(); err != nil {
+			return err
+		}
+	}
+	if s.Frequency != "" {
+		if _, _, err := parseFrequency(s.Frequency); err != nil {
+			return fmt.Errorf("invalid 'frequency' %q: %w", s.Frequency, err)
+		}
+	}
+
+	if s.TargetSource != nil {
+		if err := s.TargetSource.validate(); err != nil {
+			return fmt.Errorf("invalid 'target_source': %w", err)
+		}
+	}
+
+	if s.RTOTarget == "" && !s.TargetSource.ProvidesRTO() {
 		return fmt.Errorf("required field 'rto_target' is missing")
 	}
 
-	if _, err := time.ParseDuration(s.RTOTarget); err != nil {
-		return fmt.Errorf("invalid 'rto_target' duration: %w", err)
+	if s.RTOTarget != "" {
+		if _, err := time.ParseDuration(s.RTOTarget); err != nil {
+			return fmt.Errorf("invalid 'rto_target' duration: %w", err)
+		}
 	}
 
 	if s.RPOTarget != "" {
@@ -69,12 +268,268 @@ func (s *Scenario) Validate() error {
 		}
 	}
 
-	if s.DisruptCommand == "" {
+	if s.ExpectedDowntimeGrace != "" {
+		grace, err := time.ParseDuration(s.ExpectedDowntimeGrace)
+		if err != nil {
+			return fmt.Errorf("invalid 'expected_downtime_grace' duration: %w", err)
+		}
+		if grace < 0 {
+			return fmt.Errorf("'expected_downtime_grace' must not be negative")
+		}
+	}
+
+	if s.MaxFailedProbes != nil {
+		if *s.MaxFailedProbes < 0 {
+			return fmt.Errorf("'max_failed_probes' must not be negative")
+		}
+		if s.ExpectedDowntimeGrace != "" {
+			return fmt.Errorf("only one of 'expected_downtime_grace' and 'max_failed_probes' may be set")
+		}
+	}
+
+	if s.AbortAfter != "" {
+		if s.RTOTarget != "" {
+			rto, _ := time.ParseDuration(s.RTOTarget)
+			grace, _ := s.GetExpectedDowntimeGrace()
+			abortAfter, err := s.GetAbortAfter(rto)
+			if err != nil {
+				return fmt.Errorf("invalid 'abort_after': %w", err)
+			}
+			if abortAfter <= rto+grace {
+				return fmt.Errorf("'abort_after' (%s) must be longer than 'rto_target' plus 'expected_downtime_grace' (%s)", abortAfter, rto+grace)
+			}
+		} else if _, err := s.GetAbortAfter(time.Minute); err != nil {
+			return fmt.Errorf("invalid 'abort_after': %w", err)
+		}
+	} else if s.AbortCommand.IsSet() {
+		return fmt.Errorf("'abort_command' requires 'abort_after'")
+	}
+	if err := s.AbortCommand.validate("abort_command"); err != nil {
+		return err
+	}
+	if s.MaxRTARegression != nil && *s.MaxRTARegression < 0 {
+		return fmt.Errorf("'max_rta_regression' must not be negative")
+	}
+
+	if err := s.Shell.validate("shell"); err != nil {
+		return err
+	}
+
+	if s.Execution != nil {
+		if err := s.Execution.Validate(); err != nil {
+			return fmt.Errorf("invalid 'execution': %w", err)
+		}
+	}
+
+	for field, e := range s.groupExecutions() {
+		if _, err := s.Hosts.Members(e); err != nil {
+			return fmt.Errorf("invalid '%s': %w", field, err)
+		}
+	}
+
+	if !s.DisruptCommand.IsSet() {
 		return fmt.Errorf("required field 'disrupt_command' is missing")
 	}
 
-	if s.HealthCheckCommand == "" {
-		return fmt.Errorf("required field 'health_check_command' is missing")
+	if err := s.DisruptCommand.validate("disrupt_command"); err != nil {
+		return err
+	}
+
+	if err := s.RecoverCommand.validate("recover_command"); err != nil {
+		return err
+	}
+
+	if s.RPOCheck != nil {
+		for field, c := range map[string]Command{
+			"rpo_check.pre_snapshot":   s.RPOCheck.PreSnapshot,
+			"rpo_check.post_snapshot":  s.RPOCheck.PostSnapshot,
+			"rpo_check.verify_command": s.RPOCheck.VerifyCommand,
+		} {
+			if err := c.validate(field); err != nil {
+				return err
+			}
+		}
+
+		if s.RPOCheck.BackupFreshness != nil {
+			if err := s.RPOCheck.BackupFreshness.validate(s.RPOTarget); err != nil {
+				return fmt.Errorf("invalid 'rpo_check.backup_freshness': %w", err)
+			}
+		}
+
+		if s.RPOCheck.Markers != nil {
+			if s.RPOCheck.Timestamp != nil {
+				return fmt.Errorf("'rpo_check.markers' and 'rpo_check.timestamp' cannot both be set")
+			}
+			if err := s.RPOCheck.Markers.validate(); err != nil {
+				return fmt.Errorf("invalid 'rpo_check.markers': %w", err)
+			}
+		}
+
+		if s.RPOCheck.SnapshotInterval != "" {
+			interval, err := s.RPOCheck.GetSnapshotInterval()
+			if err != nil {
+				return fmt.Errorf("invalid 'rpo_check.snapshot_interval' duration: %w", err)
+			}
+			if interval <= 0 {
+				return fmt.Errorf("'rpo_check.snapshot_interval' must be positive")
+			}
+			if !s.RPOCheck.PostSnapshot.IsSet() {
+				return fmt.Errorf("'rpo_check.snapshot_interval' requires 'post_snapshot'")
+			}
+		}
+
+		if v := s.RPOCheck.VerifyOutput; v != nil {
+			if !s.RPOCheck.VerifyCommand.IsSet() {
+				return fmt.Errorf("invalid 'rpo_check.verify_output': requires 'verify_command'")
+			}
+			if !v.Rows() && (s.RPOCheck.Timestamp != nil || s.RPOCheck.Markers != nil) {
+				return fmt.Errorf("'rpo_check.verify_output' in seconds cannot be combined with 'timestamp' or 'markers'")
+			}
+			if err := v.validate("rpo_check.verify_output"); err != nil {
+				return err
+			}
+		}
+
+		if s.RPOCheck.Timestamp != nil {
+			if !s.RPOCheck.PreSnapshot.IsSet() || !s.RPOCheck.PostSnapshot.IsSet() {
+				return fmt.Errorf("invalid 'rpo_check.timestamp': requires 'pre_snapshot' and 'post_snapshot'")
+			}
+			if err := s.RPOCheck.Timestamp.validate("rpo_check.timestamp"); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := s.validateRPOChecks(); err != nil {
+		return err
+	}
+
+	if err := s.validateConsistencyChecks(); err != nil {
+		return err
+	}
+
+	if err := s.validateMetrics(); err != nil {
+		return err
+	}
+
+	if err := s.validateAssertions(); err != nil {
+		return err
+	}
+
+	if s.RestoreCheck != nil {
+		if err := s.RestoreCheck.validate(); err != nil {
+			return fmt.Errorf("invalid 'restore_check': %w", err)
+		}
+	}
+
+	if s.Factors != nil {
+		for i, c := range s.Factors.LogCommands {
+			if err := c.validate(fmt.Sprintf("factors.log_commands[%d]", i)); err != nil {
+				return err
+			}
+		}
+		if s.Factors.SystemMetrics != nil {
+			if err := s.Factors.SystemMetrics.validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if s.HealthCheckCommand == "" && s.HealthCheck == nil {
+		return fmt.Errorf("required field 'health_check_command' or 'health_check' is missing")
+	}
+
+	if s.HealthCheckCommand != "" && s.HealthCheck != nil {
+		return fmt.Errorf("only one of 'health_check_command' and 'health_check' may be set")
+	}
+
+	if s.HealthCheck != nil {
+		if err := s.HealthCheck.Validate(); err != nil {
+			return fmt.Errorf("invalid 'health_check': %w", err)
+		}
+	}
+
+	if s.LatencyBudget != nil {
+		if err := s.LatencyBudget.Validate(); err != nil {
+			return fmt.Errorf("invalid 'latency_budget': %w", err)
+		}
+	}
+
+	if err := s.validateDegraded(); err != nil {
+		return err
+	}
+
+	if err := s.validateRedact(); err != nil {
+		return err
+	}
+
+	if s.MaxOutput < 0 {
+		return fmt.Errorf("'max_output' must not be negative")
+	}
+
+	for i, pattern := range s.Artifacts {
+		if pattern == "" {
+			return fmt.Errorf("'artifacts[%d]' must not be empty", i)
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid 'artifacts[%d]' pattern %q: %w", i, pattern, err)
+		}
+	}
+
+	if s.ProbeInterval != "" {
+		interval, err := time.ParseDuration(s.ProbeInterval)
+		if err != nil {
+			return fmt.Errorf("invalid 'probe_interval' duration: %w", err)
+		}
+		if interval < minProbeInterval {
+			return fmt.Errorf("'probe_interval' must be at least %s", minProbeInterval)
+		}
+	}
+
+	if s.ProbeConcurrency < 0 {
+		return fmt.Errorf("'probe_concurrency' must not be negative")
+	}
+
+	if s.HealthyAfter < 0 {
+		return fmt.Errorf("'healthy_after' must not be negative")
+	}
+
+	if s.Lock != nil {
+		if err := s.Lock.validate(); err != nil {
+			return fmt.Errorf("invalid 'lock': %w", err)
+		}
+	}
+
+	if s.Simulate != nil {
+		if err := s.Simulate.validate(); err != nil {
+			return fmt.Errorf("invalid 'simulate': %w", err)
+		}
+	}
+
+	for i := range s.Guards {
+		if err := s.Guards[i].validate(fmt.Sprintf("guards[%d]", i)); err != nil {
+			return err
+		}
+	}
+
+	for i, w := range s.AllowedWindows {
+		if err := w.validate(); err != nil {
+			return fmt.Errorf("invalid 'allowed_windows[%d]': %w", i, err)
+		}
+	}
+
+	if s.Switchback != nil {
+		if !s.Switchback.Command.IsSet() {
+			return fmt.Errorf("required field 'switchback.command' is missing")
+		}
+		if err := s.Switchback.Command.validate("switchback.command"); err != nil {
+			return err
+		}
+		if s.Switchback.Target != "" {
+			if _, err := time.ParseDuration(s.Switchback.Target); err != nil {
+				return fmt.Errorf("invalid 'switchback.target' duration: %w", err)
+			}
+		}
 	}
 
 	if s.PostDisruptDelay != "" {
@@ -83,9 +538,234 @@ func (s *Scenario) Validate() error {
 		}
 	}
 
+	if err := s.validateSeed(); err != nil {
+		return err
+	}
+
+	if s.Repeat != nil {
+		if err := s.Repeat.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the probe type is known and its fields are valid
+func (h *HealthCheck) Validate() error {
+	switch h.Type {
+	case "http":
+		if h.URL == "" {
+			return fmt.Errorf("required field 'url' is missing")
+		}
+		if h.BodyRegex != "" {
+			if _, err := regexp.Compile(h.BodyRegex); err != nil {
+				return fmt.Errorf("invalid 'body_regex': %w", err)
+			}
+		}
+	case "webhook":
+		if h.Listen == "" {
+			return fmt.Errorf("required field 'listen' is missing")
+		}
+		if h.Path != "" && !strings.HasPrefix(h.Path, "/") {
+			return fmt.Errorf("'path' must start with '/'")
+		}
+	case "tcp":
+		if h.Host == "" {
+			return fmt.Errorf("required field 'host' is missing")
+		}
+		if h.Port <= 0 || h.Port > 65535 {
+			return fmt.Errorf("'port' must be between 1 and 65535")
+		}
+	case "prometheus":
+		if h.URL == "" {
+			return fmt.Errorf("required field 'url' is missing")
+		}
+		if h.Query == "" {
+			return fmt.Errorf("required field 'query' is missing")
+		}
+		if _, err := ParseCondition(h.Condition); err != nil {
+			return fmt.Errorf("invalid 'condition': %w", err)
+		}
+	case "cloudwatch":
+		if h.Namespace == "" {
+			return fmt.Errorf("required field 'namespace' is missing")
+		}
+		if h.Metric == "" {
+			return fmt.Errorf("required field 'metric' is missing")
+		}
+		if h.Statistic == "" {
+			return fmt.Errorf("required field 'statistic' is missing")
+		}
+		if _, err := ParseCondition(h.Condition); err != nil {
+			return fmt.Errorf("invalid 'condition': %w", err)
+		}
+		if h.Period != "" {
+			period, err := time.ParseDuration(h.Period)
+			if err != nil {
+				return fmt.Errorf("invalid 'period' duration: %w", err)
+			}
+			if period < time.Second || period%time.Second != 0 {
+				return fmt.Errorf("'period' must be a whole number of seconds")
+			}
+		}
+	case "":
+		return fmt.Errorf("required field 'type' is missing")
+	default:
+		return fmt.Errorf("unknown probe type %q", h.Type)
+	}
+
+	if h.Timeout != "" {
+		if _, err := time.ParseDuration(h.Timeout); err != nil {
+			return fmt.Errorf("invalid 'timeout' duration: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetTimeout returns the parsed probe timeout, or zero if not set
+func (h *HealthCheck) GetTimeout() (time.Duration, error) {
+	if h.Timeout == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(h.Timeout)
+}
+
+// Validate checks the latency budget fields
+func (l *LatencyBudget) Validate() error {
+	if l.Percentile <= 0 || l.Percentile > 100 {
+		return fmt.Errorf("'percentile' must be greater than 0 and at most 100")
+	}
+	if l.Max == "" {
+		return fmt.Errorf("required field 'max' is missing")
+	}
+	if _, err := time.ParseDuration(l.Max); err != nil {
+		return fmt.Errorf("invalid 'max' duration: %w", err)
+	}
+	if l.Window < 0 {
+		return fmt.Errorf("'window' must not be negative")
+	}
+	if l.MaxWait != "" {
+		if _, err := time.ParseDuration(l.MaxWait); err != nil {
+			return fmt.Errorf("invalid 'max_wait' duration: %w", err)
+		}
+	}
+	return nil
+}
+
+// validate checks the backup freshness fields; the RPO target is the maximum backup age
+func (b *BackupFreshness) validate(rpoTarget string) error {
+	if rpoTarget == "" {
+		return fmt.Errorf("requires 'rpo_target' to be set")
+	}
+	if !b.Command.IsSet() {
+		return fmt.Errorf("required field 'command' is missing")
+	}
+	if err := b.Command.validate("command"); err != nil {
+		return err
+	}
+	if b.Timeout != "" {
+		if _, err := time.ParseDuration(b.Timeout); err != nil {
+			return fmt.Errorf("invalid 'timeout' duration: %w", err)
+		}
+	}
 	return nil
 }
 
+// Steps returns every command the scenario runs, including the health check
+// command, keyed by field
+func (s *Scenario) Steps() map[string]Command {
+	steps := map[string]Command{}
+	for field, c := range s.steps() {
+		if c.IsSet() {
+			steps[field] = *c
+		}
+	}
+	if s.HealthCheckCommand != "" {
+		steps["health_check_command"] = Command{Run: s.HealthCheckCommand}
+	}
+	return steps
+}
+
+// steps returns every scenario step, keyed by field
+func (s *Scenario) steps() map[string]*Command {
+	steps := map[string]*Command{
+		"disrupt_command": &s.DisruptCommand,
+		"recover_command": &s.RecoverCommand,
+		"seed_command":    &s.SeedCommand,
+	}
+	if s.RPOCheck != nil {
+		steps["rpo_check.pre_snapshot"] = &s.RPOCheck.PreSnapshot
+		steps["rpo_check.post_snapshot"] = &s.RPOCheck.PostSnapshot
+		steps["rpo_check.verify_command"] = &s.RPOCheck.VerifyCommand
+		if s.RPOCheck.BackupFreshness != nil {
+			steps["rpo_check.backup_freshness.command"] = &s.RPOCheck.BackupFreshness.Command
+		}
+		if s.RPOCheck.Markers != nil {
+			steps["rpo_check.markers.write_command"] = &s.RPOCheck.Markers.WriteCommand
+			steps["rpo_check.markers.read_command"] = &s.RPOCheck.Markers.ReadCommand
+		}
+	}
+	for i := range s.Guards {
+		steps[fmt.Sprintf("guards[%d].command", i)] = &s.Guards[i].Command
+	}
+	for i := range s.RPOChecks {
+		steps[fmt.Sprintf("rpo_checks[%d].pre_snapshot", i)] = &s.RPOChecks[i].PreSnapshot
+		steps[fmt.Sprintf("rpo_checks[%d].post_snapshot", i)] = &s.RPOChecks[i].PostSnapshot
+		steps[fmt.Sprintf("rpo_checks[%d].verify_command", i)] = &s.RPOChecks[i].VerifyCommand
+	}
+	if s.RestoreCheck != nil {
+		steps["restore_check.command"] = &s.RestoreCheck.Command
+		steps["restore_check.cleanup_command"] = &s.RestoreCheck.CleanupCommand
+		for i := range s.RestoreCheck.IntegrityChecks {
+			steps[fmt.Sprintf("restore_check.integrity_checks[%d].command", i)] = &s.RestoreCheck.IntegrityChecks[i].Command
+		}
+	}
+	for i := range s.ConsistencyChecks {
+		steps[fmt.Sprintf("consistency_checks[%d].command", i)] = &s.ConsistencyChecks[i].Command
+		steps[fmt.Sprintf("consistency_checks[%d].compare_command", i)] = &s.ConsistencyChecks[i].CompareCommand
+	}
+	for i := range s.Metrics {
+		steps[fmt.Sprintf("metrics[%d].command", i)] = &s.Metrics[i].Command
+	}
+	steps["abort_command"] = &s.AbortCommand
+	if s.Switchback != nil {
+		steps["switchback.command"] = &s.Switchback.Command
+	}
+	if s.Factors != nil {
+		for i := range s.Factors.LogCommands {
+			steps[fmt.Sprintf("factors.log_commands[%d]", i)] = &s.Factors.LogCommands[i]
+		}
+		if s.Factors.SystemMetrics != nil {
+			steps["factors.system_metrics.command"] = &s.Factors.SystemMetrics.Command
+		}
+	}
+	return steps
+}
+
+// groupExecutions returns every execution that targets a host group, keyed by field
+func (s *Scenario) groupExecutions() map[string]*Execution {
+	groups := map[string]*Execution{}
+	if s.Execution != nil && s.Execution.Group != "" {
+		groups["execution"] = s.Execution
+	}
+	for field, c := range s.steps() {
+		if c.Execution != nil && c.Execution.Group != "" {
+			groups[field+".execution"] = c.Execution
+		}
+	}
+	return groups
+}
+
+// ServiceName returns the inventory service the scenario drills
+func (s *Scenario) ServiceName() string {
+	if s.Service != "" {
+		return s.Service
+	}
+	return s.Name
+}
+
 // GetRTOTargetDuration returns the parsed RTO target duration
 func (s *Scenario) GetRTOTargetDuration() (time.Duration, error) {
 	return time.ParseDuration(s.RTOTarget)
@@ -99,6 +779,54 @@ func (s *Scenario) GetRPOTargetDuration() (time.Duration, error) {
 	return time.ParseDuration(s.RPOTarget)
 }
 
+// GetExpectedDowntimeGrace returns the parsed downtime grace, or zero if not set
+func (s *Scenario) GetExpectedDowntimeGrace() (time.Duration, error) {
+	if s.ExpectedDowntimeGrace == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s.ExpectedDowntimeGrace)
+}
+
+// GetAbortAfter returns how long after the service went down the drill
+// aborts, or zero if abort_after is not set. A multiple such as "2x" is
+// relative to rtoTarget.
+func (s *Scenario) GetAbortAfter(rtoTarget time.Duration) (time.Duration, error) {
+	if s.AbortAfter == "" {
+		return 0, nil
+	}
+	if factor, ok := strings.CutSuffix(s.AbortAfter, "x"); ok {
+		multiple, err := strconv.ParseFloat(factor, 64)
+		if err != nil || multiple <= 1 {
+			return 0, fmt.Errorf("multiple %q must be a number greater than 1, e.g. \"2x\"", s.AbortAfter)
+		}
+		return time.Duration(multiple * float64(rtoTarget)), nil
+	}
+	d, err := time.ParseDuration(s.AbortAfter)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("must be positive")
+	}
+	return d, nil
+}
+
+// GetSwitchbackTarget returns the parsed switchback target, or zero if not set
+func (s *Scenario) GetSwitchbackTarget() (time.Duration, error) {
+	if s.Switchback == nil || s.Switchback.Target == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s.Switchback.Target)
+}
+
+// GetProbeInterval returns the parsed probe interval, or zero if not set
+func (s *Scenario) GetProbeInterval() (time.Duration, error) {
+	if s.ProbeInterval == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s.ProbeInterval)
+}
+
 // GetPostDisruptDelay returns the parsed post-disrupt delay, or zero if not set
 func (s *Scenario) GetPostDisruptDelay() (time.Duration, error) {
 	if s.PostDisruptDelay == "" {
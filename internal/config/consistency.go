@@ -0,0 +1,61 @@
+package config
+
+import "fmt"
+
+// ConsistencyCheck verifies the recovered data after recovery, such as row
+// counts or checksums matching, or a referential integrity query returning 0
+type ConsistencyCheck struct {
+	Name           string  `yaml:"name"`
+	Command        Command `yaml:"command"`
+	Condition      string  `yaml:"condition,omitempty"`       // Threshold for the number the command prints, e.g. "== 0"
+	CompareCommand Command `yaml:"compare_command,omitempty"` // Output must match this command's, e.g. the same count on the primary
+	MatchBaseline  bool    `yaml:"match_baseline,omitempty"`  // Output must match the command's own output before the disruption
+}
+
+func (c *ConsistencyCheck) validate(field string) error {
+	if c.Name == "" {
+		return fmt.Errorf("'%s' requires 'name'", field)
+	}
+	if !c.Command.IsSet() {
+		return fmt.Errorf("'%s' requires 'command'", field)
+	}
+	if err := c.Command.validate(field + ".command"); err != nil {
+		return err
+	}
+	if err := c.CompareCommand.validate(field + ".compare_command"); err != nil {
+		return err
+	}
+
+	modes := 0
+	if c.Condition != "" {
+		modes++
+		if _, err := ParseCondition(c.Condition); err != nil {
+			return fmt.Errorf("invalid '%s.condition': %w", field, err)
+		}
+	}
+	if c.CompareCommand.IsSet() {
+		modes++
+	}
+	if c.MatchBaseline {
+		modes++
+	}
+	if modes > 1 {
+		return fmt.Errorf("'%s' may set only one of 'condition', 'compare_command', and 'match_baseline'", field)
+	}
+	return nil
+}
+
+func (s *Scenario) validateConsistencyChecks() error {
+	names := map[string]bool{}
+	for i := range s.ConsistencyChecks {
+		c := &s.ConsistencyChecks[i]
+		if err := c.validate(fmt.Sprintf("consistency_checks[%d]", i)); err != nil {
+			return err
+		}
+		if names[c.Name] {
+			return fmt.Errorf("duplicate consistency_checks name %q", c.Name)
+		}
+		names[c.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ControlsEnv names the control mapping used by scenarios without controls_file
+const ControlsEnv = "DRILLMEASURE_CONTROLS"
+
+// Drill outcomes a control can be mapped to
+const (
+	OutcomeDrill           = "drill" // Every measured outcome
+	OutcomeBackupFreshness = "backup_freshness"
+	OutcomeGuards          = "guards"
+	OutcomeRTO             = "rto"
+	OutcomeRPO             = "rpo"
+	OutcomeConsistency     = "consistency"
+	OutcomeAssertions      = "assertions"
+	OutcomeRestore         = "restore"
+	OutcomeSwitchback      = "switchback"
+)
+
+// Outcomes lists the drill outcomes controls can be mapped to
+var Outcomes = []string{
+	OutcomeDrill, OutcomeBackupFreshness, OutcomeGuards, OutcomeRTO, OutcomeRPO,
+	OutcomeConsistency, OutcomeAssertions, OutcomeRestore, OutcomeSwitchback,
+}
+
+// ControlMapping maps drill outcomes to the compliance controls they evidence
+type ControlMapping struct {
+	Path     string    `yaml:"-" json:"path,omitempty"`
+	Controls []Control `yaml:"controls" json:"controls"`
+}
+
+// Control is one control of a compliance framework
+type Control struct {
+	ID          string   `yaml:"id" json:"id"`                                   // e.g. CC7.5 or A.17.1.3
+	Framework   string   `yaml:"framework,omitempty" json:"framework,omitempty"` // e.g. SOC 2 or ISO 27001
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Outcomes    []string `yaml:"outcomes" json:"outcomes"` // Drill outcomes that evidence the control
+}
+
+// LoadControlMapping reads a YAML control mapping file
+func LoadControlMapping(filePath string) (*ControlMapping, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read controls file: %w", err)
+	}
+
+	mapping := ControlMapping{Path: filePath}
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse controls file: %w", err)
+	}
+	if err := mapping.validate(); err != nil {
+		return nil, fmt.Errorf("invalid controls file %s: %w", filePath, err)
+	}
+	return &mapping, nil
+}
+
+func (m *ControlMapping) validate() error {
+	if len(m.Controls) == 0 {
+		return fmt.Errorf("no controls")
+	}
+	seen := map[string]bool{}
+	for i, c := range m.Controls {
+		if c.ID == "" {
+			return fmt.Errorf("'controls[%d]' requires 'id'", i)
+		}
+		key := c.Framework + "\x00" + c.ID
+		if seen[key] {
+			return fmt.Errorf("duplicate control %q", c.ID)
+		}
+		seen[key] = true
+		if len(c.Outcomes) == 0 {
+			return fmt.Errorf("control %q requires 'outcomes'", c.ID)
+		}
+		for _, o := range c.Outcomes {
+			if !isOutcome(o) {
+				return fmt.Errorf("control %q has unknown outcome %q (expected one of: %s)", c.ID, o, strings.Join(Outcomes, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+func isOutcome(name string) bool {
+	for _, o := range Outcomes {
+		if o == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Degraded classifies health checks that find the service up but impaired,
+// so time spent degraded is reported apart from time spent down
+type Degraded struct {
+	ExitCodes []int  `yaml:"exit_codes,omitempty"` // health_check_command exit codes that mean degraded
+	Latency   string `yaml:"latency,omitempty"`    // A passing check slower than this is degraded
+	Value     string `yaml:"value,omitempty"`      // Condition on a metric probe's value that means degraded, e.g. "> 0.01"
+	MaxWait   string `yaml:"max_wait,omitempty"`   // How long to keep probing a degraded service after recovery (default: rto_target)
+}
+
+// GetLatency returns the latency above which a passing check is degraded, or zero
+func (d *Degraded) GetLatency() (time.Duration, error) {
+	if d.Latency == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(d.Latency)
+}
+
+// GetMaxWait returns how long to keep probing while degraded, defaulting to rtoTarget
+func (d *Degraded) GetMaxWait(rtoTarget time.Duration) (time.Duration, error) {
+	if d.MaxWait == "" {
+		return rtoTarget, nil
+	}
+	return time.ParseDuration(d.MaxWait)
+}
+
+func (s *Scenario) validateDegraded() error {
+	d := s.Degraded
+	if d == nil {
+		return nil
+	}
+	if len(d.ExitCodes) == 0 && d.Latency == "" && d.Value == "" {
+		return fmt.Errorf("'degraded' requires 'exit_codes', 'latency', or 'value'")
+	}
+	for _, code := range d.ExitCodes {
+		if code == 0 {
+			return fmt.Errorf("'degraded.exit_codes' must not include 0, which means healthy")
+		}
+	}
+	if len(d.ExitCodes) > 0 && s.HealthCheck != nil {
+		return fmt.Errorf("'degraded.exit_codes' requires 'health_check_command'")
+	}
+	if latency, err := d.GetLatency(); err != nil {
+		return fmt.Errorf("invalid 'degraded.latency' duration: %w", err)
+	} else if d.Latency != "" && latency <= 0 {
+		return fmt.Errorf("'degraded.latency' must be positive")
+	}
+	if d.Value != "" {
+		if s.HealthCheck == nil || (s.HealthCheck.Type != "prometheus" && s.HealthCheck.Type != "cloudwatch") {
+			return fmt.Errorf("'degraded.value' requires a prometheus or cloudwatch health_check")
+		}
+		if _, err := ParseCondition(d.Value); err != nil {
+			return fmt.Errorf("invalid 'degraded.value': %w", err)
+		}
+	}
+	if _, err := d.GetMaxWait(0); err != nil {
+		return fmt.Errorf("invalid 'degraded.max_wait' duration: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ScenarioFile is a scenario found on disk
+type ScenarioFile struct {
+	Path     string
+	Scenario *Scenario
+}
+
+// FindScenarios parses every scenario file (*.yaml, *.yml) under dir in path
+// order without resolving variables; YAML files that are not scenarios are skipped
+func FindScenarios(dir string) ([]ScenarioFile, error) {
+	var found []ScenarioFile
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		if d.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			return nil
+		}
+
+		scenario, err := parseScenarioFile(path)
+		if err != nil || scenario.Name == "" || !scenario.DisruptCommand.IsSet() {
+			return nil
+		}
+		found = append(found, ScenarioFile{Path: path, Scenario: scenario})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan scenarios: %w", err)
+	}
+	return found, nil
+}
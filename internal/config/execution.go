@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Execution selects where a step's command runs
+type Execution struct {
+	Type      string `yaml:"type" json:"type"`                               // local (default), ssh, kubectl-exec, or docker
+	Host      string `yaml:"host,omitempty" json:"host,omitempty"`           // ssh: Remote host; docker: Daemon address (default: DOCKER_HOST)
+	Group     string `yaml:"group,omitempty" json:"group,omitempty"`         // ssh: Host inventory group; runs on every member, instead of host
+	User      string `yaml:"user,omitempty" json:"user,omitempty"`           // ssh: Login user (default: from ssh configuration)
+	Port      int    `yaml:"port,omitempty" json:"port,omitempty"`           // ssh: Port (default: 22)
+	Key       string `yaml:"key,omitempty" json:"key,omitempty"`             // ssh: Private key file
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"` // kubectl-exec: Pod namespace (default: from kubeconfig)
+	Selector  string `yaml:"selector,omitempty" json:"selector,omitempty"`   // kubectl-exec: Label selector; the first running pod is used
+	Pod       string `yaml:"pod,omitempty" json:"pod,omitempty"`             // kubectl-exec: Pod name, instead of selector
+	Container string `yaml:"container,omitempty" json:"container,omitempty"` // kubectl-exec: Container (default: the pod's default container); docker: Container name or ID
+	Context   string `yaml:"context,omitempty" json:"context,omitempty"`     // kubectl-exec: kubeconfig context
+}
+
+// IsRemote reports whether commands run somewhere other than the local shell
+func (e *Execution) IsRemote() bool {
+	return e != nil && e.Type != "" && e.Type != "local"
+}
+
+// Target returns the ssh destination, e.g. "ops@bastion", or the targeted group
+func (e *Execution) Target() string {
+	if e.Group != "" {
+		return "group/" + e.Group
+	}
+	if e.User == "" {
+		return e.Host
+	}
+	return e.User + "@" + e.Host
+}
+
+// AllowlistTarget identifies the execution target in allowlist entries:
+// ssh:ops@db1, ssh:group/web, kubectl-exec:<pod or selector>, or docker:<container>
+func (e *Execution) AllowlistTarget() string {
+	switch e.Type {
+	case "kubectl-exec":
+		if e.Pod != "" {
+			return e.Type + ":" + e.Pod
+		}
+		return e.Type + ":" + e.Selector
+	case "docker":
+		return e.Type + ":" + e.Container
+	}
+	return e.Type + ":" + e.Target()
+}
+
+// Validate checks the execution fields
+func (e *Execution) Validate() error {
+	if e.Group != "" && e.Type != "ssh" {
+		return fmt.Errorf("'group' requires type 'ssh'")
+	}
+	switch e.Type {
+	case "", "local":
+	case "ssh":
+		if (e.Host == "") == (e.Group == "") {
+			return fmt.Errorf("type 'ssh' requires exactly one of 'host' and 'group'")
+		}
+		if strings.HasPrefix(e.Host, "-") || strings.HasPrefix(e.User, "-") {
+			return fmt.Errorf("invalid ssh destination %q", e.Target())
+		}
+		if e.Port < 0 || e.Port > 65535 {
+			return fmt.Errorf("invalid 'port' %d", e.Port)
+		}
+	case "kubectl-exec":
+		if (e.Selector == "") == (e.Pod == "") {
+			return fmt.Errorf("type 'kubectl-exec' requires exactly one of 'selector' and 'pod'")
+		}
+	case "docker":
+		if e.Container == "" {
+			return fmt.Errorf("type 'docker' requires 'container'")
+		}
+	default:
+		return fmt.Errorf("unknown type %q (expected local, ssh, kubectl-exec, or docker)", e.Type)
+	}
+	return nil
+}
@@ -0,0 +1,730 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Expression is a parsed boolean expression in a subset of CEL: literals,
+// variables with .field and [index] access, arithmetic, comparisons, in, !,
+// &&, ||, parentheses, the has() macro, and the duration("5m") and size()
+// functions
+type Expression struct {
+	source string
+	root   exprNode
+}
+
+// exprNode is one node of a parsed expression
+type exprNode interface {
+	eval(env map[string]interface{}) (interface{}, error)
+}
+
+// ParseExpression parses a CEL-style expression
+func ParseExpression(source string) (*Expression, error) {
+	tokens, err := lexExpression(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEnd {
+		return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos+1)
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+// String returns the expression as written
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Eval evaluates the expression against env. Numbers are float64; nil marks
+// a value that was not measured, and using it is an error.
+func (e *Expression) Eval(env map[string]interface{}) (interface{}, error) {
+	return e.root.eval(env)
+}
+
+// EvalBool evaluates an expression that must produce true or false
+func (e *Expression) EvalBool(env map[string]interface{}) (bool, error) {
+	v, err := e.Eval(env)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression produced %s, not true or false", describeValue(v))
+	}
+	return b, nil
+}
+
+// Variables returns the variable paths the expression reads, such as
+// "rta" or "metrics.failed_requests"
+func (e *Expression) Variables() []string {
+	var paths []string
+	var walk func(n exprNode)
+	walk = func(n exprNode) {
+		switch n := n.(type) {
+		case *variableNode:
+			paths = append(paths, n.name)
+		case *fieldNode:
+			if path, ok := n.path(); ok {
+				paths = append(paths, path)
+			} else {
+				walk(n.object)
+			}
+		case *indexNode:
+			walk(n.object)
+			walk(n.index)
+		case *unaryNode:
+			walk(n.operand)
+		case *binaryNode:
+			walk(n.left)
+			walk(n.right)
+		case *callNode:
+			for _, arg := range n.args {
+				walk(arg)
+			}
+		case *hasNode:
+			// The field may be absent; that is what has() tests
+			walk(n.field.object)
+		}
+	}
+	walk(e.root)
+	return paths
+}
+
+// Tokens
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+)
+
+type exprToken struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// exprOperators is ordered so two-character operators match first
+var exprOperators = []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "+", "-", "*", "/", "%", "!", "(", ")", "[", "]", ".", ","}
+
+func lexExpression(source string) ([]exprToken, error) {
+	var tokens []exprToken
+	i := 0
+	for i < len(source) {
+		c := rune(source[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(source) && (source[i] >= '0' && source[i] <= '9' || source[i] == '.') {
+				i++
+			}
+			// Exponent, as in 1e3 or 2.5E-2
+			if i < len(source) && (source[i] == 'e' || source[i] == 'E') {
+				j := i + 1
+				if j < len(source) && (source[j] == '+' || source[j] == '-') {
+					j++
+				}
+				if j < len(source) && source[j] >= '0' && source[j] <= '9' {
+					for i = j; i < len(source) && source[i] >= '0' && source[i] <= '9'; i++ {
+					}
+				}
+			}
+			tokens = append(tokens, exprToken{kind: tokenNumber, text: source[start:i], pos: start})
+		case c == '_' || unicode.IsLetter(c):
+			start := i
+			for i < len(source) && (source[i] == '_' || unicode.IsLetter(rune(source[i])) || unicode.IsDigit(rune(source[i]))) {
+				i++
+			}
+			kind := tokenIdent
+			if source[start:i] == "in" {
+				kind = tokenOperator
+			}
+			tokens = append(tokens, exprToken{kind: kind, text: source[start:i], pos: start})
+		case c == '"' || c == '\'':
+			start := i
+			i++
+			var b strings.Builder
+			for i < len(source) && rune(source[i]) != c {
+				if source[i] == '\\' && i+1 < len(source) {
+					i++
+				}
+				b.WriteByte(source[i])
+				i++
+			}
+			if i >= len(source) {
+				return nil, fmt.Errorf("unterminated string at position %d", start+1)
+			}
+			i++
+			tokens = append(tokens, exprToken{kind: tokenString, text: b.String(), pos: start})
+		default:
+			matched := false
+			for _, op := range exprOperators {
+				if strings.HasPrefix(source[i:], op) {
+					tokens = append(tokens, exprToken{kind: tokenOperator, text: op, pos: i})
+					i += len(op)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected %q at position %d", string(c), i+1)
+			}
+		}
+	}
+	return append(tokens, exprToken{kind: tokenEnd, text: "end of expression", pos: len(source)}), nil
+}
+
+// Parser, lowest precedence first: || && comparison and in, + - * / % unary postfix
+
+type exprParser struct {
+	tokens []exprToken
+	next   int
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.next]
+}
+
+func (p *exprParser) accept(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokenOperator {
+		return "", false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.next++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *exprParser) expect(op string) error {
+	if _, ok := p.accept(op); !ok {
+		t := p.peek()
+		return fmt.Errorf("expected %q but found %q at position %d", op, t.text, t.pos+1)
+	}
+	return nil
+}
+
+func (p *exprParser) parseBinary(next func() (exprNode, error), ops ...string) (exprNode, error) {
+	left, err := next()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op, ok := p.accept(ops...)
+		if !ok {
+			return left, nil
+		}
+		right, err := next()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	return p.parseBinary(p.parseAnd, "||")
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	return p.parseBinary(p.parseComparison, "&&")
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	return p.parseBinary(p.parseSum, "==", "!=", "<=", ">=", "<", ">", "in")
+}
+
+func (p *exprParser) parseSum() (exprNode, error) {
+	return p.parseBinary(p.parseProduct, "+", "-")
+}
+
+func (p *exprParser) parseProduct() (exprNode, error) {
+	return p.parseBinary(p.parseUnary, "*", "/", "%")
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if op, ok := p.accept("!", "-"); ok {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: op, operand: operand}, nil
+	}
+	return p.parsePostfix()
+}
+
+func (p *exprParser) parsePostfix() (exprNode, error) {
+	node, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch op, _ := p.accept(".", "["); op {
+		case ".":
+			t := p.peek()
+			if t.kind != tokenIdent {
+				return nil, fmt.Errorf("expected a field name after '.' at position %d", t.pos+1)
+			}
+			p.next++
+			node = &fieldNode{object: node, field: t.text}
+		case "[":
+			index, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			node = &indexNode{object: node, index: index}
+		default:
+			return node, nil
+		}
+	}
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokenNumber:
+		p.next++
+		v, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.text, t.pos+1)
+		}
+		return &literalNode{value: v}, nil
+	case tokenString:
+		p.next++
+		return &literalNode{value: t.text}, nil
+	case tokenIdent:
+		p.next++
+		switch t.text {
+		case "true":
+			return &literalNode{value: true}, nil
+		case "false":
+			return &literalNode{value: false}, nil
+		case "null":
+			return &literalNode{value: nil}, nil
+		}
+		if _, ok := p.accept("("); ok {
+			return p.parseCall(t)
+		}
+		return &variableNode{name: t.text}, nil
+	case tokenOperator:
+		if t.text == "(" {
+			p.next++
+			node, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return node, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %q at position %d", t.text, t.pos+1)
+}
+
+func (p *exprParser) parseCall(name exprToken) (exprNode, error) {
+	if name.text == "has" {
+		return p.parseHas(name)
+	}
+	fn, ok := exprFunctions[name.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos+1)
+	}
+	call := &callNode{name: name.text, fn: fn}
+	if _, ok := p.accept(")"); ok {
+		return call, nil
+	}
+	for {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+		if _, ok := p.accept(","); !ok {
+			break
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+// parseHas parses the has(object.field) macro, whose argument must be a field
+// selection so that the field is tested rather than read
+func (p *exprParser) parseHas(name exprToken) (exprNode, error) {
+	arg, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	field, ok := arg.(*fieldNode)
+	if !ok {
+		return nil, fmt.Errorf("has() at position %d needs a field selection such as has(metrics.name)", name.pos+1)
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return &hasNode{field: field}, nil
+}
+
+// Evaluation
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n *literalNode) eval(map[string]interface{}) (interface{}, error) {
+	return n.value, nil
+}
+
+type variableNode struct {
+	name string
+}
+
+func (n *variableNode) eval(env map[string]interface{}) (interface{}, error) {
+	v, ok := env[n.name]
+	if !ok {
+		return nil, fmt.Errorf("unknown variable %q", n.name)
+	}
+	return v, nil
+}
+
+type fieldNode struct {
+	object exprNode
+	field  string
+}
+
+// path returns the dotted variable path when the node is a chain of fields
+// on a variable, e.g. metrics.failed_requests
+func (n *fieldNode) path() (string, bool) {
+	switch object := n.object.(type) {
+	case *variableNode:
+		return object.name + "." + n.field, true
+	case *fieldNode:
+		if path, ok := object.path(); ok {
+			return path + "." + n.field, true
+		}
+	}
+	return "", false
+}
+
+func (n *fieldNode) eval(env map[string]interface{}) (interface{}, error) {
+	object, err := n.object.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := object.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cannot read field %q of %s", n.field, describeValue(object))
+	}
+	v, ok := m[n.field]
+	if !ok {
+		return nil, fmt.Errorf("no field %q", n.field)
+	}
+	return v, nil
+}
+
+// hasNode tests whether a map has a field, which reading it directly would
+// report as an error when it does not
+type hasNode struct {
+	field *fieldNode
+}
+
+func (n *hasNode) eval(env map[string]interface{}) (interface{}, error) {
+	object, err := n.field.object.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := object.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("has(): cannot test field %q of %s", n.field.field, describeValue(object))
+	}
+	_, ok = m[n.field.field]
+	return ok, nil
+}
+
+type indexNode struct {
+	object exprNode
+	index  exprNode
+}
+
+func (n *indexNode) eval(env map[string]interface{}) (interface{}, error) {
+	object, err := n.object.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	index, err := n.index.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch o := object.(type) {
+	case map[string]interface{}:
+		key, ok := index.(string)
+		if !ok {
+			return nil, fmt.Errorf("map key must be a string, not %s", describeValue(index))
+		}
+		v, ok := o[key]
+		if !ok {
+			return nil, fmt.Errorf("no key %q", key)
+		}
+		return v, nil
+	case []interface{}:
+		i, ok := index.(float64)
+		if !ok || i != math.Trunc(i) || i < 0 || int(i) >= len(o) {
+			return nil, fmt.Errorf("index %s out of range (length %d)", describeValue(index), len(o))
+		}
+		return o[int(i)], nil
+	}
+	return nil, fmt.Errorf("cannot index %s", describeValue(object))
+}
+
+type unaryNode struct {
+	op      string
+	operand exprNode
+}
+
+func (n *unaryNode) eval(env map[string]interface{}) (interface{}, error) {
+	v, err := n.operand.eval(env)
+	if err != nil {
+		return nil, err
+	}
+	switch n.op {
+	case "!":
+		if b, ok := v.(bool); ok {
+			return !b, nil
+		}
+	case "-":
+		if f, ok := v.(float64); ok {
+			return -f, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot apply %q to %s", n.op, describeValue(v))
+}
+
+type binaryNode struct {
+	op          string
+	left, right exprNode
+}
+
+func (n *binaryNode) eval(env map[string]interface{}) (interface{}, error) {
+	left, err := n.left.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	// && and || short-circuit, so a guard like "x != null && x < 5" works
+	if n.op == "&&" || n.op == "||" {
+		l, ok := left.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%q needs true or false, not %s", n.op, describeValue(left))
+		}
+		if (n.op == "&&") != l {
+			return l, nil
+		}
+		right, err := n.right.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		r, ok := right.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%q needs true or false, not %s", n.op, describeValue(right))
+		}
+		return r, nil
+	}
+
+	right, err := n.right.eval(env)
+	if err != nil {
+		return nil, err
+	}
+
+	if n.op == "in" {
+		switch r := right.(type) {
+		case map[string]interface{}:
+			key, ok := left.(string)
+			if !ok {
+				return nil, fmt.Errorf("map key must be a string, not %s", describeValue(left))
+			}
+			_, ok = r[key]
+			return ok, nil
+		case []interface{}:
+			if !isComparable(left) {
+				return nil, fmt.Errorf("cannot look for %s in a list", describeValue(left))
+			}
+			for _, v := range r {
+				if v == left {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+		return nil, fmt.Errorf("\"in\" needs a list or map, not %s", describeValue(right))
+	}
+
+	if n.op == "==" || n.op == "!=" {
+		if !isComparable(left) || !isComparable(right) {
+			return nil, fmt.Errorf("cannot compare %s and %s", describeValue(left), describeValue(right))
+		}
+		return (left == right) == (n.op == "=="), nil
+	}
+
+	if n.op == "+" {
+		if l, ok := left.(string); ok {
+			if r, ok := right.(string); ok {
+				return l + r, nil
+			}
+		}
+	}
+	if l, ok := left.(string); ok {
+		if r, ok := right.(string); ok {
+			switch n.op {
+			case "<":
+				return l < r, nil
+			case "<=":
+				return l <= r, nil
+			case ">":
+				return l > r, nil
+			case ">=":
+				return l >= r, nil
+			}
+		}
+	}
+
+	l, lok := left.(float64)
+	r, rok := right.(float64)
+	if !lok || !rok {
+		return nil, fmt.Errorf("cannot apply %q to %s and %s", n.op, describeValue(left), describeValue(right))
+	}
+	switch n.op {
+	case "<":
+		return l < r, nil
+	case "<=":
+		return l <= r, nil
+	case ">":
+		return l > r, nil
+	case ">=":
+		return l >= r, nil
+	case "+":
+		return l + r, nil
+	case "-":
+		return l - r, nil
+	case "*":
+		return l * r, nil
+	case "/":
+		if r == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return l / r, nil
+	case "%":
+		if r == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return math.Mod(l, r), nil
+	}
+	return nil, fmt.Errorf("unknown operator %q", n.op)
+}
+
+type callNode struct {
+	name string
+	fn   func(args []interface{}) (interface{}, error)
+	args []exprNode
+}
+
+func (n *callNode) eval(env map[string]interface{}) (interface{}, error) {
+	args := make([]interface{}, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval(env)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = v
+	}
+	v, err := n.fn(args)
+	if err != nil {
+		return nil, fmt.Errorf("%s(): %w", n.name, err)
+	}
+	return v, nil
+}
+
+// exprFunctions are the functions an expression can call
+var exprFunctions = map[string]func(args []interface{}) (interface{}, error){
+	// duration("5m") is the duration in seconds, the unit of duration variables
+	"duration": func(args []interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("takes one argument")
+		}
+		s, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("needs a string such as \"5m\", not %s", describeValue(args[0]))
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, err
+		}
+		return d.Seconds(), nil
+	},
+	// size() is the length of a string, list, or map
+	"size": func(args []interface{}) (interface{}, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("takes one argument")
+		}
+		switch v := args[0].(type) {
+		case string:
+			return float64(len(v)), nil
+		case []interface{}:
+			return float64(len(v)), nil
+		case map[string]interface{}:
+			return float64(len(v)), nil
+		}
+		return nil, fmt.Errorf("cannot take the size of %s", describeValue(args[0]))
+	},
+}
+
+// isComparable reports whether v can be compared with == and !=
+func isComparable(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	return true
+}
+
+// describeValue names a value's type and value for error messages
+func describeValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null (not measured)"
+	case bool:
+		return fmt.Sprintf("bool %t", v)
+	case float64:
+		return "number " + strconv.FormatFloat(v, 'g', -1, 64)
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case map[string]interface{}:
+		return "a map"
+	case []interface{}:
+		return "a list"
+	}
+	return fmt.Sprintf("%T", v)
+}
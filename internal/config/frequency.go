@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// frequencies are the named drill frequencies, as calendar months and days
+var frequencies = map[string]struct{ months, days int }{
+	"daily":        {0, 1},
+	"weekly":       {0, 7},
+	"monthly":      {1, 0},
+	"quarterly":    {3, 0},
+	"semiannually": {6, 0},
+	"annually":     {12, 0},
+}
+
+// NextDrillDue returns when the scenario must next be drilled after a drill
+// at last, or false if it sets no frequency
+func (s *Scenario) NextDrillDue(last time.Time) (time.Time, bool) {
+	if s.Frequency == "" {
+		return time.Time{}, false
+	}
+	months, days, err := parseFrequency(s.Frequency)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return last.AddDate(0, months, days), true
+}
+
+// parseFrequency parses a named frequency, or a number of days such as 45d
+func parseFrequency(frequency string) (months, days int, err error) {
+	if f, ok := frequencies[strings.ToLower(frequency)]; ok {
+		return f.months, f.days, nil
+	}
+	if n, err := strconv.Atoi(strings.TrimSuffix(frequency, "d")); err == nil && n > 0 && strings.HasSuffix(frequency, "d") {
+		return 0, n, nil
+	}
+	return 0, 0, fmt.Errorf("expected daily, weekly, monthly, quarterly, semiannually, annually, or a number of days such as 45d")
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// Built-in GCP actions for scenario steps
+const (
+	GCPInstanceStop     = "gce_instance_stop"
+	GCPInstanceReset    = "gce_instance_reset"
+	GCPInstanceStart    = "gce_instance_start"
+	GCPCloudSQLFailover = "cloudsql_failover"
+	GCPLoggingRead      = "logging_read"
+	GCPMonitoringRead   = "monitoring_read"
+)
+
+// GCPAction is a built-in Google Cloud disruptor, recovery step, or factor collector
+type GCPAction struct {
+	Action   string `yaml:"action" json:"action"`
+	Project  string `yaml:"project,omitempty" json:"project,omitempty"`   // Default: the gcloud configuration's project
+	Zone     string `yaml:"zone,omitempty" json:"zone,omitempty"`         // gce_*: Instance zone
+	Instance string `yaml:"instance,omitempty" json:"instance,omitempty"` // gce_*, cloudsql_failover: Instance name
+	Filter   string `yaml:"filter,omitempty" json:"filter,omitempty"`     // logging_read, monitoring_read: Additional filter
+	Metric   string `yaml:"metric,omitempty" json:"metric,omitempty"`     // monitoring_read: Metric type
+	Window   string `yaml:"window,omitempty" json:"window,omitempty"`     // logging_read, monitoring_read: Look-back period (default: 1h)
+	Limit    int    `yaml:"limit,omitempty" json:"limit,omitempty"`       // logging_read: Maximum entries (default: 100)
+}
+
+// validate checks the fields required by the action
+func (g *GCPAction) validate() error {
+	switch g.Action {
+	case GCPInstanceStop, GCPInstanceReset, GCPInstanceStart:
+		if g.Instance == "" || g.Zone == "" {
+			return fmt.Errorf("action %q requires 'instance' and 'zone'", g.Action)
+		}
+	case GCPCloudSQLFailover:
+		if g.Instance == "" {
+			return fmt.Errorf("action %q requires 'instance'", g.Action)
+		}
+	case GCPLoggingRead:
+		if g.Filter == "" {
+			return fmt.Errorf("action %q requires 'filter'", g.Action)
+		}
+	case GCPMonitoringRead:
+		if g.Metric == "" || g.Project == "" {
+			return fmt.Errorf("action %q requires 'metric' and 'project'", g.Action)
+		}
+	case "":
+		return fmt.Errorf("required field 'action' is missing")
+	default:
+		return fmt.Errorf("unknown action %q", g.Action)
+	}
+
+	if g.Window != "" {
+		if _, err := time.ParseDuration(g.Window); err != nil {
+			return fmt.Errorf("invalid 'window' duration: %w", err)
+		}
+	}
+	if g.Limit < 0 {
+		return fmt.Errorf("'limit' must not be negative")
+	}
+	return nil
+}
+
+// GetWindow returns the look-back period, defaulting to one hour
+func (g *GCPAction) GetWindow() time.Duration {
+	if g.Window == "" {
+		return time.Hour
+	}
+	d, _ := time.ParseDuration(g.Window)
+	return d
+}
@@ -0,0 +1,30 @@
+package config
+
+import "fmt"
+
+// Guard is a precondition checked immediately before the disruption, such as
+// current traffic being low or no incident being open. If any guard fails the
+// disruption is not run.
+type Guard struct {
+	Name      string  `yaml:"name" json:"name"`
+	Command   Command `yaml:"command" json:"command"`
+	Condition string  `yaml:"condition,omitempty" json:"condition,omitempty"` // Threshold for the number the command prints, e.g. "< 500"; without it the command must succeed
+}
+
+func (g *Guard) validate(field string) error {
+	if g.Name == "" {
+		return fmt.Errorf("'%s' requires 'name'", field)
+	}
+	if !g.Command.IsSet() {
+		return fmt.Errorf("'%s' requires 'command'", field)
+	}
+	if err := g.Command.validate(field + ".command"); err != nil {
+		return err
+	}
+	if g.Condition != "" {
+		if _, err := ParseCondition(g.Condition); err != nil {
+			return fmt.Errorf("invalid '%s.condition': %w", field, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// HostInventory groups ssh hosts by role so a step can target a whole group
+type HostInventory struct {
+	Groups map[string]HostGroup `yaml:"groups"`
+}
+
+// HostGroup lists the hosts of one role with connection defaults for its members
+type HostGroup struct {
+	Hosts []string `yaml:"hosts"`
+	User  string   `yaml:"user,omitempty"`
+	Port  int      `yaml:"port,omitempty"`
+	Key   string   `yaml:"key,omitempty"`
+}
+
+// LoadHostInventory reads a YAML host inventory file
+func LoadHostInventory(filePath string) (*HostInventory, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read hosts file: %w", err)
+	}
+
+	var inv HostInventory
+	if err := yaml.Unmarshal(data, &inv); err != nil {
+		return nil, fmt.Errorf("failed to parse hosts file: %w", err)
+	}
+
+	for _, name := range inv.GroupNames() {
+		if len(inv.Groups[name].Hosts) == 0 {
+			return nil, fmt.Errorf("hosts file group %q has no hosts", name)
+		}
+	}
+	return &inv, nil
+}
+
+// GroupNames returns the group names in sorted order
+func (h *HostInventory) GroupNames() []string {
+	var names []string
+	for name := range h.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Members returns one ssh execution per host of the group. Fields set on e
+// override the group's defaults.
+func (h *HostInventory) Members(e *Execution) ([]*Execution, error) {
+	if h == nil {
+		return nil, fmt.Errorf("group %q requires 'hosts_file'", e.Group)
+	}
+	group, ok := h.Groups[e.Group]
+	if !ok {
+		return nil, fmt.Errorf("unknown host group %q", e.Group)
+	}
+
+	members := make([]*Execution, 0, len(group.Hosts))
+	for _, host := range group.Hosts {
+		member := *e
+		member.Group = ""
+		member.Host = host
+		if member.User == "" {
+			member.User = group.User
+		}
+		if member.Port == 0 {
+			member.Port = group.Port
+		}
+		if member.Key == "" {
+			member.Key = group.Key
+		}
+		members = append(members, &member)
+	}
+	return members, nil
+}
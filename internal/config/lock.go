@@ -0,0 +1,44 @@
+package config
+
+import "fmt"
+
+// Lock configures the run lock that keeps drills against the same service
+// from overlapping
+type Lock struct {
+	Key     string `yaml:"key,omitempty" json:"key,omitempty"`         // Default: the scenario's service
+	Backend string `yaml:"backend,omitempty" json:"backend,omitempty"` // file (default), consul, or none
+	Dir     string `yaml:"dir,omitempty" json:"dir,omitempty"`         // file: Directory shared by everyone running drills (default: reports/.locks)
+	URL     string `yaml:"url,omitempty" json:"url,omitempty"`         // consul: Agent address (default: CONSUL_HTTP_ADDR, or http://127.0.0.1:8500)
+}
+
+// LockKey names the run lock a drill of this scenario takes
+func (s *Scenario) LockKey() string {
+	if s.Lock != nil && s.Lock.Key != "" {
+		return s.Lock.Key
+	}
+	return s.ServiceName()
+}
+
+// LockBackend returns where the run lock is kept
+func (s *Scenario) LockBackend() string {
+	if s.Lock == nil || s.Lock.Backend == "" {
+		return "file"
+	}
+	return s.Lock.Backend
+}
+
+func (l *Lock) validate() error {
+	switch l.Backend {
+	case "", "file", "none":
+		if l.URL != "" {
+			return fmt.Errorf("'url' only applies to backend 'consul'")
+		}
+	case "consul":
+		if l.Dir != "" {
+			return fmt.Errorf("'dir' only applies to backend 'file'")
+		}
+	default:
+		return fmt.Errorf("unknown backend %q (expected file, consul, or none)", l.Backend)
+	}
+	return nil
+}
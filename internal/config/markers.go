@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MarkerPlaceholder is replaced with the marker's timestamp in write_command
+const MarkerPlaceholder = "{{marker}}"
+
+// defaultMarkerInterval is the time between marker writes when none is set
+const defaultMarkerInterval = time.Second
+
+// defaultMarkerLeads is how many intervals markers are written before the
+// disruption when no lead is set
+const defaultMarkerLeads = 5
+
+// Markers measures data loss by writing timestamped marker records up to the
+// moment of the disruption and finding the newest one that survived recovery
+type Markers struct {
+	WriteCommand Command `yaml:"write_command"`      // Writes one marker; {{marker}} is replaced with its time (RFC 3339)
+	ReadCommand  Command `yaml:"read_command"`       // Prints the newest surviving marker after recovery
+	Interval     string  `yaml:"interval,omitempty"` // Time between writes (default: 1s)
+	Lead         string  `yaml:"lead,omitempty"`     // How long to write markers before disrupting (default: 5 intervals)
+}
+
+// GetInterval returns the time between marker writes
+func (m *Markers) GetInterval() (time.Duration, error) {
+	if m.Interval == "" {
+		return defaultMarkerInterval, nil
+	}
+	return time.ParseDuration(m.Interval)
+}
+
+// GetLead returns how long markers are written before the disruption
+func (m *Markers) GetLead() (time.Duration, error) {
+	if m.Lead == "" {
+		interval, err := m.GetInterval()
+		return defaultMarkerLeads * interval, err
+	}
+	return time.ParseDuration(m.Lead)
+}
+
+// WithMarker returns write_command with the placeholder replaced by marker
+func (m *Markers) WithMarker(marker string) Command {
+	c := m.WriteCommand
+	c.Run = strings.ReplaceAll(c.Run, MarkerPlaceholder, marker)
+	if len(c.Argv) > 0 {
+		c.Argv = make([]string, len(m.WriteCommand.Argv))
+		for i, arg := range m.WriteCommand.Argv {
+			c.Argv[i] = strings.ReplaceAll(arg, MarkerPlaceholder, marker)
+		}
+	}
+	return c
+}
+
+func (m *Markers) validate() error {
+	if !m.WriteCommand.IsSet() {
+		return fmt.Errorf("required field 'write_command' is missing")
+	}
+	if !m.ReadCommand.IsSet() {
+		return fmt.Errorf("required field 'read_command' is missing")
+	}
+	if err := m.WriteCommand.validate("write_command"); err != nil {
+		return err
+	}
+	if err := m.ReadCommand.validate("read_command"); err != nil {
+		return err
+	}
+	if !strings.Contains(m.WriteCommand.Run+strings.Join(m.WriteCommand.Argv, " "), MarkerPlaceholder) {
+		return fmt.Errorf("'write_command' must contain %s", MarkerPlaceholder)
+	}
+	interval, err := m.GetInterval()
+	if err != nil {
+		return fmt.Errorf("invalid 'interval' duration: %w", err)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("'interval' must be positive")
+	}
+	lead, err := m.GetLead()
+	if err != nil {
+		return fmt.Errorf("invalid 'lead' duration: %w", err)
+	}
+	if lead < 0 {
+		return fmt.Errorf("'lead' must not be negative")
+	}
+	return nil
+}
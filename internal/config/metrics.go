@@ -0,0 +1,150 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// metricNamePattern keeps metric names usable as identifiers in reports and expressions
+var metricNamePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// Metric is a business-relevant value, such as a queue backlog or failed
+// request count, read from a command's output before the disruption and
+// again after recovery
+type Metric struct {
+	Name    string  `yaml:"name"`
+	Command Command `yaml:"command"`
+	Regex   string  `yaml:"regex,omitempty"` // Extracts the value; the first capture group if any (default: the last non-empty line)
+	JSON    string  `yaml:"json,omitempty"`  // Path to the value in JSON output, e.g. .queues[0].backlog
+}
+
+func (m *Metric) validate(field string) error {
+	if !metricNamePattern.MatchString(m.Name) {
+		return fmt.Errorf("'%s.name' must be lowercase letters, digits, and underscores, e.g. failed_requests", field)
+	}
+	if !m.Command.IsSet() {
+		return fmt.Errorf("'%s' requires 'command'", field)
+	}
+	if err := m.Command.validate(field + ".command"); err != nil {
+		return err
+	}
+	if m.Regex != "" && m.JSON != "" {
+		return fmt.Errorf("'%s' may set only one of 'regex' and 'json'", field)
+	}
+	if m.Regex != "" {
+		if _, err := regexp.Compile(m.Regex); err != nil {
+			return fmt.Errorf("invalid '%s.regex': %w", field, err)
+		}
+	}
+	if m.JSON != "" {
+		if _, err := ParseJSONPath(m.JSON); err != nil {
+			return fmt.Errorf("invalid '%s.json': %w", field, err)
+		}
+	}
+	return nil
+}
+
+func (s *Scenario) validateMetrics() error {
+	names := map[string]bool{}
+	for i := range s.Metrics {
+		m := &s.Metrics[i]
+		if err := m.validate(fmt.Sprintf("metrics[%d]", i)); err != nil {
+			return err
+		}
+		if names[m.Name] {
+			return fmt.Errorf("duplicate metrics name %q", m.Name)
+		}
+		names[m.Name] = true
+	}
+	return nil
+}
+
+// JSONPath is a jq-style path of object keys and array indexes, such as
+// .queues[0].backlog
+type JSONPath []interface{}
+
+// ParseJSONPath parses a path of .key and [index] steps; "." is the whole document
+func ParseJSONPath(path string) (JSONPath, error) {
+	if !strings.HasPrefix(path, ".") {
+		return nil, fmt.Errorf("path %q must start with '.'", path)
+	}
+	var steps JSONPath
+	rest := path
+	if rest == "." {
+		return steps, nil
+	}
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '[' in %q", path)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid index %q in %q", rest[1:end], path)
+			}
+			steps = append(steps, index)
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("empty key in %q", path)
+			}
+			steps = append(steps, rest[:end])
+			rest = rest[end:]
+		default:
+			return nil, fmt.Errorf("unexpected %q in %q", rest, path)
+		}
+	}
+	return steps, nil
+}
+
+// Lookup finds the value at the path in a JSON document and returns it as
+// text: strings unquoted, anything else as JSON
+func (p JSONPath) Lookup(document string) (string, error) {
+	var value interface{}
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return "", fmt.Errorf("output is not JSON: %w", err)
+	}
+
+	for _, step := range p {
+		switch key := step.(type) {
+		case string:
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return "", fmt.Errorf("cannot read key %q of a non-object", key)
+			}
+			if value, ok = object[key]; !ok {
+				return "", fmt.Errorf("key %q not found", key)
+			}
+		case int:
+			array, ok := value.([]interface{})
+			if !ok {
+				return "", fmt.Errorf("cannot index a non-array with [%d]", key)
+			}
+			if key >= len(array) {
+				return "", fmt.Errorf("index [%d] out of range (length %d)", key, len(array))
+			}
+			value = array[key]
+		}
+	}
+
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// Redact configures secrets removed from command output before it is stored
+// in results and reports
+type Redact struct {
+	Values   []string `yaml:"values,omitempty"`   // Literal secret values
+	Env      []string `yaml:"env,omitempty"`      // Environment variables whose values are secret
+	Patterns []string `yaml:"patterns,omitempty"` // Regular expressions; only the first capture group is redacted when there is one
+}
+
+// MarshalJSON masks the literal values, so reports record how many there
+// were but not what they are
+func (r Redact) MarshalJSON() ([]byte, error) {
+	type plain Redact
+	masked := plain(r)
+	masked.Values = make([]string, len(r.Values))
+	for i := range masked.Values {
+		masked.Values[i] = maskedValue
+	}
+	return json.Marshal(masked)
+}
+
+// CompilePatterns compiles the redaction patterns
+func (r *Redact) CompilePatterns() ([]*regexp.Regexp, error) {
+	var patterns []*regexp.Regexp
+	for _, p := range r.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", p, err)
+		}
+		if re.MatchString("") {
+			return nil, fmt.Errorf("redact pattern %q matches empty text", p)
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns, nil
+}
+
+// redactValues returns the non-empty literal and environment secret values
+func (r *Redact) redactValues() []string {
+	var values []string
+	for _, v := range r.Values {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	for _, name := range r.Env {
+		if v := os.Getenv(name); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func (s *Scenario) validateRedact() error {
+	r := s.Redact
+	if r == nil {
+		return nil
+	}
+	for _, v := range r.Values {
+		if v == "" {
+			return fmt.Errorf("'redact.values' must not contain empty values")
+		}
+	}
+	for _, name := range r.Env {
+		if name == "" {
+			return fmt.Errorf("'redact.env' must not contain empty names")
+		}
+	}
+	_, err := r.CompilePatterns()
+	return err
+}
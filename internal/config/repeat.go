@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultCoolDown is the pause between repeated drills when none is set
+const DefaultCoolDown = time.Minute
+
+// Repeat runs the drill several times to measure how much RTA varies
+type Repeat struct {
+	Count    int    `yaml:"count"`               // Number of drills to run
+	CoolDown string `yaml:"cool_down,omitempty"` // Pause between drills (default: 1m)
+}
+
+// GetCoolDown returns the pause between repeated drills
+func (r *Repeat) GetCoolDown() (time.Duration, error) {
+	if r.CoolDown == "" {
+		return DefaultCoolDown, nil
+	}
+	return time.ParseDuration(r.CoolDown)
+}
+
+func (r *Repeat) validate() error {
+	if r.Count < 1 {
+		return fmt.Errorf("'repeat.count' must be at least 1")
+	}
+	coolDown, err := r.GetCoolDown()
+	if err != nil {
+		return fmt.Errorf("invalid 'repeat.cool_down' duration: %w", err)
+	}
+	if coolDown < 0 {
+		return fmt.Errorf("'repeat.cool_down' must not be negative")
+	}
+	return nil
+}
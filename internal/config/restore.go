@@ -0,0 +1,52 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// RestoreCheck restores a backup into a scratch target, runs integrity
+// checks against it, and times the restore
+type RestoreCheck struct {
+	Command         Command `yaml:"command"`                    // Restores the latest backup into the scratch target
+	Target          string  `yaml:"target,omitempty"`           // Maximum restore time (default: not compared)
+	IntegrityChecks []Guard `yaml:"integrity_checks,omitempty"` // Queries against the restored data; same fields as guards
+	CleanupCommand  Command `yaml:"cleanup_command,omitempty"`  // Removes the scratch target; runs even if the restore failed
+}
+
+// GetTarget returns the maximum restore time, or zero if not set
+func (r *RestoreCheck) GetTarget() (time.Duration, error) {
+	if r.Target == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(r.Target)
+}
+
+func (r *RestoreCheck) validate() error {
+	if !r.Command.IsSet() {
+		return fmt.Errorf("required field 'command' is missing")
+	}
+	if err := r.Command.validate("command"); err != nil {
+		return err
+	}
+	if err := r.CleanupCommand.validate("cleanup_command"); err != nil {
+		return err
+	}
+	if target, err := r.GetTarget(); err != nil {
+		return fmt.Errorf("invalid 'target' duration: %w", err)
+	} else if r.Target != "" && target <= 0 {
+		return fmt.Errorf("'target' must be positive")
+	}
+	names := map[string]bool{}
+	for i := range r.IntegrityChecks {
+		check := &r.IntegrityChecks[i]
+		if err := check.validate(fmt.Sprintf("integrity_checks[%d]", i)); err != nil {
+			return err
+		}
+		if names[check.Name] {
+			return fmt.Errorf("duplicate integrity check name %q", check.Name)
+		}
+		names[check.Name] = true
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RetentionEnv names the retention policy prune applies when given no rules
+const RetentionEnv = "DRILLMEASURE_RETENTION"
+
+// RetentionRule is how long a scenario's runs are kept. A run is pruned only
+// when it is outside every limit set: not among the scenario's keep_last
+// most recent runs, and started more than older_than ago.
+type RetentionRule struct {
+	KeepLast  int    `yaml:"keep_last,omitempty"`  // Most recent runs of the scenario that are always kept
+	OlderThan string `yaml:"older_than,omitempty"` // Age after which runs may be pruned, in days (180d) or a duration
+}
+
+// RetentionPolicy is the retention rule for every scenario, with overrides
+// for some
+type RetentionPolicy struct {
+	RetentionRule `yaml:",inline"`
+	Scenarios     map[string]RetentionRule `yaml:"scenarios,omitempty"` // By scenario name; fields left unset are the default rule's
+}
+
+// LoadRetentionPolicy reads a YAML retention policy file
+func LoadRetentionPolicy(filePath string) (*RetentionPolicy, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read retention policy: %w", err)
+	}
+
+	var policy RetentionPolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse retention policy: %w", err)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid retention policy %s: %w", filePath, err)
+	}
+	return &policy, nil
+}
+
+// Validate checks every rule of the policy limits retention
+func (p *RetentionPolicy) Validate() error {
+	if err := p.RetentionRule.validate(); err != nil {
+		return err
+	}
+	for name := range p.Scenarios {
+		if err := p.Rule(name).validate(); err != nil {
+			return fmt.Errorf("scenario %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Rule returns the retention rule of a scenario
+func (p *RetentionPolicy) Rule(scenario string) RetentionRule {
+	rule := p.RetentionRule
+	if override, ok := p.Scenarios[scenario]; ok {
+		if override.KeepLast != 0 {
+			rule.KeepLast = override.KeepLast
+		}
+		if override.OlderThan != "" {
+			rule.OlderThan = override.OlderThan
+		}
+	}
+	return rule
+}
+
+// GetOlderThan returns the age after which runs may be pruned, or zero if
+// the rule sets none
+func (r RetentionRule) GetOlderThan() time.Duration {
+	d, _ := parseAge(r.OlderThan) // Validated when the policy was loaded
+	return d
+}
+
+func (r RetentionRule) validate() error {
+	if r.KeepLast < 0 {
+		return fmt.Errorf("'keep_last' must not be negative")
+	}
+	if r.OlderThan != "" {
+		if _, err := parseAge(r.OlderThan); err != nil {
+			return fmt.Errorf("invalid 'older_than' %q: %w", r.OlderThan, err)
+		}
+	}
+	if r.KeepLast == 0 && r.OlderThan == "" {
+		return fmt.Errorf("one of 'keep_last' and 'older_than' is required")
+	}
+	return nil
+}
+
+// parseAge parses a number of days such as 180d, or a duration such as 72h
+func parseAge(age string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(age, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	} else if d, err := time.ParseDuration(age); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("expected a number of days such as 180d, or a duration such as 72h")
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// NamedRPOCheck checks the data loss of one data store in rpo_checks
+type NamedRPOCheck struct {
+	Name          string             `yaml:"name"`
+	Target        string             `yaml:"target,omitempty"` // RPO target for this data store (default: rpo_target)
+	PreSnapshot   Command            `yaml:"pre_snapshot,omitempty"`
+	PostSnapshot  Command            `yaml:"post_snapshot,omitempty"`
+	VerifyCommand Command            `yaml:"verify_command,omitempty"`
+	Timestamp     *SnapshotTimestamp `yaml:"timestamp,omitempty"`
+	VerifyOutput  *VerifyOutput      `yaml:"verify_output,omitempty"`
+}
+
+// GetTarget returns the check's RPO target, or fallback if it has none
+func (c *NamedRPOCheck) GetTarget(fallback time.Duration) (time.Duration, error) {
+	if c.Target == "" {
+		return fallback, nil
+	}
+	return time.ParseDuration(c.Target)
+}
+
+func (s *Scenario) validateRPOChecks() error {
+	names := map[string]bool{}
+	for i, c := range s.RPOChecks {
+		field := fmt.Sprintf("rpo_checks[%d]", i)
+		if c.Name == "" {
+			return fmt.Errorf("'%s' requires 'name'", field)
+		}
+		if names[c.Name] {
+			return fmt.Errorf("duplicate rpo_checks name %q", c.Name)
+		}
+		names[c.Name] = true
+
+		if !c.VerifyCommand.IsSet() && c.Timestamp == nil {
+			return fmt.Errorf("'%s' requires 'verify_command' or 'timestamp'", field)
+		}
+		for name, cmd := range map[string]Command{
+			"pre_snapshot":   c.PreSnapshot,
+			"post_snapshot":  c.PostSnapshot,
+			"verify_command": c.VerifyCommand,
+		} {
+			if err := cmd.validate(field + "." + name); err != nil {
+				return err
+			}
+		}
+		if c.Target != "" {
+			if d, err := time.ParseDuration(c.Target); err != nil || d <= 0 {
+				return fmt.Errorf("invalid '%s.target' duration %q", field, c.Target)
+			}
+		}
+		if c.Timestamp != nil {
+			if !c.PreSnapshot.IsSet() || !c.PostSnapshot.IsSet() {
+				return fmt.Errorf("invalid '%s.timestamp': requires 'pre_snapshot' and 'post_snapshot'", field)
+			}
+			if err := c.Timestamp.validate(field + ".timestamp"); err != nil {
+				return err
+			}
+		}
+		if v := c.VerifyOutput; v != nil {
+			if !c.VerifyCommand.IsSet() {
+				return fmt.Errorf("invalid '%s.verify_output': requires 'verify_command'", field)
+			}
+			if !v.Rows() && c.Timestamp != nil {
+				return fmt.Errorf("'%s.verify_output' in seconds cannot be combined with 'timestamp'", field)
+			}
+			if err := v.validate(field + ".verify_output"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SeedPlaceholder is replaced with the write's sequence number in seed_command
+const SeedPlaceholder = "{{seq}}"
+
+// defaultSeedInterval is the time between seed writes when none is set
+const defaultSeedInterval = time.Second
+
+// GetSeedInterval returns the time between seed_command runs
+func (s *Scenario) GetSeedInterval() (time.Duration, error) {
+	if s.SeedInterval == "" {
+		return defaultSeedInterval, nil
+	}
+	return time.ParseDuration(s.SeedInterval)
+}
+
+// SeedCommandFor returns seed_command with the placeholder replaced by seq
+func (s *Scenario) SeedCommandFor(seq int) Command {
+	value := fmt.Sprintf("%d", seq)
+	c := s.SeedCommand
+	c.Run = strings.ReplaceAll(c.Run, SeedPlaceholder, value)
+	if len(c.Argv) > 0 {
+		c.Argv = make([]string, len(s.SeedCommand.Argv))
+		for i, arg := range s.SeedCommand.Argv {
+			c.Argv[i] = strings.ReplaceAll(arg, SeedPlaceholder, value)
+		}
+	}
+	return c
+}
+
+func (s *Scenario) validateSeed() error {
+	if !s.SeedCommand.IsSet() {
+		if s.SeedInterval != "" {
+			return fmt.Errorf("'seed_interval' requires 'seed_command'")
+		}
+		return nil
+	}
+	if err := s.SeedCommand.validate("seed_command"); err != nil {
+		return err
+	}
+	interval, err := s.GetSeedInterval()
+	if err != nil {
+		return fmt.Errorf("invalid 'seed_interval' duration: %w", err)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("'seed_interval' must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// shellArgs maps known shell names to the argv prefix the command is appended to
+var shellArgs = map[string][]string{
+	"bash":       {"bash", "-c"},
+	"sh":         {"sh", "-c"},
+	"powershell": {"powershell", "-NoProfile", "-NonInteractive", "-Command"},
+	"pwsh":       {"pwsh", "-NoProfile", "-NonInteractive", "-Command"},
+	"cmd":        {"cmd", "/C"},
+	"python3":    {"python3", "-c"},
+	"python":     {"python", "-c"},
+	"node":       {"node", "-e"},
+	"perl":       {"perl", "-e"},
+}
+
+// Shell selects how a command string is interpreted. In YAML it is either a
+// known shell or interpreter name (bash, sh, powershell, pwsh, cmd, python3,
+// python, node, perl) or an explicit argv
+// prefix such as [python3, -c] that the command is appended to.
+type Shell struct {
+	Name string
+	Argv []string
+}
+
+// UnmarshalYAML accepts a shell name or an argv sequence
+func (s *Shell) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&s.Name)
+	}
+	return node.Decode(&s.Argv)
+}
+
+// MarshalJSON writes the shell in the form it was configured
+func (s Shell) MarshalJSON() ([]byte, error) {
+	if s.Argv != nil {
+		return json.Marshal(s.Argv)
+	}
+	return json.Marshal(s.Name)
+}
+
+// UnmarshalJSON accepts both forms written by MarshalJSON
+func (s *Shell) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &s.Name)
+	}
+	return json.Unmarshal(data, &s.Argv)
+}
+
+// Args returns the argv prefix for the shell, or nil if none is configured
+func (s *Shell) Args() []string {
+	if s == nil {
+		return nil
+	}
+	if len(s.Argv) > 0 {
+		return s.Argv
+	}
+	return shellArgs[s.Name]
+}
+
+// validate checks that the named field selects a known shell
+func (s *Shell) validate(field string) error {
+	if s == nil {
+		return nil
+	}
+	if s.Name != "" && shellArgs[s.Name] == nil {
+		return fmt.Errorf("'%s' has unknown shell %q (expected bash, sh, powershell, pwsh, cmd, python3, python, node, perl, or an argv list)", field, s.Name)
+	}
+	if s.Name == "" && len(s.Argv) == 0 {
+		return fmt.Errorf("'%s' has an empty shell argv", field)
+	}
+	return nil
+}
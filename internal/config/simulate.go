@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Simulation declares the outcomes commands report under run --simulate,
+// where nothing is executed
+type Simulation struct {
+	Commands []SimulatedCommand `yaml:"commands,omitempty" json:"commands,omitempty"` // Checked in order; the first match applies
+	Script   string             `yaml:"script,omitempty" json:"script,omitempty"`     // Fault script deciding the outcome of commands no entry matches
+}
+
+// SimulatedCommand gives the outcomes of the commands matching a regex. The
+// outcome can be set inline, or as a sequence under 'outcomes'.
+type SimulatedCommand struct {
+	Match            string             `yaml:"match" json:"match"`                           // Regex matched against the command as written
+	Outcomes         []SimulatedOutcome `yaml:"outcomes,omitempty" json:"outcomes,omitempty"` // Used in turn by successive calls; the last repeats
+	SimulatedOutcome `yaml:",inline"`
+
+	pattern *regexp.Regexp
+}
+
+// SimulatedOutcome is the result a simulated command reports
+type SimulatedOutcome struct {
+	ExitCode int    `yaml:"exit_code,omitempty" json:"exit_code,omitempty"`
+	Latency  string `yaml:"latency,omitempty" json:"latency,omitempty"` // How long the command appears to run
+	Stdout   string `yaml:"stdout,omitempty" json:"stdout,omitempty"`
+	Stderr   string `yaml:"stderr,omitempty" json:"stderr,omitempty"`
+	Times    int    `yaml:"times,omitempty" json:"times,omitempty"` // Consecutive calls this outcome answers (default: 1)
+}
+
+// GetLatency returns the simulated run time
+func (o SimulatedOutcome) GetLatency() (time.Duration, error) {
+	if o.Latency == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(o.Latency)
+}
+
+// Matches reports whether command is simulated by this entry
+func (c *SimulatedCommand) Matches(command string) bool {
+	if c.pattern == nil {
+		c.pattern = regexp.MustCompile(c.Match)
+	}
+	return c.pattern.MatchString(command)
+}
+
+// Outcome returns the outcome of the call'th (0-based) matching command
+func (c *SimulatedCommand) Outcome(call int) SimulatedOutcome {
+	if len(c.Outcomes) == 0 {
+		return c.SimulatedOutcome
+	}
+	for _, o := range c.Outcomes {
+		times := o.Times
+		if times <= 0 {
+			times = 1
+		}
+		if call < times {
+			return o
+		}
+		call -= times
+	}
+	return c.Outcomes[len(c.Outcomes)-1]
+}
+
+func (s *Simulation) validate() error {
+	for i, c := range s.Commands {
+		field := fmt.Sprintf("commands[%d]", i)
+		if c.Match == "" {
+			return fmt.Errorf("%s: 'match' is required", field)
+		}
+		if _, err := regexp.Compile(c.Match); err != nil {
+			return fmt.Errorf("%s: invalid match: %w", field, err)
+		}
+		if len(c.Outcomes) > 0 && c.SimulatedOutcome != (SimulatedOutcome{}) {
+			return fmt.Errorf("%s: set the outcome inline or under 'outcomes', not both", field)
+		}
+		outcomes := append([]SimulatedOutcome{c.SimulatedOutcome}, c.Outcomes...)
+		for j, o := range outcomes {
+			if _, err := o.GetLatency(); err != nil {
+				return fmt.Errorf("%s: invalid latency %q: %w", field, o.Latency, err)
+			}
+			if o.Times < 0 || (j == 0 && o.Times != 0) {
+				return fmt.Errorf("%s: 'times' must be positive and only applies to entries under 'outcomes'", field)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SLAsEnv names the SLA registry used by scenarios without sla_file
+const SLAsEnv = "DRILLMEASURE_SLAS"
+
+// SLARegistry is the recovery commitments made for services, which scenarios
+// reference by ID instead of repeating their targets
+type SLARegistry struct {
+	Path string `yaml:"-" json:"path,omitempty"`
+	SLAs []SLA  `yaml:"slas" json:"slas"`
+}
+
+// SLA is the RTO and RPO committed to for a service
+type SLA struct {
+	ID           string  `yaml:"id" json:"id"`
+	Service      string  `yaml:"service,omitempty" json:"service,omitempty"`
+	Description  string  `yaml:"description,omitempty" json:"description,omitempty"`
+	RTO          string  `yaml:"rto" json:"rto"`
+	RPO          string  `yaml:"rpo,omitempty" json:"rpo,omitempty"`                   // Optional: no data loss commitment if unset
+	Availability float64 `yaml:"availability,omitempty" json:"availability,omitempty"` // Optional: annual availability percentage, e.g. 99.9, which sets the service's downtime budget
+	Path         string  `yaml:"-" json:"path,omitempty"`                              // Registry the SLA was loaded from
+}
+
+// LoadSLARegistry reads a YAML SLA registry file
+func LoadSLARegistry(filePath string) (*SLARegistry, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SLA file: %w", err)
+	}
+
+	registry := SLARegistry{Path: filePath}
+	if err := yaml.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("failed to parse SLA file: %w", err)
+	}
+	if err := registry.validate(); err != nil {
+		return nil, fmt.Errorf("invalid SLA file %s: %w", filePath, err)
+	}
+	for i := range registry.SLAs {
+		registry.SLAs[i].Path = filePath
+	}
+	return &registry, nil
+}
+
+// Find returns the SLA with the ID, or nil if the registry has none
+func (r *SLARegistry) Find(id string) *SLA {
+	for i := range r.SLAs {
+		if r.SLAs[i].ID == id {
+			return &r.SLAs[i]
+		}
+	}
+	return nil
+}
+
+func (r *SLARegistry) validate() error {
+	if len(r.SLAs) == 0 {
+		return fmt.Errorf("no SLAs")
+	}
+	seen := map[string]bool{}
+	for i, sla := range r.SLAs {
+		if sla.ID == "" {
+			return fmt.Errorf("'slas[%d]' requires 'id'", i)
+		}
+		if seen[sla.ID] {
+			return fmt.Errorf("duplicate SLA %q", sla.ID)
+		}
+		seen[sla.ID] = true
+		if sla.RTO == "" {
+			return fmt.Errorf("SLA %q requires 'rto'", sla.ID)
+		}
+		if _, err := time.ParseDuration(sla.RTO); err != nil {
+			return fmt.Errorf("SLA %q has an invalid 'rto': %w", sla.ID, err)
+		}
+		if sla.RPO != "" {
+			if _, err := time.ParseDuration(sla.RPO); err != nil {
+				return fmt.Errorf("SLA %q has an invalid 'rpo': %w", sla.ID, err)
+			}
+		}
+		if sla.Availability != 0 {
+			if sla.Availability <= 0 || sla.Availability >= 100 {
+				return fmt.Errorf("SLA %q has an invalid 'availability' %v: must be a percentage between 0 and 100", sla.ID, sla.Availability)
+			}
+			if sla.Service == "" {
+				return fmt.Errorf("SLA %q requires 'service' with 'availability', to know whose downtime to count", sla.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// GetRTO returns the committed RTO
+func (s *SLA) GetRTO() time.Duration {
+	d, _ := time.ParseDuration(s.RTO) // Validated when the registry was loaded
+	return d
+}
+
+// GetRPO returns the committed RPO, or zero if the SLA commits to none
+func (s *SLA) GetRPO() time.Duration {
+	d, _ := time.ParseDuration(s.RPO)
+	return d
+}
+
+// DowntimeBudget returns the downtime the SLA's availability allows over a
+// period, or zero if it commits to no availability
+func (s *SLA) DowntimeBudget(period time.Duration) time.Duration {
+	if s.Availability == 0 {
+		return 0
+	}
+	return time.Duration(float64(period) * (100 - s.Availability) / 100)
+}
+
+// applySLA resolves the scenario's sla from the registry. Targets the
+// scenario leaves unset are the SLA's, the RPO only if the scenario has an
+// rpo_check to measure it with; ones it sets may only be stricter, which
+// validate checks.
+func (s *Scenario) applySLA(registry *SLARegistry) error {
+	sla := registry.Find(s.SLA)
+	if sla == nil {
+		return fmt.Errorf("unknown SLA %q (not in %s)", s.SLA, registry.Path)
+	}
+	s.SLADefinition = sla
+	if s.RTOTarget == "" {
+		s.RTOTarget = sla.RTO
+	}
+	if s.RPOTarget == "" && sla.RPO != "" && s.RPOCheck != nil {
+		s.RPOTarget = sla.RPO
+	}
+	return nil
+}
+
+// validateSLA checks the scenario's targets are no looser than its SLA's
+func (s *Scenario) validateSLA() error {
+	if s.TargetSource != nil {
+		return fmt.Errorf("only one of 'sla' and 'target_source' may be set")
+	}
+	if s.SLADefinition == nil {
+		// Parsed without resolving the SLA, e.g. when discovering scenarios
+		return nil
+	}
+	sla := s.SLADefinition
+	if rto, err := time.ParseDuration(s.RTOTarget); err == nil && rto > sla.GetRTO() {
+		return fmt.Errorf("'rto_target' (%s) is looser than SLA %q's RTO (%s)", s.RTOTarget, sla.ID, sla.RTO)
+	}
+	if rpo, err := time.ParseDuration(s.RPOTarget); err == nil && sla.RPO != "" && rpo > sla.GetRPO() {
+		return fmt.Errorf("'rpo_target' (%s) is looser than SLA %q's RPO (%s)", s.RPOTarget, sla.ID, sla.RPO)
+	}
+	return nil
+}
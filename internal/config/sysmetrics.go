@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// DefaultSystemMetricsInterval is the time between system metric samples when none is set
+const DefaultSystemMetricsInterval = 5 * time.Second
+
+// SystemMetrics samples host metrics in the background for the whole drill.
+// Without a command, CPU, memory, load, and disk usage are read on the host
+// running drillmeasure.
+type SystemMetrics struct {
+	Interval string  `yaml:"interval,omitempty"`  // Time between samples (default: 5s)
+	DiskPath string  `yaml:"disk_path,omitempty"` // Filesystem whose usage is sampled (default: /)
+	Command  Command `yaml:"command,omitempty"`   // Prints name=value lines instead of the built-in metrics
+}
+
+// GetInterval returns the time between samples
+func (m *SystemMetrics) GetInterval() (time.Duration, error) {
+	if m.Interval == "" {
+		return DefaultSystemMetricsInterval, nil
+	}
+	return time.ParseDuration(m.Interval)
+}
+
+// GetDiskPath returns the filesystem whose usage is sampled
+func (m *SystemMetrics) GetDiskPath() string {
+	if m.DiskPath == "" {
+		return "/"
+	}
+	return m.DiskPath
+}
+
+func (m *SystemMetrics) validate() error {
+	interval, err := m.GetInterval()
+	if err != nil {
+		return fmt.Errorf("invalid 'factors.system_metrics.interval' duration: %w", err)
+	}
+	if interval < time.Second {
+		return fmt.Errorf("'factors.system_metrics.interval' must be at least 1s")
+	}
+	if m.Command.IsSet() {
+		if m.DiskPath != "" {
+			return fmt.Errorf("'factors.system_metrics' may set only one of 'command' and 'disk_path'")
+		}
+		if err := m.Command.validate("factors.system_metrics.command"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
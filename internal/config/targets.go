@@ -0,0 +1,58 @@
+package config
+
+import "fmt"
+
+// TargetSource resolves rto_target and rpo_target at run time from a centrally
+// governed source, so targets can change without editing every scenario
+type TargetSource struct {
+	Type   string `yaml:"type" json:"type"`                           // http, consul, or ssm
+	URL    string `yaml:"url,omitempty" json:"url,omitempty"`         // http: Endpoint returning {"rto_target": "5m", "rpo_target": "15m"}; consul: Agent address (default: CONSUL_HTTP_ADDR, or http://127.0.0.1:8500)
+	RTOKey string `yaml:"rto_key,omitempty" json:"rto_key,omitempty"` // consul: KV key; ssm: Parameter name
+	RPOKey string `yaml:"rpo_key,omitempty" json:"rpo_key,omitempty"` // consul: KV key; ssm: Parameter name
+	Region string `yaml:"region,omitempty" json:"region,omitempty"`   // ssm: AWS region (default: from the AWS configuration)
+}
+
+// ProvidesRTO reports whether the source may supply the RTO target, making
+// rto_target in the scenario optional
+func (t *TargetSource) ProvidesRTO() bool {
+	return t != nil && (t.Type == "http" || t.RTOKey != "")
+}
+
+// String describes the source, e.g. "ssm /dr/checkout/rto"
+func (t *TargetSource) String() string {
+	switch t.Type {
+	case "http":
+		return "http " + t.URL
+	default:
+		keys := t.RTOKey
+		if t.RPOKey != "" {
+			if keys != "" {
+				keys += ", "
+			}
+			keys += t.RPOKey
+		}
+		return t.Type + " " + keys
+	}
+}
+
+func (t *TargetSource) validate() error {
+	switch t.Type {
+	case "http":
+		if t.URL == "" {
+			return fmt.Errorf("type 'http' requires 'url'")
+		}
+		if t.RTOKey != "" || t.RPOKey != "" {
+			return fmt.Errorf("type 'http' reads both targets from 'url' and does not use 'rto_key' or 'rpo_key'")
+		}
+	case "consul", "ssm":
+		if t.RTOKey == "" && t.RPOKey == "" {
+			return fmt.Errorf("type '%s' requires 'rto_key' or 'rpo_key'", t.Type)
+		}
+	default:
+		return fmt.Errorf("unknown type %q (expected http, consul, or ssm)", t.Type)
+	}
+	if t.Region != "" && t.Type != "ssm" {
+		return fmt.Errorf("'region' only applies to type 'ssm'")
+	}
+	return nil
+}
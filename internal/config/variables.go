@@ -0,0 +1,222 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// variableRef matches ${{ name }} references in scenario fields
+var variableRef = regexp.MustCompile(`\$\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+// secretNameHints are substrings that mark a variable as secret when found in its name
+var secretNameHints = []string{"password", "passwd", "secret", "token", "credential", "apikey", "api_key", "private_key"}
+
+// maskedValue replaces secret variable values in reports
+const maskedValue = "********"
+
+// ParseValuesFile reads a YAML file of variable values
+func ParseValuesFile(filePath string) (map[string]string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read values file: %w", err)
+	}
+
+	values := map[string]string{}
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse values file: %w", err)
+	}
+
+	return values, nil
+}
+
+// ParseScenarioWithVariables parses a scenario and substitutes ${{ name }}
+// references using the scenario's own variables overridden by the given values
+func ParseScenarioWithVariables(filePath string, overrides map[string]string) (*Scenario, error) {
+	scenario, err := parseScenarioFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if scenario.Variables == nil {
+		scenario.Variables = map[string]string{}
+	}
+	for name, value := range overrides {
+		scenario.Variables[name] = value
+	}
+
+	if err := scenario.expandVariables(); err != nil {
+		return nil, err
+	}
+	scenario.applyCloudDefaults()
+
+	if scenario.HostsFile != "" {
+		if scenario.Hosts, err = LoadHostInventory(scenario.HostsFile); err != nil {
+			return nil, err
+		}
+	}
+
+	controlsFile := scenario.ControlsFile
+	if controlsFile == "" {
+		controlsFile = os.Getenv(ControlsEnv)
+	}
+	if controlsFile != "" {
+		if scenario.Controls, err = LoadControlMapping(controlsFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if scenario.SLA != "" {
+		slaFile := scenario.SLAFile
+		if slaFile == "" {
+			slaFile = os.Getenv(SLAsEnv)
+		}
+		if slaFile == "" {
+			return nil, fmt.Errorf("'sla' requires 'sla_file' or $%s", SLAsEnv)
+		}
+		registry, err := LoadSLARegistry(slaFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := scenario.applySLA(registry); err != nil {
+			return nil, err
+		}
+	}
+
+	return scenario, nil
+}
+
+// expandVariables replaces variable references in every string field of the scenario
+func (s *Scenario) expandVariables() error {
+	var missing []string
+	expand := func(in string) string {
+		return variableRef.ReplaceAllStringFunc(in, func(ref string) string {
+			name := variableRef.FindStringSubmatch(ref)[1]
+			value, ok := s.Variables[name]
+			if !ok {
+				missing = append(missing, name)
+				return ref
+			}
+			return value
+		})
+	}
+
+	expandValue(reflect.ValueOf(s).Elem(), expand)
+
+	if len(missing) > 0 {
+		return fmt.Errorf("undefined variables: %s", strings.Join(uniqueSorted(missing), ", "))
+	}
+	return nil
+}
+
+// expandValue walks structs, pointers, slices, and map values applying expand
+// to each string
+func expandValue(v reflect.Value, expand func(string) string) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			expandValue(v.Elem(), expand)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).Name == "Variables" || !v.Field(i).CanSet() {
+				continue
+			}
+			expandValue(v.Field(i), expand)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			expandValue(v.Index(i), expand)
+		}
+	case reflect.Map:
+		// Map values are not addressable, so each is expanded in a copy and set back
+		iter := v.MapRange()
+		for iter.Next() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(iter.Value())
+			expandValue(value, expand)
+			v.SetMapIndex(iter.Key(), value)
+		}
+	case reflect.String:
+		v.SetString(expand(v.String()))
+	}
+}
+
+// IsSecretVariable reports whether a variable's value must be masked in reports
+func (s *Scenario) IsSecretVariable(name string) bool {
+	for _, secret := range s.SecretVariables {
+		if secret == name {
+			return true
+		}
+	}
+
+	return IsSecretName(name)
+}
+
+// IsSecretName reports whether a variable or environment variable name suggests a secret value
+func IsSecretName(name string) bool {
+	lower := strings.ToLower(name)
+	for _, hint := range secretNameHints {
+		if strings.Contains(lower, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// SecretValues returns the non-empty values of secret variables and of the
+// redact block's values and environment variables, longest first so a secret
+// containing another is masked whole
+func (s *Scenario) SecretValues() []string {
+	var values []string
+	for name, value := range s.Variables {
+		if value != "" && s.IsSecretVariable(name) {
+			values = append(values, value)
+		}
+	}
+	if s.Redact != nil {
+		values = append(values, s.Redact.redactValues()...)
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	return values
+}
+
+// MaskedVariables returns the resolved variables with secret values masked
+func (s *Scenario) MaskedVariables() map[string]string {
+	masked := make(map[string]string, len(s.Variables))
+	for name, value := range s.Variables {
+		if s.IsSecretVariable(name) {
+			value = maskedValue
+		}
+		masked[name] = value
+	}
+	return masked
+}
+
+// VariableNames returns the resolved variable names in sorted order
+func (s *Scenario) VariableNames() []string {
+	names := make([]string, 0, len(s.Variables))
+	for name := range s.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func uniqueSorted(in []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, s := range in {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
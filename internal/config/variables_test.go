@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseScenarioWithVariablesExpandsMapValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	scenario := `name: alb-failover
+rto_target: 5m
+disrupt_command: echo disrupt
+variables:
+  lb: app/checkout/0123456789abcdef
+health_check:
+  type: cloudwatch
+  namespace: AWS/ApplicationELB
+  metric: HTTPCode_ELB_5XX_Count
+  statistic: Sum
+  condition: "< 1"
+  dimensions:
+    LoadBalancer: ${{ lb }}
+`
+	if err := os.WriteFile(path, []byte(scenario), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := ParseScenarioWithVariables(path, map[string]string{"lb": "app/checkout/fedcba9876543210"})
+	if err != nil {
+		t.Fatalf("ParseScenarioWithVariables: %v", err)
+	}
+	if got, want := s.HealthCheck.Dimensions["LoadBalancer"], "app/checkout/fedcba9876543210"; got != want {
+		t.Errorf("dimension LoadBalancer = %q, want %q", got, want)
+	}
+}
+
+func TestParseScenarioWithVariablesReportsUndefinedInMapValues(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	scenario := `name: alb-failover
+rto_target: 5m
+disrupt_command: echo disrupt
+health_check:
+  type: cloudwatch
+  namespace: AWS/ApplicationELB
+  metric: HTTPCode_ELB_5XX_Count
+  condition: "< 1"
+  dimensions:
+    LoadBalancer: ${{ lb }}
+`
+	if err := os.WriteFile(path, []byte(scenario), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseScenarioWithVariables(path, nil); err == nil {
+		t.Fatal("expected an undefined variable error for a dimension value")
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import "fmt"
+
+// Built-in vSphere actions for scenario steps
+const (
+	VSphereVMPowerOff           = "vm_power_off"
+	VSphereVMPowerOn            = "vm_power_on"
+	VSphereVMReset              = "vm_reset"
+	VSphereVMMigrate            = "vm_migrate"
+	VSphereHostReboot           = "host_reboot"
+	VSphereHostMaintenanceEnter = "host_maintenance_enter"
+	VSphereHostMaintenanceExit  = "host_maintenance_exit"
+)
+
+// VSphereAction is a built-in VMware vSphere disruptor or recovery step
+type VSphereAction struct {
+	Action     string `yaml:"action" json:"action"`
+	VM         string `yaml:"vm,omitempty" json:"vm,omitempty"`                 // vm_*: VM name or inventory path
+	Host       string `yaml:"host,omitempty" json:"host,omitempty"`             // vm_migrate: Destination ESXi host; host_*: ESXi host
+	Datastore  string `yaml:"datastore,omitempty" json:"datastore,omitempty"`   // vm_migrate: Destination datastore (storage vMotion)
+	URL        string `yaml:"url,omitempty" json:"url,omitempty"`               // vCenter URL (default: GOVC_URL)
+	Datacenter string `yaml:"datacenter,omitempty" json:"datacenter,omitempty"` // Default: GOVC_DATACENTER
+	Insecure   bool   `yaml:"insecure,omitempty" json:"insecure,omitempty"`     // Skip TLS verification
+}
+
+// validate checks the fields required by the action
+func (v *VSphereAction) validate() error {
+	switch v.Action {
+	case VSphereVMPowerOff, VSphereVMPowerOn, VSphereVMReset:
+		if v.VM == "" {
+			return fmt.Errorf("action %q requires 'vm'", v.Action)
+		}
+	case VSphereVMMigrate:
+		if v.VM == "" || (v.Host == "" && v.Datastore == "") {
+			return fmt.Errorf("action %q requires 'vm' and 'host' or 'datastore'", v.Action)
+		}
+	case VSphereHostReboot, VSphereHostMaintenanceEnter, VSphereHostMaintenanceExit:
+		if v.Host == "" {
+			return fmt.Errorf("action %q requires 'host'", v.Action)
+		}
+	case "":
+		return fmt.Errorf("required field 'action' is missing")
+	default:
+		return fmt.Errorf("unknown action %q", v.Action)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaintenanceWindow is a recurring time range in which a drill may disrupt
+type MaintenanceWindow struct {
+	Days     []string `yaml:"days,omitempty" json:"days,omitempty"`         // mon through sun (default: every day)
+	Start    string   `yaml:"start" json:"start"`                           // HH:MM
+	End      string   `yaml:"end" json:"end"`                               // HH:MM; earlier than start for windows that cross midnight
+	Timezone string   `yaml:"timezone,omitempty" json:"timezone,omitempty"` // IANA time zone, e.g. Europe/Berlin (default: UTC)
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// parseClock parses HH:MM into minutes after midnight
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q (expected HH:MM)", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func (w MaintenanceWindow) location() (*time.Location, error) {
+	if w.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(w.Timezone)
+}
+
+func (w MaintenanceWindow) validate() error {
+	for _, d := range w.Days {
+		if _, ok := weekdays[strings.ToLower(d)]; !ok {
+			return fmt.Errorf("unknown day %q (expected mon, tue, wed, thu, fri, sat, or sun)", d)
+		}
+	}
+	if _, err := parseClock(w.Start); err != nil {
+		return fmt.Errorf("'start': %w", err)
+	}
+	if _, err := parseClock(w.End); err != nil {
+		return fmt.Errorf("'end': %w", err)
+	}
+	if _, err := w.location(); err != nil {
+		return fmt.Errorf("invalid 'timezone': %w", err)
+	}
+	return nil
+}
+
+// onDay reports whether the window opens on day
+func (w MaintenanceWindow) onDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if weekdays[strings.ToLower(d)] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains reports whether t falls inside the window. A window that crosses
+// midnight belongs to the day it opens on; one whose start equals its end
+// lasts the whole day.
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	loc, err := w.location()
+	if err != nil {
+		return false
+	}
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return false
+	}
+
+	t = t.In(loc)
+	now := t.Hour()*60 + t.Minute()
+	if start < end {
+		return w.onDay(t.Weekday()) && now >= start && now < end
+	}
+	if now >= start {
+		return w.onDay(t.Weekday())
+	}
+	return now < end && w.onDay(t.AddDate(0, 0, -1).Weekday())
+}
+
+// String describes the window, e.g. "tue,thu 09:00-16:00 Europe/Berlin"
+func (w MaintenanceWindow) String() string {
+	days := "daily"
+	if len(w.Days) > 0 {
+		days = strings.ToLower(strings.Join(w.Days, ","))
+	}
+	tz := w.Timezone
+	if tz == "" {
+		tz = "UTC"
+	}
+	return fmt.Sprintf("%s %s-%s %s", days, w.Start, w.End, tz)
+}
+
+// InAllowedWindow reports whether t is inside one of the scenario's
+// allowed_windows, or the scenario sets none
+func (s *Scenario) InAllowedWindow(t time.Time) bool {
+	if len(s.AllowedWindows) == 0 {
+		return true
+	}
+	for _, w := range s.AllowedWindows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// DescribeAllowedWindows lists the scenario's allowed_windows
+func (s *Scenario) DescribeAllowedWindows() string {
+	descriptions := make([]string, len(s.AllowedWindows))
+	for i, w := range s.AllowedWindows {
+		descriptions[i] = w.String()
+	}
+	return strings.Join(descriptions, "; ")
+}
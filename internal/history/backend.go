@@ -0,0 +1,33 @@
+package history
+
+import "strings"
+
+// Backend is a database the run history is kept in. The Store writes SQL
+// that SQLite and Postgres both accept, and asks the backend for the few
+// expressions where they differ.
+type Backend interface {
+	// Location identifies the database in messages, without credentials
+	Location() string
+	// Exec runs SQL statements
+	Exec(sql string) error
+	// Query returns the rows of a SELECT as a JSON array of objects keyed by
+	// column name, in the order the query returns them
+	Query(query string) ([]byte, error)
+	// Columns returns the names of the table's columns
+	Columns(table string) ([]string, error)
+	// Upsert returns a statement inserting a row, replacing the one with the
+	// same primary key if there is one
+	Upsert(table string, key, columns, values []string) string
+	// Time returns an expression ordering and comparing an RFC 3339 text
+	// value chronologically
+	Time(expr string) string
+	// Contains returns an expression that is true when the text column
+	// contains the substring expression
+	Contains(column, substr string) string
+}
+
+// isPostgresURL reports whether the history location is a Postgres
+// connection URL rather than a data directory
+func isPostgresURL(location string) bool {
+	return strings.HasPrefix(location, "postgres://") || strings.HasPrefix(location, "postgresql://")
+}
@@ -0,0 +1,88 @@
+package history
+
+import (
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// DowntimeBudget is a service's downtime over a period, from drills and real
+// incidents, against what its SLA's availability allows
+type DowntimeBudget struct {
+	SLA           *config.SLA
+	Budget        time.Duration // Downtime the availability allows over the period
+	Drills        time.Duration // Measured by drills of the service in the period
+	DrillCount    int
+	Incidents     time.Duration // From incidents imported for the service
+	IncidentCount int
+	Month         time.Duration // Drill and incident downtime in the current month
+}
+
+// Used returns the downtime counted against the budget
+func (b DowntimeBudget) Used() time.Duration {
+	return b.Drills + b.Incidents
+}
+
+// Remaining returns the budget left, negative once it is exhausted
+func (b DowntimeBudget) Remaining() time.Duration {
+	return b.Budget - b.Used()
+}
+
+// UsedPercent returns the share of the budget used
+func (b DowntimeBudget) UsedPercent() float64 {
+	if b.Budget == 0 {
+		return 0
+	}
+	return float64(b.Used()) / float64(b.Budget) * 100
+}
+
+// DowntimeBudgets returns the downtime budget over [from, to) of each SLA in
+// the registry with an availability, with the downtime the history records
+// for its service. Every non-simulated run with measured downtime counts,
+// including failed and incomplete ones, since the service was down either
+// way. Downtime in the month containing now is also reported separately.
+func (s *Store) DowntimeBudgets(registry *config.SLARegistry, from, to, now time.Time) ([]DowntimeBudget, error) {
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	inMonth := func(t time.Time) bool {
+		return !t.Before(monthStart) && t.Before(monthStart.AddDate(0, 1, 0))
+	}
+
+	var budgets []DowntimeBudget
+	for i := range registry.SLAs {
+		sla := &registry.SLAs[i]
+		if sla.Availability == 0 {
+			continue
+		}
+		b := DowntimeBudget{SLA: sla, Budget: sla.DowntimeBudget(to.Sub(from))}
+
+		runs, err := s.Runs(Filter{Service: sla.Service, From: from, To: to})
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range runs {
+			rta, err := time.ParseDuration(run.RTA)
+			if run.Simulated || err != nil || rta <= 0 {
+				continue
+			}
+			b.Drills += rta
+			b.DrillCount++
+			if start, err := time.Parse(time.RFC3339, run.StartTime); err == nil && inMonth(start) {
+				b.Month += rta
+			}
+		}
+
+		incidents, err := s.Incidents(sla.Service, from, to)
+		if err != nil {
+			return nil, err
+		}
+		for _, incident := range incidents {
+			b.Incidents += incident.GetDowntime()
+			b.IncidentCount++
+			if start, err := time.Parse(time.RFC3339, incident.StartTime); err == nil && inMonth(start) {
+				b.Month += incident.GetDowntime()
+			}
+		}
+		budgets = append(budgets, b)
+	}
+	return budgets, nil
+}
@@ -0,0 +1,388 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/report"
+)
+
+// DataDirEnv overrides the default data directory
+const DataDirEnv = "DRILLMEASURE_DATA_DIR"
+
+// URLEnv keeps the history in the Postgres database at this URL instead of
+// the data directory
+const URLEnv = "DRILLMEASURE_HISTORY_URL"
+
+const schema = `CREATE TABLE IF NOT EXISTS runs (
+	report_path TEXT PRIMARY KEY,
+	scenario    TEXT NOT NULL,
+	service     TEXT NOT NULL DEFAULT '',
+	start_time  TEXT NOT NULL,
+	end_time    TEXT NOT NULL DEFAULT '',
+	rto_target  TEXT NOT NULL DEFAULT '',
+	rta         TEXT NOT NULL DEFAULT '',
+	rto_passed  INTEGER NOT NULL DEFAULT 0,
+	rpo_target  TEXT NOT NULL DEFAULT '',
+	rpo_actual  TEXT NOT NULL DEFAULT '',
+	rpo_passed  INTEGER NOT NULL DEFAULT 0,
+	passed      INTEGER NOT NULL DEFAULT 0,
+	incomplete  INTEGER NOT NULL DEFAULT 0,
+	simulated   INTEGER NOT NULL DEFAULT 0,
+	errors      INTEGER NOT NULL DEFAULT 0,
+	recorded_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS runs_scenario_start ON runs (scenario, start_time);
+CREATE TABLE IF NOT EXISTS baselines (
+	scenario    TEXT PRIMARY KEY,
+	report_path TEXT NOT NULL,
+	set_at      TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS incidents (
+	service     TEXT NOT NULL,
+	start_time  TEXT NOT NULL,
+	downtime    TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	recorded_at TEXT NOT NULL,
+	PRIMARY KEY (service, start_time)
+);
+`
+
+// addedColumns are columns added to the runs table after it was created,
+// which older databases are upgraded with
+var addedColumns = []struct{ name, definition string }{
+	{"tags", "TEXT NOT NULL DEFAULT ''"}, // Comma-separated, with leading and trailing commas
+	{"sla", "TEXT NOT NULL DEFAULT ''"},  // ID of the SLA the run validated
+}
+
+// Run results
+const (
+	ResultPass       = "pass"
+	ResultFail       = "fail"
+	ResultIncomplete = "incomplete"
+	ResultSimulated  = "simulated"
+)
+
+// Results lists the run results history can be filtered by
+var Results = []string{ResultPass, ResultFail, ResultIncomplete, ResultSimulated}
+
+// Run is a run's key results as kept in the history
+type Run struct {
+	ReportPath string   `json:"report_path"` // Absolute path of the run directory
+	Scenario   string   `json:"scenario"`
+	Service    string   `json:"service"`
+	Tags       []string `json:"-"`
+	SLA        string   `json:"sla"`
+	StartTime  string   `json:"start_time"`
+	EndTime    string   `json:"end_time"`
+	RTOTarget  string   `json:"rto_target"`
+	RTA        string   `json:"rta"`
+	RTOPassed  bool     `json:"rto_passed"`
+	RPOTarget  string   `json:"rpo_target"`
+	RPOActual  string   `json:"rpo_actual"`
+	RPOPassed  bool     `json:"rpo_passed"`
+	Passed     bool     `json:"passed"` // Met the RTO target and, if set, the RPO target
+	Incomplete bool     `json:"incomplete"`
+	Simulated  bool     `json:"simulated"`
+	Errors     int      `json:"errors"`
+	RecordedAt string   `json:"recorded_at"`
+}
+
+// NewRun summarizes a run's report for the history
+func NewRun(runDir string, data *report.ReportData) (Run, error) {
+	path, err := filepath.Abs(runDir)
+	if err != nil {
+		return Run{}, err
+	}
+	run := Run{
+		ReportPath: path,
+		StartTime:  data.StartTime,
+		EndTime:    data.EndTime,
+		RTOTarget:  data.RTOTarget,
+		RTA:        data.RTA,
+		RTOPassed:  data.RTOPassed,
+		RPOTarget:  data.RPOTarget,
+		RPOActual:  data.RPOActual,
+		RPOPassed:  data.RPOPassed,
+		Incomplete: data.Status == "incomplete",
+		Simulated:  data.Simulated,
+		Errors:     len(data.Errors),
+	}
+	if data.Scenario != nil {
+		run.Scenario = data.Scenario.Name
+		run.Service = data.Scenario.ServiceName()
+		run.Tags = data.Scenario.Tags
+	}
+	if data.SLA != nil {
+		run.SLA = data.SLA.ID
+	}
+	run.Passed = run.RTOPassed && (run.RPOTarget == "" || run.RPOPassed)
+	return run, nil
+}
+
+// Result returns whether the run passed, failed, was incomplete, or was simulated
+func (r Run) Result() string {
+	switch {
+	case r.Simulated:
+		return ResultSimulated
+	case r.Incomplete:
+		return ResultIncomplete
+	case r.Passed:
+		return ResultPass
+	}
+	return ResultFail
+}
+
+// Filter selects runs from the history
+type Filter struct {
+	Scenario string
+	Service  string    // Runs that drilled the service, if set
+	Tags     []string  // Runs must have every tag
+	SLA      string    // Runs that validated the SLA, if set
+	From     time.Time // Runs started at or after From, if set
+	To       time.Time // Runs started before To, if set
+	Result   string    // One of Results, if set
+	Limit    int       // Most recent runs to return; 0 returns every run
+}
+
+// DefaultDataDir returns $DRILLMEASURE_DATA_DIR, or drillmeasure under
+// $XDG_DATA_HOME (default: ~/.local/share)
+func DefaultDataDir() string {
+	if dir := os.Getenv(DataDirEnv); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "drillmeasure")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".local", "share", "drillmeasure")
+	}
+	return ".drillmeasure"
+}
+
+// Store is the run history database
+type Store struct {
+	backend Backend
+}
+
+// Open opens the run history at location: a Postgres connection URL
+// (postgres://...), or a data directory the SQLite database is created in
+// if needed. Either database is accessed with its command-line shell,
+// psql or sqlite3, which must be installed.
+func Open(location string) (*Store, error) {
+	var backend Backend
+	var err error
+	if isPostgresURL(location) {
+		backend, err = newPostgresBackend(location)
+	} else {
+		backend, err = newSQLiteBackend(location)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return OpenBackend(backend)
+}
+
+// OpenBackend creates the history's tables in the backend if needed
+func OpenBackend(backend Backend) (*Store, error) {
+	s := &Store{backend: backend}
+	if err := backend.Exec(schema); err != nil {
+		return nil, err
+	}
+	if err := s.migrate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// migrate adds the columns a database created by an older version lacks
+func (s *Store) migrate() error {
+	columns, err := s.backend.Columns("runs")
+	if err != nil {
+		return err
+	}
+	existing := map[string]bool{}
+	for _, c := range columns {
+		existing[c] = true
+	}
+
+	var stmts strings.Builder
+	for _, c := range addedColumns {
+		if !existing[c.name] {
+			stmts.WriteString(fmt.Sprintf("ALTER TABLE runs ADD COLUMN %s %s;\n", c.name, c.definition))
+		}
+	}
+	if stmts.Len() == 0 {
+		return nil
+	}
+	return s.backend.Exec(stmts.String())
+}
+
+// Path returns the database file, or the URL of a Postgres history without
+// its password
+func (s *Store) Path() string {
+	return s.backend.Location()
+}
+
+// Record adds the run to the history, replacing an earlier record of the
+// same run directory (e.g. before it was resumed)
+func (s *Store) Record(run Run) error {
+	if run.RecordedAt == "" {
+		run.RecordedAt = time.Now().Format(time.RFC3339)
+	}
+	tags := ""
+	if len(run.Tags) > 0 {
+		tags = "," + strings.Join(run.Tags, ",") + ","
+	}
+	columns := []string{"report_path", "scenario", "service", "tags", "sla", "start_time", "end_time",
+		"rto_target", "rta", "rto_passed", "rpo_target", "rpo_actual", "rpo_passed", "passed", "incomplete", "simulated", "errors",
+		"recorded_at"}
+	values := []string{quote(run.ReportPath), quote(run.Scenario), quote(run.Service), quote(tags), quote(run.SLA),
+		quote(run.StartTime), quote(run.EndTime), quote(run.RTOTarget), quote(run.RTA), boolInt(run.RTOPassed),
+		quote(run.RPOTarget), quote(run.RPOActual), boolInt(run.RPOPassed), boolInt(run.Passed), boolInt(run.Incomplete),
+		boolInt(run.Simulated), strconv.Itoa(run.Errors), quote(run.RecordedAt)}
+	return s.backend.Exec(s.backend.Upsert("runs", []string{"report_path"}, columns, values))
+}
+
+// Runs returns the runs matching the filter, most recent first
+func (s *Store) Runs(filter Filter) ([]Run, error) {
+	var where []string
+	if filter.Scenario != "" {
+		where = append(where, "scenario = "+quote(filter.Scenario))
+	}
+	if filter.Service != "" {
+		where = append(where, "service = "+quote(filter.Service))
+	}
+	if filter.SLA != "" {
+		where = append(where, "sla = "+quote(filter.SLA))
+	}
+	for _, tag := range filter.Tags {
+		where = append(where, s.backend.Contains("tags", quote(","+tag+",")))
+	}
+	if !filter.From.IsZero() {
+		where = append(where, s.backend.Time("start_time")+" >= "+s.backend.Time(quote(filter.From.UTC().Format(time.RFC3339))))
+	}
+	if !filter.To.IsZero() {
+		where = append(where, s.backend.Time("start_time")+" < "+s.backend.Time(quote(filter.To.UTC().Format(time.RFC3339))))
+	}
+	switch filter.Result {
+	case "":
+	case ResultSimulated:
+		where = append(where, "simulated = 1")
+	case ResultIncomplete:
+		where = append(where, "simulated = 0 AND incomplete = 1")
+	case ResultPass:
+		where = append(where, "simulated = 0 AND incomplete = 0 AND passed = 1")
+	case ResultFail:
+		where = append(where, "simulated = 0 AND incomplete = 0 AND passed = 0")
+	default:
+		return nil, fmt.Errorf("unknown result %q (expected %s)", filter.Result, strings.Join(Results, ", "))
+	}
+
+	query := "SELECT * FROM runs"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY " + s.backend.Time("start_time") + " DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT " + strconv.Itoa(filter.Limit)
+	}
+
+	return s.query(query)
+}
+
+// SetBaseline makes the recorded run the baseline of its scenario, which
+// later runs of it are compared against
+func (s *Store) SetBaseline(run Run) error {
+	return s.backend.Exec(s.backend.Upsert("baselines", []string{"scenario"}, []string{"scenario", "report_path", "set_at"},
+		[]string{quote(run.Scenario), quote(run.ReportPath), quote(time.Now().Format(time.RFC3339))}))
+}
+
+// Baseline returns the baseline run of a scenario, or nil if it has none
+func (s *Store) Baseline(scenario string) (*Run, error) {
+	runs, err := s.query("SELECT runs.* FROM baselines JOIN runs USING (report_path) WHERE baselines.scenario = " + quote(scenario))
+	if err != nil || len(runs) == 0 {
+		return nil, err
+	}
+	return &runs[0], nil
+}
+
+// Baselines returns the baseline run of every scenario that has one, by scenario
+func (s *Store) Baselines() ([]Run, error) {
+	return s.query("SELECT runs.* FROM baselines JOIN runs USING (report_path) ORDER BY baselines.scenario")
+}
+
+// ClearBaseline removes a scenario's baseline, reporting whether it had one
+func (s *Store) ClearBaseline(scenario string) (bool, error) {
+	rows, err := s.backend.Query("SELECT scenario FROM baselines WHERE scenario = " + quote(scenario))
+	if err != nil || string(bytes.TrimSpace(rows)) == "[]" {
+		return false, err
+	}
+	return true, s.backend.Exec("DELETE FROM baselines WHERE scenario = " + quote(scenario) + ";\n")
+}
+
+// DeleteRuns removes the runs of the run directories from the history, with
+// any baselines that are among them
+func (s *Store) DeleteRuns(reportPaths []string) error {
+	if len(reportPaths) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(reportPaths))
+	for i, p := range reportPaths {
+		quoted[i] = quote(p)
+	}
+	in := "(" + strings.Join(quoted, ", ") + ")"
+	return s.backend.Exec("DELETE FROM baselines WHERE report_path IN " + in + ";\nDELETE FROM runs WHERE report_path IN " + in + ";\n")
+}
+
+// query returns the runs a SELECT of runs columns returns
+func (s *Store) query(query string) ([]Run, error) {
+	out, err := s.backend.Query(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		Run
+		Tags       string `json:"tags"`
+		RTOPassed  int    `json:"rto_passed"`
+		RPOPassed  int    `json:"rpo_passed"`
+		Passed     int    `json:"passed"`
+		Incomplete int    `json:"incomplete"`
+		Simulated  int    `json:"simulated"`
+	}
+	if err := json.Unmarshal(out, &rows); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.Path(), err)
+	}
+	runs := make([]Run, len(rows))
+	for i, row := range rows {
+		runs[i] = row.Run
+		if tags := strings.Trim(row.Tags, ","); tags != "" {
+			runs[i].Tags = strings.Split(tags, ",")
+		}
+		runs[i].RTOPassed = row.RTOPassed != 0
+		runs[i].RPOPassed = row.RPOPassed != 0
+		runs[i].Passed = row.Passed != 0
+		runs[i].Incomplete = row.Incomplete != 0
+		runs[i].Simulated = row.Simulated != 0
+	}
+	return runs, nil
+}
+
+// quote renders s as an SQL string literal
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func boolInt(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,161 @@
+package history
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Incident is a real outage of a service, counted against its downtime
+// budget alongside drills
+type Incident struct {
+	Service     string `json:"service" yaml:"service"`
+	StartTime   string `json:"start_time" yaml:"start"`            // RFC 3339
+	EndTime     string `json:"-" yaml:"end,omitempty"`             // Import only: sets Downtime when it is not given
+	Downtime    string `json:"downtime" yaml:"downtime,omitempty"` // Duration the service was down
+	Description string `json:"description" yaml:"description,omitempty"`
+	RecordedAt  string `json:"recorded_at" yaml:"-"`
+}
+
+// GetDowntime returns how long the service was down
+func (i Incident) GetDowntime() time.Duration {
+	d, _ := time.ParseDuration(i.Downtime) // Validated when the incident was parsed
+	return d
+}
+
+// ParseIncidents reads incidents to import from a CSV file (with 'service'
+// and 'start' columns, either 'downtime' or 'end', and an optional
+// 'description') or a YAML or JSON list of incidents
+func ParseIncidents(path string) ([]Incident, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read incidents: %w", err)
+	}
+
+	var incidents []Incident
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		incidents, err = parseIncidentsCSV(string(raw))
+	} else {
+		err = yaml.Unmarshal(raw, &incidents)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse incidents %s: %w", path, err)
+	}
+	if len(incidents) == 0 {
+		return nil, fmt.Errorf("%s lists no incidents", path)
+	}
+
+	for i := range incidents {
+		if err := incidents[i].normalize(); err != nil {
+			return nil, fmt.Errorf("invalid incident %d in %s: %w", i+1, path, err)
+		}
+	}
+	return incidents, nil
+}
+
+func parseIncidentsCSV(content string) ([]Incident, error) {
+	records, err := csv.NewReader(strings.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty CSV")
+	}
+
+	columns := map[string]int{}
+	for i, h := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, required := range []string{"service", "start"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV has no '%s' column", required)
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var incidents []Incident
+	for _, record := range records[1:] {
+		incidents = append(incidents, Incident{
+			Service:     field(record, "service"),
+			StartTime:   field(record, "start"),
+			EndTime:     field(record, "end"),
+			Downtime:    field(record, "downtime"),
+			Description: field(record, "description"),
+		})
+	}
+	return incidents, nil
+}
+
+// normalize checks the incident and stores its start in UTC and its end as
+// a downtime
+func (i *Incident) normalize() error {
+	if i.Service == "" {
+		return fmt.Errorf("'service' is required")
+	}
+	start, err := time.Parse(time.RFC3339, i.StartTime)
+	if err != nil {
+		return fmt.Errorf("invalid 'start' %q: expected RFC 3339, e.g. 2024-06-01T10:00:00Z", i.StartTime)
+	}
+	i.StartTime = start.UTC().Format(time.RFC3339)
+
+	switch {
+	case i.Downtime != "":
+		d, err := time.ParseDuration(i.Downtime)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid 'downtime' %q", i.Downtime)
+		}
+	case i.EndTime != "":
+		end, err := time.Parse(time.RFC3339, i.EndTime)
+		if err != nil {
+			return fmt.Errorf("invalid 'end' %q: expected RFC 3339, e.g. 2024-06-01T10:45:00Z", i.EndTime)
+		}
+		if !end.After(start) {
+			return fmt.Errorf("'end' must be after 'start'")
+		}
+		i.Downtime = end.Sub(start).String()
+	default:
+		return fmt.Errorf("one of 'downtime' and 'end' is required")
+	}
+	return nil
+}
+
+// RecordIncident adds the incident to the history, replacing an earlier
+// import of the same service's incident at the same start
+func (s *Store) RecordIncident(incident Incident) error {
+	if incident.RecordedAt == "" {
+		incident.RecordedAt = time.Now().Format(time.RFC3339)
+	}
+	return s.backend.Exec(s.backend.Upsert("incidents", []string{"service", "start_time"},
+		[]string{"service", "start_time", "downtime", "description", "recorded_at"},
+		[]string{quote(incident.Service), quote(incident.StartTime), quote(incident.Downtime), quote(incident.Description),
+			quote(incident.RecordedAt)}))
+}
+
+// Incidents returns the service's incidents that started in [from, to), most
+// recent first
+func (s *Store) Incidents(service string, from, to time.Time) ([]Incident, error) {
+	start := s.backend.Time("start_time")
+	out, err := s.backend.Query(fmt.Sprintf("SELECT * FROM incidents WHERE service = %s AND %s >= %s AND %s < %s ORDER BY %s DESC",
+		quote(service), start, s.backend.Time(quote(from.UTC().Format(time.RFC3339))),
+		start, s.backend.Time(quote(to.UTC().Format(time.RFC3339))), start))
+	if err != nil {
+		return nil, err
+	}
+
+	var incidents []Incident
+	if err := json.Unmarshal(out, &incidents); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.Path(), err)
+	}
+	return incidents, nil
+}
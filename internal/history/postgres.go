@@ -0,0 +1,126 @@
+package history
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// psqlBinary is the Postgres shell the history is read and written with
+const psqlBinary = "psql"
+
+// postgresBackend keeps the history in a Postgres database, accessed with
+// the psql command-line shell, so one history can be shared by every
+// operator and CI job that can reach it
+type postgresBackend struct {
+	url      string
+	password string // Taken out of the URL so it never appears in psql's arguments
+}
+
+// newPostgresBackend checks psql is installed. A password in the connection
+// URL is passed to psql in PGPASSWORD rather than on its command line, where
+// any local user could read it; without one, PGPASSWORD or ~/.pgpass apply.
+func newPostgresBackend(connURL string) (*postgresBackend, error) {
+	if _, err := exec.LookPath(psqlBinary); err != nil {
+		return nil, fmt.Errorf("%s is not installed", psqlBinary)
+	}
+	u, err := url.Parse(connURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Postgres URL: %w", err)
+	}
+	b := &postgresBackend{}
+	if password, ok := u.User.Password(); ok {
+		b.password = password
+		u.User = url.User(u.User.Username())
+	}
+	if query := u.Query(); query.Has("password") {
+		b.password = query.Get("password")
+		query.Del("password")
+		u.RawQuery = query.Encode()
+	}
+	b.url = u.String()
+	return b, nil
+}
+
+func (b *postgresBackend) Location() string {
+	u, err := url.Parse(b.url)
+	if err != nil {
+		return "postgres"
+	}
+	return u.Redacted()
+}
+
+func (b *postgresBackend) Exec(sql string) error {
+	_, err := b.run(sql)
+	return err
+}
+
+// Query has Postgres render each row as JSON, one per line, and joins them
+// into an array
+func (b *postgresBackend) Query(query string) ([]byte, error) {
+	out, err := b.run("SELECT row_to_json(q) FROM (" + query + ") q;\n")
+	if err != nil {
+		return nil, err
+	}
+	var rows []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			rows = append(rows, line)
+		}
+	}
+	return []byte("[" + strings.Join(rows, ",") + "]"), nil
+}
+
+func (b *postgresBackend) Columns(table string) ([]string, error) {
+	out, err := b.run("SELECT column_name FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = " + quote(table) + ";\n")
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, nil
+}
+
+func (b *postgresBackend) Upsert(table string, key, columns, values []string) string {
+	var updates []string
+	for _, c := range columns {
+		updates = append(updates, c+" = EXCLUDED."+c)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO UPDATE SET %s;\n",
+		table, strings.Join(columns, ", "), strings.Join(values, ", "), strings.Join(key, ", "), strings.Join(updates, ", "))
+}
+
+func (b *postgresBackend) Time(expr string) string {
+	return "CAST(NULLIF(" + expr + ", '') AS timestamptz)"
+}
+
+func (b *postgresBackend) Contains(column, substr string) string {
+	return "strpos(" + column + ", " + substr + ") > 0"
+}
+
+// run runs SQL against the database, stopping at the first error, and
+// returns its unaligned, headerless output
+func (b *postgresBackend) run(sql string) ([]byte, error) {
+	cmd := exec.Command(psqlBinary, "-X", "-q", "-A", "-t", "-v", "ON_ERROR_STOP=1", "-d", b.url)
+	if b.password != "" {
+		cmd.Env = append(os.Environ(), "PGPASSWORD="+b.password)
+	}
+	cmd.Stdin = strings.NewReader("SET client_min_messages = warning;\n" + sql)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %s", b.Location(), msg)
+		}
+		return nil, fmt.Errorf("%s: %w", b.Location(), err)
+	}
+	return out, nil
+}
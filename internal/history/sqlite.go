@@ -0,0 +1,102 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DBFileName is the SQLite run history kept in the data directory
+const DBFileName = "history.db"
+
+// sqliteBinary is the SQLite shell the history is read and written with
+const sqliteBinary = "sqlite3"
+
+// sqliteBackend keeps the history in a SQLite database file, accessed with
+// the sqlite3 command-line shell
+type sqliteBackend struct {
+	path string
+}
+
+// newSQLiteBackend creates the data directory if needed
+func newSQLiteBackend(dataDir string) (*sqliteBackend, error) {
+	if _, err := exec.LookPath(sqliteBinary); err != nil {
+		return nil, fmt.Errorf("%s is not installed", sqliteBinary)
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+	return &sqliteBackend{path: filepath.Join(dataDir, DBFileName)}, nil
+}
+
+func (b *sqliteBackend) Location() string {
+	return b.path
+}
+
+func (b *sqliteBackend) Exec(sql string) error {
+	_, err := b.run(sql)
+	return err
+}
+
+func (b *sqliteBackend) Query(query string) ([]byte, error) {
+	out, err := b.run(".mode json\n" + query + ";\n")
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return []byte("[]"), nil
+	}
+	return out, nil
+}
+
+func (b *sqliteBackend) Columns(table string) ([]string, error) {
+	out, err := b.Query("PRAGMA table_info(" + table + ")")
+	if err != nil {
+		return nil, err
+	}
+	var columns []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(out, &columns); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", b.path, err)
+	}
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.Name
+	}
+	return names, nil
+}
+
+func (b *sqliteBackend) Upsert(table string, key, columns, values []string) string {
+	return fmt.Sprintf("INSERT OR REPLACE INTO %s (%s) VALUES (%s);\n",
+		table, strings.Join(columns, ", "), strings.Join(values, ", "))
+}
+
+func (b *sqliteBackend) Time(expr string) string {
+	return "julianday(" + expr + ")"
+}
+
+func (b *sqliteBackend) Contains(column, substr string) string {
+	return "instr(" + column + ", " + substr + ") > 0"
+}
+
+// run runs SQL against the database and returns its output. Concurrent
+// drills wait for each other's writes rather than failing.
+func (b *sqliteBackend) run(sql string) ([]byte, error) {
+	cmd := exec.Command(sqliteBinary, "-batch", "-bail", b.path)
+	cmd.Stdin = strings.NewReader(".timeout 10000\n" + sql)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %s", b.path, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", b.path, err)
+	}
+	return out, nil
+}
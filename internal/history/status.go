@@ -0,0 +1,96 @@
+package history
+
+import (
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// Drill statuses
+const (
+	StatusOK           = "ok"
+	StatusOverdue      = "overdue"
+	StatusNeverDrilled = "never drilled"
+	StatusNoFrequency  = "-" // The scenario sets no frequency, so it can't be overdue
+)
+
+// ScenarioStatus is when a scenario was last drilled and whether it is overdue
+type ScenarioStatus struct {
+	Scenario  *config.Scenario
+	LastDrill *Run      // Most recent completed, non-simulated run; nil if never drilled
+	Due       time.Time // When the next drill is due; zero without a frequency or a drill
+	Status    string
+}
+
+// Status returns when each scenario was last drilled, from the history, and
+// whether it is overdue for its frequency at now. Simulated and incomplete
+// runs don't count as drills; failed ones do.
+func (s *Store) Status(scenarios []*config.Scenario, now time.Time) ([]ScenarioStatus, error) {
+	statuses := make([]ScenarioStatus, 0, len(scenarios))
+	for _, scenario := range scenarios {
+		runs, err := s.Runs(Filter{Scenario: scenario.Name})
+		if err != nil {
+			return nil, err
+		}
+		st := ScenarioStatus{Scenario: scenario, Status: StatusNoFrequency}
+		var last time.Time
+		st.LastDrill, last = lastDrill(runs)
+
+		// A frequency that is not valid is reported by validate, not here
+		if due, ok := scenario.NextDrillDue(last); ok {
+			switch {
+			case st.LastDrill == nil:
+				st.Status = StatusNeverDrilled
+			case now.After(due):
+				st.Due, st.Status = due, StatusOverdue
+			default:
+				st.Due, st.Status = due, StatusOK
+			}
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, nil
+}
+
+// SLAStatus is when an SLA was last validated by a drill
+type SLAStatus struct {
+	SLA       *config.SLA
+	Scenarios []string // Scenarios that validate the SLA
+	LastDrill *Run     // Most recent completed, non-simulated run that validated it; nil if never drilled
+}
+
+// SLAStatus returns when each SLA in the registry was last drilled, from the
+// history, and which of the scenarios reference it
+func (s *Store) SLAStatus(registry *config.SLARegistry, scenarios []*config.Scenario) ([]SLAStatus, error) {
+	statuses := make([]SLAStatus, 0, len(registry.SLAs))
+	for i := range registry.SLAs {
+		sla := &registry.SLAs[i]
+		runs, err := s.Runs(Filter{SLA: sla.ID})
+		if err != nil {
+			return nil, err
+		}
+		st := SLAStatus{SLA: sla}
+		st.LastDrill, _ = lastDrill(runs)
+		for _, scenario := range scenarios {
+			if scenario.SLA == sla.ID {
+				st.Scenarios = append(st.Scenarios, scenario.Name)
+			}
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, nil
+}
+
+// lastDrill returns the most recent of runs, most recent first, that counts
+// as a drill, and when it started
+func lastDrill(runs []Run) (*Run, time.Time) {
+	for i := range runs {
+		if runs[i].Simulated || runs[i].Incomplete {
+			continue
+		}
+		if start, err := time.Parse(time.RFC3339, runs[i].StartTime); err == nil {
+			return &runs[i], start
+		}
+	}
+	return nil, time.Time{}
+}
@@ -0,0 +1,249 @@
+package inventory
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the imported inventory kept at the top of the reports directory
+const FileName = "inventory.json"
+
+// Service is a service that is expected to have DR drills
+type Service struct {
+	Name  string `json:"name" yaml:"name"`
+	Owner string `json:"owner,omitempty" yaml:"owner,omitempty"`
+	Tier  string `json:"tier,omitempty" yaml:"tier,omitempty"`
+}
+
+// Inventory is the list of services drill coverage is measured against
+type Inventory struct {
+	Source   string    `json:"source"`
+	Services []Service `json:"services"`
+}
+
+// Parse reads a service inventory from a CSV file (with a 'name' column and
+// optional 'owner' and 'tier' columns), a YAML or JSON list of services, or
+// a Backstage catalog export
+func Parse(path string) ([]Service, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inventory: %w", err)
+	}
+
+	var services []Service
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		services, err = parseCSV(string(raw))
+	} else {
+		services, err = parseDocument(raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse inventory %s: %w", path, err)
+	}
+
+	seen := map[string]bool{}
+	var unique []Service
+	for _, s := range services {
+		s.Name = strings.TrimSpace(s.Name)
+		if s.Name == "" || seen[s.Name] {
+			continue
+		}
+		seen[s.Name] = true
+		unique = append(unique, s)
+	}
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("inventory %s lists no services", path)
+	}
+	sort.Slice(unique, func(i, j int) bool { return unique[i].Name < unique[j].Name })
+	return unique, nil
+}
+
+func parseCSV(content string) ([]Service, error) {
+	records, err := csv.NewReader(strings.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty CSV")
+	}
+
+	columns := map[string]int{}
+	for i, h := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	nameCol, ok := columns["name"]
+	if !ok {
+		if nameCol, ok = columns["service"]; !ok {
+			return nil, fmt.Errorf("CSV header must have a 'name' or 'service' column")
+		}
+	}
+	field := func(record []string, column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var services []Service
+	for _, record := range records[1:] {
+		if nameCol >= len(record) {
+			continue
+		}
+		services = append(services, Service{
+			Name:  record[nameCol],
+			Owner: field(record, "owner"),
+			Tier:  field(record, "tier"),
+		})
+	}
+	return services, nil
+}
+
+// backstageEntity is the subset of a Backstage catalog entity that is used
+type backstageEntity struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name string `yaml:"name"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Owner     string `yaml:"owner"`
+		Lifecycle string `yaml:"lifecycle"`
+		Tier      string `yaml:"tier"`
+	} `yaml:"spec"`
+}
+
+// parseDocument reads YAML or JSON: a list of services, {services: [...]},
+// or Backstage entities as a list, {items: [...]}, or a multi-document stream
+func parseDocument(raw []byte) ([]Service, error) {
+	var services []Service
+	decoder := yaml.NewDecoder(strings.NewReader(string(raw)))
+	for {
+		var node yaml.Node
+		if err := decoder.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		found, err := parseNode(&node)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, found...)
+	}
+	return services, nil
+}
+
+func parseNode(node *yaml.Node) ([]Service, error) {
+	if node.Kind == yaml.DocumentNode && len(node.Content) == 1 {
+		node = node.Content[0]
+	}
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var services []Service
+		for _, item := range node.Content {
+			found, err := parseNode(item)
+			if err != nil {
+				return nil, err
+			}
+			services = append(services, found...)
+		}
+		return services, nil
+	case yaml.MappingNode:
+		var wrapper struct {
+			Services []yaml.Node `yaml:"services"`
+			Items    []yaml.Node `yaml:"items"`
+			Kind     string      `yaml:"kind"`
+		}
+		if err := node.Decode(&wrapper); err != nil {
+			return nil, err
+		}
+		if wrapper.Services != nil || wrapper.Items != nil {
+			var services []Service
+			for _, item := range append(wrapper.Services, wrapper.Items...) {
+				found, err := parseNode(&item)
+				if err != nil {
+					return nil, err
+				}
+				services = append(services, found...)
+			}
+			return services, nil
+		}
+		if wrapper.Kind != "" {
+			return parseBackstageEntity(node)
+		}
+		var s Service
+		if err := node.Decode(&s); err != nil {
+			return nil, err
+		}
+		return []Service{s}, nil
+	case yaml.ScalarNode:
+		return []Service{{Name: node.Value}}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// parseBackstageEntity maps a Backstage Component or System to a service;
+// other kinds (Group, User, API, ...) are skipped
+func parseBackstageEntity(node *yaml.Node) ([]Service, error) {
+	var e backstageEntity
+	if err := node.Decode(&e); err != nil {
+		return nil, err
+	}
+	if e.Kind != "Component" && e.Kind != "System" {
+		return nil, nil
+	}
+	tier := e.Spec.Tier
+	if tier == "" {
+		tier = e.Spec.Lifecycle
+	}
+	return []Service{{Name: e.Metadata.Name, Owner: e.Spec.Owner, Tier: tier}}, nil
+}
+
+// Load reads the imported inventory from the reports directory
+func Load(reportsDir string) (*Inventory, error) {
+	raw, err := os.ReadFile(filepath.Join(reportsDir, FileName))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no inventory in %s (run 'drillmeasure inventory import <file>')", reportsDir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inventory: %w", err)
+	}
+
+	var inv Inventory
+	if err := json.Unmarshal(raw, &inv); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(reportsDir, FileName), err)
+	}
+	return &inv, nil
+}
+
+// Save writes the inventory to the reports directory, replacing any previous import
+func Save(reportsDir string, inv *Inventory) error {
+	if err := os.MkdirAll(reportsDir, 0755); err != nil {
+		return err
+	}
+
+	raw, err := json.MarshalIndent(inv, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(reportsDir, FileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write inventory: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write inventory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AnnotationsFileName holds the review notes added to a run after it finished
+const AnnotationsFileName = "annotations.json"
+
+// Annotation kinds
+const (
+	AnnotationNote    = "note"
+	AnnotationTicket  = "ticket"   // Remediation ticket raised from the review
+	AnnotationSignOff = "sign_off" // The reviewer accepted the run as evidence
+	AnnotationKeep    = "keep"     // The run is retained however old it gets
+)
+
+// annotationsHeading starts the annotations section, which is always last in report.md
+const annotationsHeading = "## Annotations\n"
+
+// Annotation is a reviewer's note on a stored run
+type Annotation struct {
+	Time   string `json:"time"`
+	Author string `json:"author,omitempty"`
+	Kind   string `json:"kind"`
+	Text   string `json:"text,omitempty"`
+	Ticket string `json:"ticket,omitempty"` // Ticket ID or URL
+}
+
+// ReadAnnotations loads a run's annotations, if it has any
+func ReadAnnotations(runDir string) ([]Annotation, error) {
+	raw, err := os.ReadFile(filepath.Join(runDir, AnnotationsFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var annotations []Annotation
+	if err := json.Unmarshal(raw, &annotations); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", AnnotationsFileName, err)
+	}
+	return annotations, nil
+}
+
+// AddAnnotation appends an annotation to the run, then brings report.json,
+// report.md, the attestation, and the manifest up to date with all of the
+// run's annotations
+func AddAnnotation(runDir string, annotation Annotation) error {
+	data, err := ReadReport(runDir)
+	if err != nil {
+		return err
+	}
+	annotations, err := ReadAnnotations(runDir)
+	if err != nil {
+		return err
+	}
+	annotations = append(annotations, annotation)
+
+	raw, err := json.MarshalIndent(annotations, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(runDir, AnnotationsFileName), raw, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", AnnotationsFileName, err)
+	}
+
+	data.Annotations = annotations
+	raw, err = json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(runDir, "report.json"), raw, 0644); err != nil {
+		return fmt.Errorf("failed to write report.json: %w", err)
+	}
+
+	mdPath := filepath.Join(runDir, "report.md")
+	md, err := os.ReadFile(mdPath)
+	if err != nil {
+		return fmt.Errorf("failed to read report.md: %w", err)
+	}
+	content := AnnotateMarkdown(string(md), annotations)
+	if err := os.WriteFile(mdPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write report.md: %w", err)
+	}
+
+	if err := RefreshAttestation(runDir); err != nil {
+		return err
+	}
+	return RefreshManifest(runDir)
+}
+
+// AnnotateMarkdown replaces the annotations section of a Markdown report
+func AnnotateMarkdown(md string, annotations []Annotation) string {
+	if i := strings.Index(md, "\n"+annotationsHeading); i >= 0 {
+		md = md[:i+1]
+	}
+	return md + FormatAnnotations(annotations)
+}
+
+// FormatAnnotations renders the Markdown annotations section
+func FormatAnnotations(annotations []Annotation) string {
+	if len(annotations) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(annotationsHeading + "\n")
+	b.WriteString("_Added after the drill by its reviewers._\n\n")
+	for _, a := range annotations {
+		var label string
+		switch a.Kind {
+		case AnnotationSignOff:
+			label = "✅ **Sign-off**"
+		case AnnotationTicket:
+			label = "🎫 **Remediation ticket**"
+		case AnnotationKeep:
+			label = "📌 **Kept**"
+		default:
+			label = "📝 **Note**"
+		}
+		by := ""
+		if a.Author != "" {
+			by = " by " + a.Author
+		}
+		b.WriteString(fmt.Sprintf("- %s%s, %s", label, by, a.Time))
+		if a.Ticket != "" {
+			b.WriteString(fmt.Sprintf(": %s", a.Ticket))
+		}
+		b.WriteString("\n")
+		if a.Text != "" {
+			// Indent so multi-line notes stay within their list item
+			b.WriteString("\n  " + strings.ReplaceAll(strings.TrimRight(a.Text, "\n"), "\n", "\n  ") + "\n\n")
+		}
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// RefreshManifest rehashes the run directory after a change, keeping the
+// inputs the manifest already records. Runs from before manifests existed
+// are left without one.
+func RefreshManifest(runDir string) error {
+	raw, err := os.ReadFile(filepath.Join(runDir, ManifestFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", ManifestFileName, err)
+	}
+	return WriteManifest(runDir, manifest.Inputs)
+}
+
+// Flagged reports whether a reviewer has flagged the run to be retained: it
+// was signed off, raised a remediation ticket, or was marked to keep
+func Flagged(runDir string) (bool, error) {
+	annotations, err := ReadAnnotations(runDir)
+	if err != nil {
+		return false, err
+	}
+	for _, a := range annotations {
+		switch a.Kind {
+		case AnnotationSignOff, AnnotationTicket, AnnotationKeep:
+			return true, nil
+		}
+	}
+	return false, nil
+}
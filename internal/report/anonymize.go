@@ -0,0 +1,383 @@
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// Patterns for infrastructure details in free text such as errors and reasons
+var (
+	anonIPv4 = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	anonIPv6 = regexp.MustCompile(`\b(?:[0-9A-Fa-f]{1,4}:){3,7}[0-9A-Fa-f]{1,4}\b|\b(?:[0-9A-Fa-f]{1,4}:)+:(?:[0-9A-Fa-f]{1,4}\b)?`)
+	// Azure subscription and tenant IDs
+	anonUUID = regexp.MustCompile(`\b[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}\b`)
+	// AWS account IDs, also inside ARNs
+	anonAccount = regexp.MustCompile(`\b\d{12}\b`)
+	anonHost    = regexp.MustCompile(`\b(?:[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?\.)+[A-Za-z]{2,63}\b`)
+)
+
+// anonFileExtensions are dotted names that are files rather than hosts
+var anonFileExtensions = map[string]bool{
+	"csv": true, "gz": true, "json": true, "jsonl": true, "log": true, "md": true, "prom": true,
+	"sh": true, "sql": true, "svg": true, "txt": true, "xml": true, "yaml": true, "yml": true,
+}
+
+// anonymizer replaces infrastructure details with stable placeholders, so
+// the same host is host-1 wherever it appears in a report
+type anonymizer struct {
+	placeholders map[string]string
+	counts       map[string]int
+	commands     []string // Command bodies, removed wherever they are quoted
+}
+
+// AnonymizeReport strips hostnames, IP addresses, account IDs, and command
+// bodies and output from a report so it can be shared outside the
+// organization. Timings, hashes, and pass/fail results are kept, and the
+// report keeps its schema.
+func AnonymizeReport(data *ReportData) {
+	a := &anonymizer{placeholders: map[string]string{}, counts: map[string]int{}}
+	commands := reportCommands(data)
+	for _, cmd := range commands {
+		if cmd.Result.Command != "" {
+			a.commands = append(a.commands, cmd.Result.Command)
+		}
+	}
+	sort.Slice(a.commands, func(i, j int) bool { return len(a.commands[i]) > len(a.commands[j]) })
+	for _, cmd := range commands {
+		a.commandResult(cmd.Result)
+	}
+
+	if data.Scenario != nil {
+		s := data.Scenario
+		data.Scenario = &config.Scenario{
+			Name:                  a.text(s.Name),
+			Service:               a.text(s.Service),
+			RTOTarget:             s.RTOTarget,
+			RPOTarget:             s.RPOTarget,
+			ExpectedDowntimeGrace: s.ExpectedDowntimeGrace,
+			MaxFailedProbes:       s.MaxFailedProbes,
+			AbortAfter:            s.AbortAfter,
+			HealthyAfter:          s.HealthyAfter,
+			Assertions:            s.Assertions,
+		}
+	}
+	data.Variables = nil
+	data.IdempotencyKey = ""
+	data.Anonymized = true
+
+	for i := range data.Guards {
+		a.guard(&data.Guards[i])
+	}
+	for i := range data.RPOChecks {
+		c := &data.RPOChecks[i]
+		c.Name = a.text(c.Name)
+		c.Reason = a.text(c.Reason)
+		a.snapshotDiff(c.SnapshotDiff)
+	}
+	if data.RestoreCheck != nil {
+		data.RestoreCheck.Target = a.text(data.RestoreCheck.Target)
+		data.RestoreCheck.Reason = a.text(data.RestoreCheck.Reason)
+		for i := range data.RestoreCheck.IntegrityChecks {
+			a.guard(&data.RestoreCheck.IntegrityChecks[i])
+		}
+	}
+	for i := range data.ConsistencyChecks {
+		c := &data.ConsistencyChecks[i]
+		c.Name = a.text(c.Name)
+		c.Reason = a.text(c.Reason)
+		c.Expected = ""
+		c.Value = ""
+	}
+	for i := range data.Metrics {
+		m := &data.Metrics[i]
+		m.Name = a.text(m.Name)
+		for _, s := range []*MetricSampleData{m.Before, m.After} {
+			if s != nil {
+				s.Error = a.text(s.Error)
+			}
+		}
+	}
+	for i := range data.Assertions {
+		data.Assertions[i].Error = a.text(data.Assertions[i].Error)
+	}
+	a.snapshotDiff(data.SnapshotDiff)
+	for i := range data.SystemSamples {
+		data.SystemSamples[i].Error = a.text(data.SystemSamples[i].Error)
+	}
+	for i := range data.Artifacts {
+		data.Artifacts[i].Pattern = a.text(data.Artifacts[i].Pattern)
+		data.Artifacts[i].Source = ""
+		data.Artifacts[i].Path = a.text(data.Artifacts[i].Path)
+	}
+	for i := range data.Errors {
+		data.Errors[i] = a.text(data.Errors[i])
+	}
+	for i := range data.Annotations {
+		data.Annotations[i].Author = ""
+		data.Annotations[i].Text = a.text(data.Annotations[i].Text)
+		data.Annotations[i].Ticket = a.text(data.Annotations[i].Ticket)
+	}
+}
+
+// commandResult removes what a command ran and printed, keeping its exit
+// code, timing, and output hashes
+func (a *anonymizer) commandResult(r *CommandResultData) {
+	r.Command = ""
+	r.Stdout = ""
+	r.Stderr = ""
+	r.StdoutFile = ""
+	r.StderrFile = ""
+	r.RunAs = ""
+	r.ExpectedStdout = nil
+	r.StdoutMismatches = nil
+	if r.Host != "" {
+		r.Host = a.placeholder("host", r.Host)
+	}
+	for i := range r.Members {
+		r.Members[i].Host = a.placeholder("host", r.Members[i].Host)
+	}
+	if r.Probe != nil {
+		r.Probe.Target = ""
+	}
+}
+
+func (a *anonymizer) guard(g *GuardData) {
+	g.Name = a.text(g.Name)
+	g.Value = a.text(g.Value)
+	g.Reason = a.text(g.Reason)
+}
+
+// snapshotDiff drops the changed records, which are the drilled data itself
+func (a *anonymizer) snapshotDiff(d *SnapshotDiffData) {
+	if d == nil {
+		return
+	}
+	d.Lines = nil
+	for i := range d.Checksums {
+		d.Checksums[i].Key = a.text(d.Checksums[i].Key)
+	}
+}
+
+// text replaces quoted commands, IP addresses, account IDs, and hostnames in s
+func (a *anonymizer) text(s string) string {
+	if s == "" {
+		return s
+	}
+	for _, c := range a.commands {
+		s = strings.ReplaceAll(s, c, "[command]")
+	}
+	s = anonUUID.ReplaceAllStringFunc(s, func(m string) string { return a.placeholder("account", m) })
+	s = anonIPv4.ReplaceAllStringFunc(s, func(m string) string { return a.placeholder("ip", m) })
+	s = anonIPv6.ReplaceAllStringFunc(s, func(m string) string { return a.placeholder("ip", m) })
+	s = anonAccount.ReplaceAllStringFunc(s, func(m string) string { return a.placeholder("account", m) })
+	s = anonHost.ReplaceAllStringFunc(s, func(m string) string {
+		if anonFileExtensions[strings.ToLower(strings.TrimPrefix(filepath.Ext(m), "."))] {
+			return m
+		}
+		return a.placeholder("host", m)
+	})
+	return s
+}
+
+// placeholder returns the stable placeholder for value, such as "host-2"
+func (a *anonymizer) placeholder(kind, value string) string {
+	key := kind + "\x00" + value
+	if p, ok := a.placeholders[key]; ok {
+		return p
+	}
+	a.counts[kind]++
+	p := fmt.Sprintf("%s-%d", kind, a.counts[kind])
+	a.placeholders[key] = p
+	return p
+}
+
+// labeledCommandData is a command result in a report with the label
+// runner.DrillResult.LabeledCommands gives it
+type labeledCommandData struct {
+	Label  string
+	Result *CommandResultData
+}
+
+// reportCommands lists every command result in the report, in the order of
+// runner.DrillResult.LabeledCommands
+func reportCommands(data *ReportData) []labeledCommandData {
+	var cmds []labeledCommandData
+	add := func(label string, result *CommandResultData) {
+		if result != nil {
+			cmds = append(cmds, labeledCommandData{Label: label, Result: result})
+		}
+	}
+
+	add("backup-freshness", data.BackupFreshness)
+	if data.RestoreCheck != nil {
+		add("restore", data.RestoreCheck.Restore)
+		for i := range data.RestoreCheck.IntegrityChecks {
+			add(fmt.Sprintf("integrity-check-%d", i+1), data.RestoreCheck.IntegrityChecks[i].Result)
+		}
+		add("restore-cleanup", data.RestoreCheck.Cleanup)
+	}
+	add("pre-snapshot", data.PreSnapshot)
+	for i := range data.Guards {
+		add(fmt.Sprintf("guard-%d", i+1), data.Guards[i].Result)
+	}
+	add("disrupt", data.Disrupt)
+	add("recover", data.Recover)
+	add("abort", data.Abort)
+	for i := range data.HealthCheckAttempts {
+		add(fmt.Sprintf("health-check-%d", i+1), &data.HealthCheckAttempts[i])
+	}
+	add("seed", data.Seed)
+	add("post-snapshot", data.PostSnapshot)
+	add("rpo-verify", data.RPOVerify)
+	add("marker-read", data.MarkerRead)
+	for i := range data.ConsistencyChecks {
+		add(fmt.Sprintf("consistency-%d-baseline", i+1), data.ConsistencyChecks[i].Baseline)
+		add(fmt.Sprintf("consistency-%d", i+1), data.ConsistencyChecks[i].Result)
+		add(fmt.Sprintf("consistency-%d-compare", i+1), data.ConsistencyChecks[i].Compare)
+	}
+	for i := range data.RPOChecks {
+		add(fmt.Sprintf("rpo-check-%d-pre-snapshot", i+1), data.RPOChecks[i].PreSnapshot)
+		add(fmt.Sprintf("rpo-check-%d-post-snapshot", i+1), data.RPOChecks[i].PostSnapshot)
+		add(fmt.Sprintf("rpo-check-%d-verify", i+1), data.RPOChecks[i].Verify)
+	}
+	for i := range data.Metrics {
+		if data.Metrics[i].Before != nil {
+			add(fmt.Sprintf("metric-%d-before", i+1), data.Metrics[i].Before.Result)
+		}
+		if data.Metrics[i].After != nil {
+			add(fmt.Sprintf("metric-%d-after", i+1), data.Metrics[i].After.Result)
+		}
+	}
+	add("switchback", data.Switchback)
+	for i := range data.FactorLogs {
+		add(fmt.Sprintf("factor-log-%d", i+1), &data.FactorLogs[i])
+	}
+	return cmds
+}
+
+// GenerateAnonymizedMarkdownReport summarizes an anonymized report for
+// sharing: the outcome against the targets, the phase timings, each check's
+// result, the mapped compliance controls, and each command's exit code,
+// duration, and output hashes
+func GenerateAnonymizedMarkdownReport(data *ReportData) string {
+	var b strings.Builder
+
+	b.WriteString("# Drill Report (Anonymized)\n\n")
+	if data.Scenario != nil {
+		b.WriteString(fmt.Sprintf("**Scenario:** %s\n\n", data.Scenario.Name))
+	}
+	b.WriteString(fmt.Sprintf("**Execution Time:** %s\n\n", data.StartTime))
+	b.WriteString("> 🔒 **Anonymized:** Hostnames, IP addresses, account IDs, command bodies, and command output were removed for external sharing. Timings, output hashes, and results are as recorded.\n\n")
+	if data.Simulated {
+		b.WriteString("> 🧪 **Simulated:** No commands were executed, so this is not evidence of a drill.\n\n")
+	}
+	if data.Status == "incomplete" {
+		b.WriteString("> ⚠️ **Incomplete:** The drill stopped before completion. Results below are partial.\n\n")
+	}
+
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Metric | Target | Actual | Status |\n")
+	b.WriteString("|--------|--------|--------|--------|\n")
+	switch {
+	case data.GuardFailed || data.BackupStale:
+		b.WriteString(fmt.Sprintf("| Recovery Time | %s | N/A (drill not run) | - |\n", data.RTOTarget))
+	case data.RTAStart == "":
+		b.WriteString(fmt.Sprintf("| Recovery Time | %s | N/A (no downtime) | %s |\n", data.RTOTarget, anonStatus(data.RTOPassed)))
+	case data.MaxFailedProbes != nil:
+		b.WriteString(fmt.Sprintf("| Recovery Time | - | %s | - |\n", data.RTA))
+		b.WriteString(fmt.Sprintf("| Failed Probes | ≤ %d | %d | %s |\n", *data.MaxFailedProbes, data.FailedProbes, anonStatus(data.RTOPassed)))
+	default:
+		b.WriteString(fmt.Sprintf("| Recovery Time | %s | %s | %s |\n", data.RTOTarget, data.RTA, anonStatus(data.RTOPassed)))
+	}
+	if data.RPOTarget != "" {
+		actual := data.RPOActual
+		if actual == "" {
+			actual = "-"
+		}
+		b.WriteString(fmt.Sprintf("| Data Loss (RPO) | %s | %s | %s |\n", data.RPOTarget, actual, anonStatus(data.RPOPassed)))
+	}
+	if data.SwitchbackTime != "" {
+		target := data.SwitchbackTarget
+		if target == "" {
+			target = "-"
+		}
+		b.WriteString(fmt.Sprintf("| Switchback | %s | %s | %s |\n", target, data.SwitchbackTime, anonStatus(data.SwitchbackPassed)))
+	}
+	b.WriteString("\n")
+
+	if len(data.Phases) > 0 {
+		b.WriteString("## Phases\n\n")
+		b.WriteString("| Phase | Start | Duration |\n")
+		b.WriteString("|-------|-------|----------|\n")
+		for _, p := range data.Phases {
+			b.WriteString(fmt.Sprintf("| %s | %s | %s |\n", p.Name, p.Start, p.Duration))
+		}
+		b.WriteString("\n")
+	}
+
+	var checks []string
+	for _, g := range data.Guards {
+		checks = append(checks, fmt.Sprintf("| Guard: %s | %s |", g.Name, anonStatus(g.Passed)))
+	}
+	if data.RestoreCheck != nil {
+		checks = append(checks, fmt.Sprintf("| Restore check (%s) | %s |", data.RestoreCheck.RestoreTime, anonStatus(data.RestoreCheck.Passed)))
+	}
+	for _, c := range data.RPOChecks {
+		checks = append(checks, fmt.Sprintf("| RPO check: %s | %s |", c.Name, anonStatus(c.Passed)))
+	}
+	for _, c := range data.ConsistencyChecks {
+		checks = append(checks, fmt.Sprintf("| Consistency: %s | %s |", c.Name, anonStatus(c.Passed)))
+	}
+	for _, a := range data.Assertions {
+		checks = append(checks, fmt.Sprintf("| Assertion: `%s` | %s |", a.Expression, anonStatus(a.Passed)))
+	}
+	if len(checks) > 0 {
+		b.WriteString("## Checks\n\n")
+		b.WriteString("| Check | Status |\n")
+		b.WriteString("|-------|--------|\n")
+		b.WriteString(strings.Join(checks, "\n"))
+		b.WriteString("\n\n")
+	}
+
+	if len(data.Controls) > 0 {
+		b.WriteString("## Compliance Controls\n\n")
+		b.WriteString(formatControls(data.Controls))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Commands\n\n")
+	b.WriteString("| Step | Timestamp | Duration | Exit Code | Stdout Hash (SHA256) | Stderr Hash (SHA256) |\n")
+	b.WriteString("|------|-----------|----------|-----------|----------------------|----------------------|\n")
+	for _, cmd := range reportCommands(data) {
+		r := cmd.Result
+		if strings.HasPrefix(cmd.Label, "health-check-") {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("| %s | %s | %s | %d | `%s` | `%s` |\n", cmd.Label, r.Timestamp, r.Duration, r.ExitCode, r.StdoutHash, r.StderrHash))
+	}
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("**Health Checks:** %d run, %d failed\n\n", len(data.HealthCheckAttempts), data.FailedProbes))
+
+	if len(data.Errors) > 0 {
+		b.WriteString("## Errors\n\n")
+		for _, e := range data.Errors {
+			b.WriteString(fmt.Sprintf("- %s\n", e))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(FormatAnnotations(data.Annotations))
+	return b.String()
+}
+
+func anonStatus(passed bool) string {
+	if passed {
+		return "✅ PASS"
+	}
+	return "❌ FAIL"
+}
@@ -0,0 +1,110 @@
+package report
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// artifactsDirName holds the files collected by the scenario's artifacts
+const artifactsDirName = "artifacts"
+
+// CollectArtifacts copies the files matching the scenario's artifacts globs
+// into the output directory and records each with its hash. Directories are
+// copied whole. Secret values are masked in the copies, as for inputs.
+// Simulated runs collect nothing, since no command wrote the files.
+func CollectArtifacts(result *runner.DrillResult, outputDir string) error {
+	if result.Simulated {
+		return nil
+	}
+
+	outputAbs, err := filepath.Abs(outputDir)
+	if err != nil {
+		return err
+	}
+	secrets := result.Scenario.SecretValues()
+	seen := map[string]bool{}
+	for _, pattern := range result.Scenario.Artifacts {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid artifacts pattern %q: %w", pattern, err)
+		}
+
+		var files []string
+		for _, match := range matches {
+			err := filepath.WalkDir(match, func(path string, d fs.DirEntry, err error) error {
+				if err != nil || d.IsDir() {
+					return err
+				}
+				if d.Type().IsRegular() {
+					files = append(files, path)
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to read artifact %s: %w", match, err)
+			}
+		}
+		if len(files) == 0 {
+			result.Artifacts = append(result.Artifacts, runner.Artifact{Pattern: pattern})
+			continue
+		}
+
+		for _, file := range files {
+			abs, err := filepath.Abs(file)
+			if err != nil {
+				return err
+			}
+			// A broad pattern can match the run's own output
+			if seen[abs] || strings.HasPrefix(abs, outputAbs+string(filepath.Separator)) {
+				continue
+			}
+			seen[abs] = true
+			artifact, err := copyArtifact(outputDir, abs, secrets)
+			if err != nil {
+				return err
+			}
+			artifact.Pattern = pattern
+			result.Artifacts = append(result.Artifacts, *artifact)
+		}
+	}
+	return nil
+}
+
+// copyArtifact copies the file at the absolute path src under the artifacts
+// directory, keeping its path so files of the same name don't collide
+func copyArtifact(outputDir, src string, secrets []string) (*runner.Artifact, error) {
+	raw, err := os.ReadFile(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact: %w", err)
+	}
+	content := string(raw)
+	for _, secret := range secrets {
+		content = strings.ReplaceAll(content, secret, "********")
+	}
+
+	rel := strings.TrimPrefix(src, filepath.VolumeName(src))
+	rel = filepath.Join(artifactsDirName, strings.TrimLeft(rel, `/\`))
+	dest := filepath.Join(outputDir, rel)
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create artifacts directory: %w", err)
+	}
+	if err := os.WriteFile(dest, []byte(content), 0644); err != nil {
+		return nil, fmt.Errorf("failed to copy artifact %s: %w", src, err)
+	}
+
+	artifact := &runner.Artifact{
+		Source: src,
+		Path:   filepath.ToSlash(rel),
+		SHA256: hashBytes([]byte(content)),
+		Size:   int64(len(content)),
+	}
+	if content != string(raw) {
+		artifact.SourceSHA256 = hashBytes(raw)
+	}
+	return artifact, nil
+}
@@ -0,0 +1,231 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// AttestationFileName holds the in-toto statement describing the drill
+const AttestationFileName = "attestation.intoto.json"
+
+// in-toto statement and drill predicate types
+const (
+	InTotoStatementType = "https://in-toto.io/Statement/v1"
+	DrillPredicateType  = "https://github.com/drillmeasure/drillmeasure/attestation/drill/v1"
+	drillTypeScenario   = "https://github.com/drillmeasure/drillmeasure/drill/scenario/v1"
+	drillmeasureRunner  = "https://github.com/drillmeasure/drillmeasure"
+)
+
+// attestationSubjects are the run files the statement attests to, when present
+var attestationSubjects = []string{"report.json", "report.md", HTMLFileName, ResultFileName, JUnitFileName}
+
+// Statement is an in-toto v1 statement about a drill's reports
+type Statement struct {
+	Type          string         `json:"_type"`
+	Subject       []Subject      `json:"subject"`
+	PredicateType string         `json:"predicateType"`
+	Predicate     DrillPredicate `json:"predicate"`
+}
+
+// Subject is a file the statement is about, identified by its digest
+type Subject struct {
+	Name   string            `json:"name"` // Relative to the run directory
+	Digest map[string]string `json:"digest"`
+}
+
+// DrillPredicate describes the drill the way SLSA provenance describes a
+// build: what was run, by what, when, and what came of it
+type DrillPredicate struct {
+	DrillDefinition DrillDefinition `json:"drillDefinition"`
+	RunDetails      DrillRunDetails `json:"runDetails"`
+	Outcome         DrillOutcome    `json:"outcome"`
+}
+
+// DrillDefinition is the scenario and the input files the drill was run from
+type DrillDefinition struct {
+	DrillType            string            `json:"drillType"`
+	Scenario             ScenarioParams    `json:"scenario"`
+	ResolvedDependencies []Subject         `json:"resolvedDependencies,omitempty"` // Copies under inputs/
+	Variables            map[string]string `json:"variables,omitempty"`            // Resolved, secrets masked
+}
+
+// ScenarioParams are the scenario fields the drill's targets come from
+type ScenarioParams struct {
+	Name      string `json:"name"`
+	Service   string `json:"service,omitempty"`
+	RTOTarget string `json:"rtoTarget,omitempty"`
+	RPOTarget string `json:"rpoTarget,omitempty"`
+	SLA       string `json:"sla,omitempty"` // SLA the targets come from
+}
+
+// DrillRunDetails identifies the tool and the run
+type DrillRunDetails struct {
+	Runner   DrillRunner   `json:"runner"`
+	Metadata DrillMetadata `json:"metadata"`
+}
+
+// DrillRunner is the tool that ran the drill
+type DrillRunner struct {
+	ID      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+// DrillMetadata identifies the run and when it happened
+type DrillMetadata struct {
+	InvocationID string `json:"invocationId"` // Run directory name
+	StartedOn    string `json:"startedOn"`
+	FinishedOn   string `json:"finishedOn"`
+	ResumedOn    string `json:"resumedOn,omitempty"`
+}
+
+// DrillOutcome is the measured result of the drill
+type DrillOutcome struct {
+	Status     string          `json:"status"` // complete or incomplete
+	Simulated  bool            `json:"simulated,omitempty"`
+	RTA        string          `json:"rta,omitempty"`
+	CountedRTA string          `json:"countedRta,omitempty"`
+	RTOPassed  bool            `json:"rtoPassed"`
+	RPOActual  string          `json:"rpoActual,omitempty"`
+	RPOPassed  *bool           `json:"rpoPassed,omitempty"`
+	Outcomes   map[string]bool `json:"outcomes,omitempty"` // Measured outcomes, as in controls files
+	Controls   []ControlData   `json:"controls,omitempty"`
+	Errors     int             `json:"errors"`
+}
+
+// WriteAttestation writes an in-toto statement whose subjects are the run's
+// reports and whose predicate describes the drill. It is written after the
+// reports, which it hashes.
+func WriteAttestation(result *runner.DrillResult, outputDir, toolVersion string) error {
+	data, err := ReadReport(outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to read report: %w", err)
+	}
+
+	statement := Statement{
+		Type:          InTotoStatementType,
+		PredicateType: DrillPredicateType,
+		Predicate: DrillPredicate{
+			DrillDefinition: DrillDefinition{
+				DrillType: drillTypeScenario,
+				Scenario: ScenarioParams{
+					Name:      result.Scenario.Name,
+					Service:   result.Scenario.Service,
+					RTOTarget: data.RTOTarget,
+					RPOTarget: data.RPOTarget,
+					SLA:       result.Scenario.SLA,
+				},
+				Variables: data.Variables,
+			},
+			RunDetails: DrillRunDetails{
+				Runner: DrillRunner{
+					ID:      drillmeasureRunner,
+					Version: map[string]string{"drillmeasure": toolVersion},
+				},
+				Metadata: DrillMetadata{
+					InvocationID: filepath.Base(filepath.Clean(outputDir)),
+					StartedOn:    data.StartTime,
+					FinishedOn:   data.EndTime,
+					ResumedOn:    data.ResumedAt,
+				},
+			},
+			Outcome: DrillOutcome{
+				Status:     data.Status,
+				Simulated:  data.Simulated,
+				RTA:        data.RTA,
+				CountedRTA: data.CountedRTA,
+				RTOPassed:  data.RTOPassed,
+				RPOActual:  data.RPOActual,
+				Outcomes:   drillOutcomes(result),
+				Controls:   data.Controls,
+				Errors:     len(data.Errors),
+			},
+		},
+	}
+	if passed, measured := statement.Predicate.Outcome.Outcomes[config.OutcomeRPO]; measured {
+		statement.Predicate.Outcome.RPOPassed = &passed
+	}
+
+	inputs, err := inputDigests(outputDir)
+	if err != nil {
+		return err
+	}
+	statement.Predicate.DrillDefinition.ResolvedDependencies = inputs
+
+	return writeAttestation(outputDir, &statement)
+}
+
+// RefreshAttestation rehashes the subjects of the run's statement after its
+// reports changed. Runs without a statement are left without one.
+func RefreshAttestation(runDir string) error {
+	raw, err := os.ReadFile(filepath.Join(runDir, AttestationFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var statement Statement
+	if err := json.Unmarshal(raw, &statement); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", AttestationFileName, err)
+	}
+	return writeAttestation(runDir, &statement)
+}
+
+func writeAttestation(runDir string, statement *Statement) error {
+	statement.Subject = []Subject{}
+	for _, name := range attestationSubjects {
+		raw, err := os.ReadFile(filepath.Join(runDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		statement.Subject = append(statement.Subject, Subject{Name: name, Digest: map[string]string{"sha256": hashBytes(raw)}})
+	}
+
+	raw, err := json.MarshalIndent(statement, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(runDir, AttestationFileName), raw, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", AttestationFileName, err)
+	}
+	return nil
+}
+
+// inputDigests hashes the copies of the files the drill was run from
+func inputDigests(runDir string) ([]Subject, error) {
+	var inputs []Subject
+	err := filepath.WalkDir(filepath.Join(runDir, inputsDirName), func(path string, d fs.DirEntry, err error) error {
+		if os.IsNotExist(err) {
+			return fs.SkipDir
+		}
+		if err != nil || d.IsDir() {
+			return err
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(runDir, path)
+		if err != nil {
+			return err
+		}
+		inputs = append(inputs, Subject{Name: filepath.ToSlash(rel), Digest: map[string]string{"sha256": hashBytes(raw)}})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash inputs: %w", err)
+	}
+	sort.Slice(inputs, func(i, j int) bool { return inputs[i].Name < inputs[j].Name })
+	return inputs, nil
+}
@@ -0,0 +1,75 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// badgeLabel is the left-hand text of every scenario badge
+const badgeLabel = "RTO drill"
+
+// Badge colors, matching the usual shield palette
+const (
+	badgePassing = "#4c1"
+	badgeFailing = "#e05d44"
+)
+
+// Badges renders an SVG shield for the latest run of each scenario in the
+// reports directory index, keyed by scenario name
+func Badges(reportsDir string) (map[string]string, error) {
+	index, err := readIndex(reportsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Index entries are kept sorted by start time, so the last one wins
+	latest := map[string]IndexEntry{}
+	for _, e := range index.Runs {
+		if e.Simulated {
+			continue
+		}
+		latest[e.Scenario] = e
+	}
+
+	badges := make(map[string]string, len(latest))
+	for scenario, e := range latest {
+		message, color := "failing", badgeFailing
+		if entryPassed(e) {
+			message, color = "passing", badgePassing
+		}
+		if e.RTA != "" {
+			message += ", " + e.RTA
+		}
+		badges[scenario] = RenderBadge(badgeLabel, message, color)
+	}
+	return badges, nil
+}
+
+// badgeTextWidth approximates the rendered width of text in the 11px badge font
+func badgeTextWidth(text string) int {
+	return len([]rune(text))*7 + 10
+}
+
+// RenderBadge renders a flat two-part SVG shield, e.g. "RTO drill | passing, 3m12s"
+func RenderBadge(label, message, color string) string {
+	lw, mw := badgeTextWidth(label), badgeTextWidth(message)
+	label, message = html.EscapeString(label), html.EscapeString(message)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`+"\n", lw+mw, label, message))
+	b.WriteString(fmt.Sprintf("  <title>%s: %s</title>\n", label, message))
+	b.WriteString(`  <linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>` + "\n")
+	b.WriteString(fmt.Sprintf(`  <clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`+"\n", lw+mw))
+	b.WriteString(`  <g clip-path="url(#r)">` + "\n")
+	b.WriteString(fmt.Sprintf(`    <rect width="%d" height="20" fill="#555"/>`+"\n", lw))
+	b.WriteString(fmt.Sprintf(`    <rect x="%d" width="%d" height="20" fill="%s"/>`+"\n", lw, mw, color))
+	b.WriteString(fmt.Sprintf(`    <rect width="%d" height="20" fill="url(#s)"/>`+"\n", lw+mw))
+	b.WriteString("  </g>\n")
+	b.WriteString(`  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` + "\n")
+	b.WriteString(fmt.Sprintf(`    <text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%d" y="14">%s</text>`+"\n", lw/2, label, lw/2, label))
+	b.WriteString(fmt.Sprintf(`    <text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text><text x="%d" y="14">%s</text>`+"\n", lw+mw/2, message, lw+mw/2, message))
+	b.WriteString("  </g>\n")
+	b.WriteString("</svg>\n")
+	return b.String()
+}
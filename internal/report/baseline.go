@@ -0,0 +1,68 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// BaselineData is the run's RTA relative to its scenario's baseline run in JSON
+type BaselineData struct {
+	ReportPath    string   `json:"report_path"` // Run directory of the baseline run
+	StartTime     string   `json:"start_time"`
+	RTA           string   `json:"rta"`                      // The baseline run's RTA
+	RTAChange     *float64 `json:"rta_change,omitempty"`     // Percent the RTA grew (negative: shrank); absent if the baseline had no downtime
+	MaxRegression *float64 `json:"max_regression,omitempty"` // Percent the RTA may grow by, from max_rta_regression
+	Regressed     bool     `json:"regressed"`
+}
+
+// baselineData returns the comparison with the baseline run, or nil if the
+// run was not compared with one
+func baselineData(result *runner.DrillResult) *BaselineData {
+	if !result.BaselineCompared() {
+		return nil
+	}
+	b := result.Baseline
+	data := &BaselineData{
+		ReportPath:    b.ReportPath,
+		StartTime:     b.StartTime.Format(time.RFC3339),
+		RTA:           formatDuration(b.RTA),
+		MaxRegression: b.MaxRegression,
+		Regressed:     result.BaselineRegressed(),
+	}
+	if change, ok := b.RTAChange(result.RTA); ok {
+		data.RTAChange = &change
+	}
+	return data
+}
+
+// baselineSummaryRow is the Summary table row comparing the RTA with the
+// baseline run's
+func baselineSummaryRow(result *runner.DrillResult) string {
+	b := result.Baseline
+	target, status := "-", "-"
+	if b.MaxRegression != nil {
+		target = fmt.Sprintf("≤ +%g%% vs %s", *b.MaxRegression, formatDuration(b.RTA))
+		status = "✅ PASS"
+		if result.BaselineRegressed() {
+			status = "❌ REGRESSED"
+		}
+	}
+	actual := formatDuration(result.RTA)
+	if change, ok := b.RTAChange(result.RTA); ok {
+		actual += fmt.Sprintf(" (%+.1f%%)", change)
+	}
+	return fmt.Sprintf("| RTA vs Baseline (%s) | %s | %s | %s |\n", b.StartTime.Format("2006-01-02"), target, actual, status)
+}
+
+// junitBaselineCase is the regression check against the baseline run
+func junitBaselineCase(result *runner.DrillResult) junitCase {
+	c := junitCase{Name: "RTA vs baseline", Time: junitSeconds(0)}
+	if result.BaselineRegressed() {
+		change, _ := result.Baseline.RTAChange(result.RTA)
+		c.Failure = junitFailure(fmt.Sprintf("RTA %s is %+.1f%% on the baseline's %s (max: +%g%%)",
+			formatDuration(result.RTA), change, formatDuration(result.Baseline.RTA), *result.Baseline.MaxRegression))
+	}
+	return c
+}
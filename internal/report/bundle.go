@@ -0,0 +1,228 @@
+package report
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// BundleManifestFileName is the manifest at the top of an evidence bundle
+const BundleManifestFileName = "bundle.json"
+
+// BundleManifest lists every file of an evidence bundle. BundleSHA256 chains
+// the file hashes: it is the SHA256 of one "<sha256>  <path>\n" line per file,
+// sorted by path, so it changes if any file is added, removed, or modified.
+type BundleManifest struct {
+	CreatedAt    string         `json:"created_at"`
+	RunDir       string         `json:"run_dir"` // Directory in the bundle holding the run
+	Files        []ManifestFile `json:"files"`   // Paths are relative to the bundle root
+	BundleSHA256 string         `json:"bundle_sha256"`
+}
+
+// bundleHash computes the top-level hash of a bundle's files
+func bundleHash(files []ManifestFile) string {
+	sorted := append([]ManifestFile(nil), files...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Path < sorted[j].Path })
+
+	h := sha256.New()
+	for _, f := range sorted {
+		fmt.Fprintf(h, "%s  %s\n", f.SHA256, f.Path)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// WriteBundle packs a run directory into a tar.gz evidence bundle at
+// bundlePath, with a bundle manifest of every file and the bundle hash
+func WriteBundle(runDir, bundlePath string) (*BundleManifest, error) {
+	runName := filepath.Base(filepath.Clean(runDir))
+	manifest := &BundleManifest{
+		CreatedAt: time.Now().Format(time.RFC3339),
+		RunDir:    runName,
+		Files:     []ManifestFile{},
+	}
+
+	// The bundle must not end up inside itself
+	absBundle, err := filepath.Abs(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	err = filepath.WalkDir(runDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !d.Type().IsRegular() {
+			return err
+		}
+		if abs, err := filepath.Abs(p); err == nil && abs == absBundle {
+			return nil
+		}
+		rel, err := filepath.Rel(runDir, p)
+		if err != nil {
+			return err
+		}
+		raw, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, ManifestFile{
+			Path:   path.Join(runName, filepath.ToSlash(rel)),
+			SHA256: hashBytes(raw),
+			Size:   int64(len(raw)),
+		})
+		paths = append(paths, p)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash run files: %w", err)
+	}
+	if len(manifest.Files) == 0 {
+		return nil, fmt.Errorf("%s has no files", runDir)
+	}
+	manifest.BundleSHA256 = bundleHash(manifest.Files)
+
+	encoded, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Create(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bundle: %w", err)
+	}
+	zw := gzip.NewWriter(f)
+	tw := tar.NewWriter(zw)
+	modTime := time.Now()
+
+	err = writeTarFile(tw, BundleManifestFileName, encoded, modTime)
+	for i := 0; err == nil && i < len(paths); i++ {
+		var raw []byte
+		raw, err = os.ReadFile(paths[i])
+		if err != nil {
+			break
+		}
+		if hashBytes(raw) != manifest.Files[i].SHA256 {
+			err = fmt.Errorf("%s changed while the bundle was written", paths[i])
+			break
+		}
+		err = writeTarFile(tw, manifest.Files[i].Path, raw, modTime)
+	}
+	if err == nil {
+		err = tw.Close()
+	}
+	if err == nil {
+		err = zw.Close()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(bundlePath)
+		return nil, fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return manifest, nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, content []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(content)),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tw.Write(content)
+	return err
+}
+
+// VerifyBundle checks every file of an evidence bundle against its manifest
+// and recomputes the bundle hash. It returns the manifest and the problems
+// found; a bundle with no problems is complete and unmodified.
+func VerifyBundle(bundlePath string) (*BundleManifest, []string, error) {
+	f, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+	tr := tar.NewReader(zr)
+
+	var manifest *BundleManifest
+	hashes := map[string]string{}
+	var duplicates []string
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		raw, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		if header.Name == BundleManifestFileName {
+			manifest = &BundleManifest{}
+			if err := json.Unmarshal(raw, manifest); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse %s: %w", BundleManifestFileName, err)
+			}
+			continue
+		}
+		if _, ok := hashes[header.Name]; ok {
+			duplicates = append(duplicates, header.Name)
+		}
+		hashes[header.Name] = hashBytes(raw)
+	}
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("%s has no %s", bundlePath, BundleManifestFileName)
+	}
+
+	var problems []string
+	for _, name := range duplicates {
+		problems = append(problems, fmt.Sprintf("duplicate: %s", name))
+	}
+	listed := map[string]bool{}
+	for _, file := range manifest.Files {
+		listed[file.Path] = true
+		actual, ok := hashes[file.Path]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("missing: %s", file.Path))
+		case actual != file.SHA256:
+			problems = append(problems, fmt.Sprintf("modified: %s", file.Path))
+		}
+	}
+	var extra []string
+	for name := range hashes {
+		if !listed[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		problems = append(problems, fmt.Sprintf("not in manifest: %s", name))
+	}
+	if hash := bundleHash(manifest.Files); hash != manifest.BundleSHA256 {
+		problems = append(problems, fmt.Sprintf("bundle hash mismatch: manifest records %s, files hash to %s",
+			manifest.BundleSHA256, hash))
+	}
+	return manifest, problems, nil
+}
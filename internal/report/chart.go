@@ -0,0 +1,152 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// ChartFileName is the chart of health checks over the run in the output directory
+const ChartFileName = "health-checks.svg"
+
+// Chart geometry, in pixels
+const (
+	chartWidth     = 800
+	chartLeft      = 70 // Room for the axis labels
+	chartRight     = 20
+	chartStripTop  = 40
+	chartStripH    = 24
+	chartLatencyY  = 90
+	chartLatencyH  = 170
+	chartAxisY     = chartLatencyY + chartLatencyH
+	chartHeight    = chartAxisY + 60
+	chartPlotWidth = chartWidth - chartLeft - chartRight
+)
+
+// chartStateColors match the badge palette, with amber for degraded
+var chartStateColors = map[string]string{
+	runner.StateHealthy:  "#4c1",
+	runner.StateDegraded: "#dfb317",
+	runner.StateDown:     "#e05d44",
+}
+
+// WriteHealthChart writes an SVG chart of availability and health check
+// latency over the run
+func WriteHealthChart(result *runner.DrillResult, outputDir string) error {
+	if len(result.HealthCheckAttempts) == 0 {
+		return nil
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, ChartFileName), []byte(RenderHealthChart(result)), 0644); err != nil {
+		return fmt.Errorf("failed to write health check chart: %w", err)
+	}
+	return nil
+}
+
+// RenderHealthChart draws each health check's state as a strip across the
+// run, and its latency below, with the downtime window shaded across both
+func RenderHealthChart(result *runner.DrillResult) string {
+	start, end := result.StartTime, result.EndTime
+	span := end.Sub(start)
+	x := func(t time.Time) float64 {
+		if span <= 0 {
+			return chartLeft
+		}
+		f := float64(t.Sub(start)) / float64(span)
+		return chartLeft + min(max(f, 0), 1)*chartPlotWidth
+	}
+
+	attempts := result.HealthCheckAttempts
+	slowest := time.Duration(0)
+	for _, a := range attempts {
+		slowest = max(slowest, a.Duration)
+	}
+	top := chartLatencyScale(slowest)
+	y := func(d time.Duration) float64 {
+		return chartAxisY - float64(d)/float64(top)*chartLatencyH
+	}
+
+	var b strings.Builder
+	title := html.EscapeString(result.Scenario.Name + ": health checks")
+	b.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="%s">`+"\n",
+		chartWidth, chartHeight, chartWidth, chartHeight, title))
+	b.WriteString(fmt.Sprintf("  <title>%s</title>\n", title))
+	b.WriteString(`  <rect width="100%" height="100%" fill="#fff"/>` + "\n")
+	b.WriteString(`  <g font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11" fill="#333">` + "\n")
+	b.WriteString(fmt.Sprintf(`    <text x="%d" y="20" font-size="13" font-weight="bold">%s</text>`+"\n", chartLeft, title))
+
+	// Downtime window, behind everything else
+	if !result.RTOStartTime.IsZero() && !result.RTOEndTime.IsZero() {
+		x0, x1 := x(result.RTOStartTime), x(result.RTOEndTime)
+		b.WriteString(fmt.Sprintf(`    <rect x="%.1f" y="%d" width="%.1f" height="%d" fill="#e05d44" fill-opacity="0.12"/>`+"\n",
+			x0, chartStripTop-6, max(x1-x0, 1), chartAxisY-chartStripTop+6))
+		b.WriteString(fmt.Sprintf(`    <text x="%.1f" y="%d" fill="#e05d44">Downtime (RTA %s)</text>`+"\n",
+			x0+3, chartLatencyY-6, formatDuration(result.RTA)))
+	}
+
+	// Availability strip: each check's state holds until the next check
+	b.WriteString(fmt.Sprintf(`    <text x="%d" y="%d" text-anchor="end">Availability</text>`+"\n", chartLeft-8, chartStripTop+chartStripH/2+4))
+	for i, a := range attempts {
+		until := end
+		if i+1 < len(attempts) {
+			until = attempts[i+1].Timestamp
+		}
+		x0, x1 := x(a.Timestamp), x(until)
+		b.WriteString(fmt.Sprintf(`    <rect x="%.1f" y="%d" width="%.1f" height="%d" fill="%s"/>`+"\n",
+			x0, chartStripTop, max(x1-x0, 1), chartStripH, chartStateColors[a.State()]))
+	}
+
+	// Latency axis and gridlines
+	b.WriteString(fmt.Sprintf(`    <text x="%d" y="%d" text-anchor="end">Latency</text>`+"\n", chartLeft-8, chartLatencyY-6))
+	for _, f := range []float64{0, 0.5, 1} {
+		d := time.Duration(float64(top) * f)
+		gy := y(d)
+		b.WriteString(fmt.Sprintf(`    <line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#ddd"/>`+"\n", chartLeft, gy, chartWidth-chartRight, gy))
+		b.WriteString(fmt.Sprintf(`    <text x="%d" y="%.1f" text-anchor="end">%s</text>`+"\n", chartLeft-8, gy+4, formatDuration(d)))
+	}
+
+	// Latency line, with each check colored by its state
+	points := make([]string, len(attempts))
+	for i, a := range attempts {
+		points[i] = fmt.Sprintf("%.1f,%.1f", x(a.Timestamp), y(a.Duration))
+	}
+	b.WriteString(fmt.Sprintf(`    <polyline points="%s" fill="none" stroke="#888" stroke-width="1"/>`+"\n", strings.Join(points, " ")))
+	for _, a := range attempts {
+		b.WriteString(fmt.Sprintf(`    <circle cx="%.1f" cy="%.1f" r="2.5" fill="%s"/>`+"\n", x(a.Timestamp), y(a.Duration), chartStateColors[a.State()]))
+	}
+
+	// Time axis, as time since the drill started
+	b.WriteString(fmt.Sprintf(`    <line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#333"/>`+"\n", chartLeft, chartAxisY, chartWidth-chartRight, chartAxisY))
+	for i := 0; i <= 4; i++ {
+		tx := chartLeft + float64(i)/4*chartPlotWidth
+		b.WriteString(fmt.Sprintf(`    <text x="%.1f" y="%d" text-anchor="middle">+%s</text>`+"\n",
+			tx, chartAxisY+16, formatDuration(span*time.Duration(i)/4)))
+	}
+
+	// Legend
+	lx := chartLeft
+	for _, state := range []string{runner.StateHealthy, runner.StateDegraded, runner.StateDown} {
+		b.WriteString(fmt.Sprintf(`    <rect x="%d" y="%d" width="10" height="10" fill="%s"/><text x="%d" y="%d">%s</text>`+"\n",
+			lx, chartAxisY+32, chartStateColors[state], lx+14, chartAxisY+41, state))
+		lx += 90
+	}
+	b.WriteString("  </g>\n")
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// chartLatencyScale rounds the slowest latency up to a 1, 2, or 5 step so
+// the axis labels stay readable
+func chartLatencyScale(slowest time.Duration) time.Duration {
+	for step := time.Millisecond; ; step *= 10 {
+		for _, m := range []time.Duration{1, 2, 5} {
+			if slowest <= step*m {
+				return step * m
+			}
+		}
+	}
+}
@@ -0,0 +1,317 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RunComparison is one run in a comparison, with the label it is shown under
+type RunComparison struct {
+	Label string // e.g. the run directory name
+	Data  *ReportData
+}
+
+// GenerateComparisonMarkdownReport diffs two runs' reports: RTA and its
+// breakdown, per-phase timings, health check counts, and checks whose result
+// changed. Changes are B relative to A, so A is normally the earlier run.
+func GenerateComparisonMarkdownReport(a, b RunComparison) string {
+	var s strings.Builder
+
+	s.WriteString("# Drill Comparison\n\n")
+	s.WriteString("| | Run | Scenario | Started |\n")
+	s.WriteString("|---|-----|----------|---------|\n")
+	runs := map[string]RunComparison{"A": a, "B": b}
+	for _, letter := range []string{"A", "B"} {
+		run := runs[letter]
+		s.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", letter, run.Label, comparisonScenario(run.Data), run.Data.StartTime))
+	}
+	s.WriteString("\n")
+	if comparisonScenario(a.Data) != comparisonScenario(b.Data) {
+		s.WriteString("> ⚠️ The runs are of different scenarios, so their timings may not be comparable.\n\n")
+	}
+	for _, letter := range []string{"A", "B"} {
+		if runs[letter].Data.Status == "incomplete" {
+			s.WriteString(fmt.Sprintf("> ⚠️ Run %s is incomplete; its results are partial.\n\n", letter))
+		}
+		if runs[letter].Data.Simulated {
+			s.WriteString(fmt.Sprintf("> ⚠️ Run %s was simulated; it is not evidence of a drill.\n\n", letter))
+		}
+	}
+
+	s.WriteString(fmt.Sprintf("**Result:** %s\n\n", summarizeRTAChange(a.Data, b.Data)))
+
+	// Summary
+	s.WriteString("## Summary\n\n")
+	s.WriteString("| Metric | A | B | Change |\n")
+	s.WriteString("|--------|---|---|--------|\n")
+	durationRow := func(name, va, vb string) {
+		if va == "" && vb == "" {
+			return
+		}
+		s.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", name, orDash(va), orDash(vb), formatDurationChange(va, vb)))
+	}
+	durationRow("RTA", a.Data.RTA, b.Data.RTA)
+	durationRow("Counted RTA", a.Data.CountedRTA, b.Data.CountedRTA)
+	durationRow("RTO target", a.Data.RTOTarget, b.Data.RTOTarget)
+	s.WriteString(fmt.Sprintf("| RTO | %s | %s | %s |\n",
+		passFail(a.Data.RTOPassed), passFail(b.Data.RTOPassed), formatResultChange(a.Data.RTOPassed, b.Data.RTOPassed)))
+	durationRow("Time to detect", a.Data.TimeToDetect, b.Data.TimeToDetect)
+	durationRow("Recovery execution", a.Data.RecoveryExecution, b.Data.RecoveryExecution)
+	durationRow("Time to validate", a.Data.TimeToValidate, b.Data.TimeToValidate)
+	if a.Data.RPOTarget != "" || b.Data.RPOTarget != "" {
+		durationRow("RPO actual", a.Data.RPOActual, b.Data.RPOActual)
+		durationRow("RPO target", a.Data.RPOTarget, b.Data.RPOTarget)
+		s.WriteString(fmt.Sprintf("| RPO | %s | %s | %s |\n",
+			passFail(a.Data.RPOPassed), passFail(b.Data.RPOPassed), formatResultChange(a.Data.RPOPassed, b.Data.RPOPassed)))
+	}
+	durationRow("Switchback", a.Data.SwitchbackTime, b.Data.SwitchbackTime)
+	countRow := func(name string, va, vb int) {
+		s.WriteString(fmt.Sprintf("| %s | %d | %d | %s |\n", name, va, vb, formatCountChange(va, vb)))
+	}
+	countRow("Health checks", len(a.Data.HealthCheckAttempts), len(b.Data.HealthCheckAttempts))
+	countRow("Failed health checks", a.Data.FailedProbes, b.Data.FailedProbes)
+	countRow("Errors", len(a.Data.Errors), len(b.Data.Errors))
+	s.WriteString("\n")
+
+	// Phases
+	if len(a.Data.Phases) > 0 || len(b.Data.Phases) > 0 {
+		s.WriteString("## Phases\n\n")
+		s.WriteString("| Phase | A | B | Change |\n")
+		s.WriteString("|-------|---|---|--------|\n")
+		for _, name := range comparisonPhaseNames(a.Data, b.Data) {
+			label := phaseLabels[name]
+			if label == "" {
+				label = name
+			}
+			da, db := phaseDuration(a.Data, name), phaseDuration(b.Data, name)
+			s.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", label, orDash(da), orDash(db), formatDurationChange(da, db)))
+		}
+		s.WriteString("\n")
+	}
+
+	// Checks
+	s.WriteString("## Pass/Fail Changes\n\n")
+	changes := checkChanges(a.Data, b.Data)
+	if len(changes) == 0 {
+		s.WriteString("No check changed result.\n\n")
+		return s.String()
+	}
+	s.WriteString("| Check | A | B | Change |\n")
+	s.WriteString("|-------|---|---|--------|\n")
+	for _, c := range changes {
+		s.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", c.name, c.a, c.b, c.change))
+	}
+	s.WriteString("\n")
+	return s.String()
+}
+
+func comparisonScenario(data *ReportData) string {
+	if data.Scenario == nil {
+		return "-"
+	}
+	return data.Scenario.Name
+}
+
+// summarizeRTAChange states in one line whether recovery got faster
+func summarizeRTAChange(a, b *ReportData) string {
+	ra, errA := time.ParseDuration(a.RTA)
+	rb, errB := time.ParseDuration(b.RTA)
+	switch {
+	case errA != nil || errB != nil:
+		return "RTA not measured in both runs"
+	case rb < ra:
+		return fmt.Sprintf("✅ RTA improved by %s%s", formatDuration(ra-rb), formatPercent(rb-ra, ra))
+	case rb > ra:
+		return fmt.Sprintf("❌ RTA regressed by %s%s", formatDuration(rb-ra), formatPercent(rb-ra, ra))
+	}
+	return "RTA unchanged"
+}
+
+// formatDurationChange renders B minus A for two formatted durations
+func formatDurationChange(va, vb string) string {
+	da, errA := time.ParseDuration(va)
+	db, errB := time.ParseDuration(vb)
+	if errA != nil || errB != nil {
+		return "-"
+	}
+	if da == db {
+		return "no change"
+	}
+	sign := "+"
+	diff := db - da
+	if diff < 0 {
+		sign = "-"
+		diff = -diff
+	}
+	return sign + formatDuration(diff) + formatPercent(db-da, da)
+}
+
+// formatPercent renders change as a percentage of base, when base is non-zero
+func formatPercent(change, base time.Duration) string {
+	if base == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (%+.0f%%)", float64(change)/float64(base)*100)
+}
+
+func formatCountChange(va, vb int) string {
+	if va == vb {
+		return "no change"
+	}
+	return fmt.Sprintf("%+d", vb-va)
+}
+
+func formatResultChange(passedA, passedB bool) string {
+	switch {
+	case passedA == passedB:
+		return "no change"
+	case passedB:
+		return "✅ fixed"
+	}
+	return "❌ regressed"
+}
+
+func passFail(passed bool) string {
+	if passed {
+		return "✅ PASS"
+	}
+	return "❌ FAIL"
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// comparisonPhaseNames lists the phases of either run, in A's order and then
+// those only B entered
+func comparisonPhaseNames(a, b *ReportData) []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, phases := range [][]PhaseData{a.Phases, b.Phases} {
+		for _, p := range phases {
+			if !seen[p.Name] {
+				seen[p.Name] = true
+				names = append(names, p.Name)
+			}
+		}
+	}
+	return names
+}
+
+// phaseDuration returns the total duration of a phase, which a resumed run
+// may have entered more than once
+func phaseDuration(data *ReportData, name string) string {
+	var total time.Duration
+	found := false
+	for _, p := range data.Phases {
+		if p.Name != name {
+			continue
+		}
+		d, err := time.ParseDuration(p.Duration)
+		if err != nil {
+			return ""
+		}
+		total += d
+		found = true
+	}
+	if !found {
+		return ""
+	}
+	return formatDuration(total)
+}
+
+type checkChange struct {
+	name, a, b, change string
+}
+
+// checkChanges lists the checks whose result differs between the runs,
+// including checks only one of them ran
+func checkChanges(a, b *ReportData) []checkChange {
+	resultsA, resultsB := checkResults(a), checkResults(b)
+
+	var changes []checkChange
+	seen := map[string]bool{}
+	for _, results := range [][]checkResult{resultsA, resultsB} {
+		for _, r := range results {
+			if seen[r.name] {
+				continue
+			}
+			seen[r.name] = true
+			ra, inA := findCheck(resultsA, r.name)
+			rb, inB := findCheck(resultsB, r.name)
+			switch {
+			case !inA:
+				changes = append(changes, checkChange{r.name, "-", rb, "new in B"})
+			case !inB:
+				changes = append(changes, checkChange{r.name, ra, "-", "not in B"})
+			case ra != rb:
+				change := "changed"
+				if rb == passFail(true) {
+					change = "✅ fixed"
+				} else if ra == passFail(true) {
+					change = "❌ regressed"
+				}
+				changes = append(changes, checkChange{r.name, ra, rb, change})
+			}
+		}
+	}
+	return changes
+}
+
+type checkResult struct {
+	name, result string
+}
+
+func findCheck(results []checkResult, name string) (string, bool) {
+	for _, r := range results {
+		if r.name == name {
+			return r.result, true
+		}
+	}
+	return "", false
+}
+
+// checkResults lists the result of every check a run reports
+func checkResults(data *ReportData) []checkResult {
+	var results []checkResult
+	if data.BackupFreshness != nil {
+		results = append(results, checkResult{"Backup freshness", passFail(!data.BackupStale)})
+	}
+	for _, g := range data.Guards {
+		results = append(results, checkResult{"Guard: " + g.Name, passFail(g.Passed)})
+	}
+	if data.RestoreCheck != nil {
+		results = append(results, checkResult{"Restore check", passFail(data.RestoreCheck.Passed)})
+	}
+	for _, c := range data.RPOChecks {
+		results = append(results, checkResult{"RPO check: " + c.Name, passFail(c.Passed)})
+	}
+	for _, c := range data.ConsistencyChecks {
+		results = append(results, checkResult{"Consistency check: " + c.Name, passFail(c.Passed)})
+	}
+	for _, as := range data.Assertions {
+		results = append(results, checkResult{"Assertion: `" + as.Expression + "`", passFail(as.Passed)})
+	}
+	if data.Switchback != nil {
+		results = append(results, checkResult{"Switchback", passFail(data.SwitchbackPassed)})
+	}
+	for _, c := range data.Controls {
+		status := "➖ Not measured"
+		switch c.Status {
+		case ControlMet:
+			status = passFail(true)
+		case ControlNotMet:
+			status = passFail(false)
+		}
+		id := c.ID
+		if c.Framework != "" {
+			id = c.Framework + " " + c.ID
+		}
+		results = append(results, checkResult{"Control: " + id, status})
+	}
+	return results
+}
@@ -0,0 +1,126 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// Control statuses
+const (
+	ControlMet         = "met"
+	ControlNotMet      = "not_met"
+	ControlNotMeasured = "not_measured"
+)
+
+// ControlData represents a mapped compliance control in JSON
+type ControlData struct {
+	ID          string   `json:"id"`
+	Framework   string   `json:"framework,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Outcomes    []string `json:"outcomes"`
+	Status      string   `json:"status"` // met, not_met, or not_measured
+}
+
+// drillOutcomes reports whether each measured outcome of the drill passed;
+// outcomes the drill did not measure are absent
+func drillOutcomes(result *runner.DrillResult) map[string]bool {
+	outcomes := map[string]bool{}
+	if result.BackupFreshness != nil {
+		outcomes[config.OutcomeBackupFreshness] = !result.BackupStale
+	}
+	if len(result.Guards) > 0 {
+		outcomes[config.OutcomeGuards] = !result.GuardFailed
+	}
+	if !result.BackupStale && !result.GuardFailed {
+		// An incomplete drill did not demonstrate recovery, even if it passed so far
+		outcomes[config.OutcomeRTO] = result.RTOPassed && !result.Incomplete
+	}
+	if (result.RPOTarget > 0 && !result.OnlyRPOChecks()) || result.MissingRows != nil {
+		outcomes[config.OutcomeRPO] = result.RPOPassed
+	}
+	if len(result.RPOChecks) > 0 {
+		passed, measured := outcomes[config.OutcomeRPO]
+		outcomes[config.OutcomeRPO] = (passed || !measured) && result.RPOChecksPassed()
+	}
+	if len(result.ConsistencyChecks) > 0 {
+		outcomes[config.OutcomeConsistency] = result.ConsistencyPassed()
+	}
+	if len(result.Assertions) > 0 {
+		outcomes[config.OutcomeAssertions] = result.AssertionsPassed()
+	}
+	if result.RestoreCheck != nil {
+		outcomes[config.OutcomeRestore] = result.RestoreCheck.Passed
+	}
+	if result.Switchback != nil {
+		outcomes[config.OutcomeSwitchback] = result.SwitchbackPassed
+	}
+
+	if len(outcomes) > 0 {
+		drill := true
+		for _, passed := range outcomes {
+			drill = drill && passed
+		}
+		outcomes[config.OutcomeDrill] = drill
+	}
+	return outcomes
+}
+
+// evaluateControls maps the drill's outcomes to the scenario's controls. A
+// control is met when every mapped outcome the drill measured passed, and
+// not measured when the drill measured none of them.
+func evaluateControls(result *runner.DrillResult) []ControlData {
+	if result.Scenario == nil || result.Scenario.Controls == nil {
+		return nil
+	}
+
+	outcomes := drillOutcomes(result)
+	var controls []ControlData
+	for _, c := range result.Scenario.Controls.Controls {
+		status := ControlNotMeasured
+		for _, o := range c.Outcomes {
+			passed, measured := outcomes[o]
+			if !measured {
+				continue
+			}
+			if !passed {
+				status = ControlNotMet
+				break
+			}
+			status = ControlMet
+		}
+		controls = append(controls, ControlData{
+			ID:          c.ID,
+			Framework:   c.Framework,
+			Description: c.Description,
+			Outcomes:    c.Outcomes,
+			Status:      status,
+		})
+	}
+	return controls
+}
+
+// formatControls renders the control mapping for the Compliance Notes section
+func formatControls(controls []ControlData) string {
+	var b strings.Builder
+	b.WriteString("| Framework | Control | Description | Evidence | Status |\n")
+	b.WriteString("|-----------|---------|-------------|----------|--------|\n")
+	for _, c := range controls {
+		status := "➖ Not measured"
+		switch c.Status {
+		case ControlMet:
+			status = "✅ Met"
+		case ControlNotMet:
+			status = "❌ Not met"
+		}
+		framework := c.Framework
+		if framework == "" {
+			framework = "-"
+		}
+		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+			framework, c.ID, c.Description, strings.Join(c.Outcomes, ", "), status))
+	}
+	return b.String()
+}
@@ -0,0 +1,145 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/drillmeasure/drillmeasure/internal/inventory"
+)
+
+// ServiceCoverage is the drill status of one inventory service
+type ServiceCoverage struct {
+	Service   inventory.Service
+	Scenarios []string // Scenario files that drill the service
+	Runs      int
+	LastRun   *IndexEntry
+}
+
+// CoverageReport compares a service inventory with scenarios and stored runs
+type CoverageReport struct {
+	Source    string
+	Services  []ServiceCoverage
+	Unmatched []string // Drilled services or scenarios that are not in the inventory
+}
+
+// BuildCoverageReport matches inventory services to scenarios by their service
+// (or name) and to indexed runs in the reports directory
+func BuildCoverageReport(reportsDir string, inv *inventory.Inventory, scenarios []config.ScenarioFile) (*CoverageReport, error) {
+	index, err := readIndex(reportsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &CoverageReport{Source: inv.Source}
+	known := map[string]int{}
+	for i, s := range inv.Services {
+		known[s.Name] = i
+		c.Services = append(c.Services, ServiceCoverage{Service: s})
+	}
+
+	unmatched := map[string]bool{}
+	for _, f := range scenarios {
+		service := f.Scenario.ServiceName()
+		if i, ok := known[service]; ok {
+			c.Services[i].Scenarios = append(c.Services[i].Scenarios, f.Path)
+		} else {
+			unmatched[service] = true
+		}
+	}
+
+	// Index entries are kept sorted by start time, so the last match is the latest run
+	for i := range index.Runs {
+		entry := index.Runs[i]
+		if entry.Simulated {
+			continue
+		}
+		service := entry.Service
+		if service == "" {
+			service = entry.Scenario
+		}
+		if j, ok := known[service]; ok {
+			c.Services[j].Runs++
+			c.Services[j].LastRun = &index.Runs[i]
+		} else {
+			unmatched[service] = true
+		}
+	}
+
+	for name := range unmatched {
+		c.Unmatched = append(c.Unmatched, name)
+	}
+	sort.Strings(c.Unmatched)
+	return c, nil
+}
+
+// GenerateCoverageMarkdownReport renders a coverage report as Markdown
+func GenerateCoverageMarkdownReport(c *CoverageReport) string {
+	var b strings.Builder
+
+	drilled, withScenario := 0, 0
+	for _, s := range c.Services {
+		if s.LastRun != nil {
+			drilled++
+		}
+		if len(s.Scenarios) > 0 {
+			withScenario++
+		}
+	}
+
+	b.WriteString("# DR Drill Coverage Report\n\n")
+	b.WriteString(fmt.Sprintf("**Inventory:** %s\n\n", c.Source))
+	b.WriteString(fmt.Sprintf("**Generated:** %s\n\n", time.Now().Format(time.RFC3339)))
+
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Metric | Value |\n")
+	b.WriteString("|--------|-------|\n")
+	b.WriteString(fmt.Sprintf("| Services in inventory | %d |\n", len(c.Services)))
+	b.WriteString(fmt.Sprintf("| Services with scenarios | %s |\n", formatPassRate(withScenario, len(c.Services))))
+	b.WriteString(fmt.Sprintf("| Services drilled | %s |\n", formatPassRate(drilled, len(c.Services))))
+	b.WriteString(fmt.Sprintf("| Never drilled | %d |\n", len(c.Services)-drilled))
+	b.WriteString("\n")
+
+	b.WriteString("## Services\n\n")
+	b.WriteString("| Service | Owner | Tier | Scenarios | Drills | Last Drill | Last Result |\n")
+	b.WriteString("|---------|-------|------|-----------|--------|------------|-------------|\n")
+	for _, s := range c.Services {
+		scenarios := "❌ none"
+		if len(s.Scenarios) > 0 {
+			scenarios = "`" + strings.Join(s.Scenarios, "`, `") + "`"
+		}
+		lastRun, lastResult := "⚠️ never drilled", "-"
+		if s.LastRun != nil {
+			lastRun = s.LastRun.StartTime
+			lastResult = "❌ FAIL"
+			if entryPassed(*s.LastRun) {
+				lastResult = "✅ PASS"
+			}
+		}
+		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %d | %s | %s |\n",
+			s.Service.Name, dashIfEmpty(s.Service.Owner), dashIfEmpty(s.Service.Tier),
+			scenarios, s.Runs, lastRun, lastResult))
+	}
+	b.WriteString("\n")
+
+	if len(c.Unmatched) > 0 {
+		b.WriteString("## Not in Inventory\n\n")
+		b.WriteString("These services have scenarios or runs but are not listed in the inventory. ")
+		b.WriteString("Set `service:` in the scenario to match an inventory name, or add them to the inventory.\n\n")
+		for _, name := range c.Unmatched {
+			b.WriteString(fmt.Sprintf("- %s\n", name))
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
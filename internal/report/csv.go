@@ -0,0 +1,85 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// CSV exports in the output directory, for spreadsheets and BI tools
+const (
+	AttemptsFileName = "attempts.csv"
+	TimelineFileName = "timeline.csv"
+)
+
+// WriteCSVExports writes every health check attempt and the drill timeline
+// as CSV. Times are RFC 3339 in UTC and durations are in milliseconds.
+func WriteCSVExports(result *runner.DrillResult, outputDir string) error {
+	attempts := [][]string{{"attempt", "timestamp", "phase", "exit_code", "latency_ms", "state"}}
+	for i, attempt := range result.HealthCheckAttempts {
+		attempts = append(attempts, []string{
+			strconv.Itoa(i + 1),
+			csvTime(attempt.Timestamp),
+			phaseAt(result, attempt.Timestamp),
+			strconv.Itoa(attempt.ExitCode),
+			csvMillis(attempt.Duration),
+			attempt.State(),
+		})
+	}
+	if err := writeCSV(filepath.Join(outputDir, AttemptsFileName), attempts); err != nil {
+		return fmt.Errorf("failed to write health check attempts: %w", err)
+	}
+
+	timeline := [][]string{{"phase", "label", "start", "end", "duration_ms"}}
+	for _, row := range timelineRows(result) {
+		end, duration := "", ""
+		if !row.end.IsZero() {
+			end, duration = csvTime(row.end), csvMillis(row.end.Sub(row.start))
+		}
+		timeline = append(timeline, []string{row.name, row.label, csvTime(row.start), end, duration})
+	}
+	if err := writeCSV(filepath.Join(outputDir, TimelineFileName), timeline); err != nil {
+		return fmt.Errorf("failed to write timeline: %w", err)
+	}
+	return nil
+}
+
+// phaseAt returns the drill phase in progress at t. Recovery runs alongside
+// RTA measurement, so the phase that started last wins.
+func phaseAt(result *runner.DrillResult, t time.Time) string {
+	for i := len(result.Phases) - 1; i >= 0; i-- {
+		p := result.Phases[i]
+		if !t.Before(p.Start) && (p.End.IsZero() || !t.After(p.End)) {
+			return p.Name
+		}
+	}
+	return ""
+}
+
+// writeCSV writes rows to a new CSV file at path
+func writeCSV(path string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	if err := w.WriteAll(rows); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func csvTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func csvMillis(d time.Duration) string {
+	return strconv.FormatFloat(float64(d)/float64(time.Millisecond), 'f', 3, 64)
+}
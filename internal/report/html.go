@@ -0,0 +1,244 @@
+package report
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// HTMLFileName holds the HTML rendering of report.md
+const HTMLFileName = "report.html"
+
+const htmlStyle = `body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 1000px; margin: 2em auto; padding: 0 1em; color: #1f2328; line-height: 1.5; }
+h1, h2 { border-bottom: 1px solid #d0d7de; padding-bottom: .3em; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #d0d7de; padding: 4px 10px; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+code { background: #f6f8fa; padding: .1em .3em; border-radius: 4px; font-size: 90%; }
+pre { background: #f6f8fa; padding: 1em; overflow-x: auto; border-radius: 6px; }
+pre code { background: none; padding: 0; }
+blockquote { margin: 1em 0; padding: 0 1em; color: #59636e; border-left: 4px solid #d0d7de; }
+img { max-width: 100%; }
+`
+
+var (
+	htmlCode    = regexp.MustCompile("`([^`]+)`")
+	htmlImage   = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)\)`)
+	htmlLink    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	htmlBold    = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	htmlItalic  = regexp.MustCompile(`(^|\s|\()_(\S(?:.*?\S)?)_($|\s|[.,;:)])`)
+	htmlTableHR = regexp.MustCompile(`^\|?(\s*:?-+:?\s*\|)+\s*:?-*:?\s*$`)
+)
+
+// GenerateHTMLReport renders a Markdown report as a standalone HTML page. It
+// covers the Markdown the reports are written in: headings, tables, lists,
+// code blocks, quotes, images, and bold, italic, and code text.
+func GenerateHTMLReport(markdown string) string {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	title := "Drill Report"
+	for _, line := range lines {
+		if strings.HasPrefix(line, "# ") {
+			title = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+			break
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	b.WriteString("<title>" + html.EscapeString(title) + "</title>\n")
+	b.WriteString("<style>\n" + htmlStyle + "</style>\n</head>\n<body>\n")
+	renderMarkdownBlocks(&b, lines)
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+func renderMarkdownBlocks(b *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case strings.HasPrefix(trimmed, "```"):
+			i++
+			var code []string
+			for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```") {
+				code = append(code, lines[i])
+				i++
+			}
+			i++ // Closing fence
+			b.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case headingLevel(line) > 0:
+			level := string(rune('0' + headingLevel(line)))
+			text := strings.TrimSpace(strings.TrimLeft(line, "#"))
+			b.WriteString("<h" + level + ">" + renderInline(text) + "</h" + level + ">\n")
+			i++
+
+		case trimmed == "---" || trimmed == "***":
+			b.WriteString("<hr>\n")
+			i++
+
+		case strings.HasPrefix(line, "|"):
+			var rows []string
+			for i < len(lines) && strings.HasPrefix(lines[i], "|") {
+				rows = append(rows, lines[i])
+				i++
+			}
+			renderTable(b, rows)
+
+		case strings.HasPrefix(line, ">"):
+			var quote []string
+			for i < len(lines) && strings.HasPrefix(lines[i], ">") {
+				quote = append(quote, renderInline(strings.TrimSpace(strings.TrimPrefix(lines[i], ">"))))
+				i++
+			}
+			b.WriteString("<blockquote><p>" + strings.Join(quote, "<br>\n") + "</p></blockquote>\n")
+
+		case isListItem(line):
+			i = renderList(b, lines, i)
+
+		default:
+			var para []string
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" && !startsBlock(lines[i]) {
+				para = append(para, renderInline(strings.TrimSpace(lines[i])))
+				i++
+			}
+			b.WriteString("<p>" + strings.Join(para, "<br>\n") + "</p>\n")
+		}
+	}
+}
+
+// renderList renders the list starting at lines[i] and returns the index of
+// the first line after it. Item text continues on lines indented by two
+// spaces, including after blank lines.
+func renderList(b *strings.Builder, lines []string, i int) int {
+	b.WriteString("<ul>\n")
+	for i < len(lines) && isListItem(lines[i]) {
+		paragraphs := [][]string{{renderInline(strings.TrimSpace(lines[i][2:]))}}
+		i++
+		for i < len(lines) {
+			if strings.TrimSpace(lines[i]) == "" {
+				// A blank line ends the item unless indented text follows
+				next := i + 1
+				for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+					next++
+				}
+				if next < len(lines) && strings.HasPrefix(lines[next], "  ") && !isListItem(lines[next]) {
+					paragraphs = append(paragraphs, nil)
+					i = next
+					continue
+				}
+				break
+			}
+			if !strings.HasPrefix(lines[i], "  ") {
+				break
+			}
+			last := len(paragraphs) - 1
+			paragraphs[last] = append(paragraphs[last], renderInline(strings.TrimSpace(lines[i])))
+			i++
+		}
+
+		b.WriteString("<li>")
+		for n, para := range paragraphs {
+			if n > 0 {
+				b.WriteString("<p>")
+			}
+			b.WriteString(strings.Join(para, "<br>\n"))
+			if n > 0 {
+				b.WriteString("</p>")
+			}
+		}
+		b.WriteString("</li>\n")
+
+		// Items separated by blank lines stay in one list
+		next := i
+		for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+			next++
+		}
+		if next < len(lines) && isListItem(lines[next]) {
+			i = next
+		}
+	}
+	b.WriteString("</ul>\n")
+	return i
+}
+
+func renderTable(b *strings.Builder, rows []string) {
+	b.WriteString("<table>\n")
+	body := rows
+	if len(rows) > 1 && htmlTableHR.MatchString(rows[1]) {
+		b.WriteString("<thead><tr>")
+		for _, cell := range tableCells(rows[0]) {
+			b.WriteString("<th>" + renderInline(cell) + "</th>")
+		}
+		b.WriteString("</tr></thead>\n")
+		body = rows[2:]
+	}
+	b.WriteString("<tbody>\n")
+	for _, row := range body {
+		b.WriteString("<tr>")
+		for _, cell := range tableCells(row) {
+			b.WriteString("<td>" + renderInline(cell) + "</td>")
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</tbody>\n</table>\n")
+}
+
+func tableCells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	row = strings.TrimSuffix(row, "|")
+	cells := strings.Split(row, "|")
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+	return cells
+}
+
+func headingLevel(line string) int {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || level >= len(line) || line[level] != ' ' {
+		return 0
+	}
+	return level
+}
+
+func isListItem(line string) bool {
+	return strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ")
+}
+
+func startsBlock(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "```") || headingLevel(line) > 0 || trimmed == "---" ||
+		strings.HasPrefix(line, "|") || strings.HasPrefix(line, ">") || isListItem(line)
+}
+
+// renderInline escapes text and renders its inline Markdown. Code spans are
+// left as written.
+func renderInline(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range htmlCode.FindAllStringSubmatchIndex(text, -1) {
+		b.WriteString(renderEmphasis(text[last:m[0]]))
+		b.WriteString("<code>" + html.EscapeString(text[m[2]:m[3]]) + "</code>")
+		last = m[1]
+	}
+	b.WriteString(renderEmphasis(text[last:]))
+	return b.String()
+}
+
+func renderEmphasis(text string) string {
+	text = html.EscapeString(text)
+	text = htmlImage.ReplaceAllString(text, `<img src="$2" alt="$1">`)
+	text = htmlLink.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = htmlBold.ReplaceAllString(text, "<strong>$1</strong>")
+	return htmlItalic.ReplaceAllString(text, "$1<em>$2</em>$3")
+}
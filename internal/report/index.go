@@ -0,0 +1,167 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// IndexFileName is the run index kept at the top of the reports directory
+const IndexFileName = "index.json"
+
+// Index summarizes every run in a reports directory
+type Index struct {
+	Runs []IndexEntry `json:"runs"`
+}
+
+// IndexEntry summarizes a single run for discovery by other tools
+type IndexEntry struct {
+	RunDir     string `json:"run_dir"`
+	Scenario   string `json:"scenario"`
+	Service    string `json:"service,omitempty"`
+	StartTime  string `json:"start_time"`
+	EndTime    string `json:"end_time"`
+	RTOTarget  string `json:"rto_target"`
+	RTA        string `json:"rta"`
+	RTOPassed  bool   `json:"rto_passed"`
+	RPOTarget  string `json:"rpo_target,omitempty"`
+	RPOPassed  bool   `json:"rpo_passed,omitempty"`
+	RPOActual  string `json:"rpo_actual,omitempty"` // Measured data loss
+	Errors     int    `json:"errors"`
+	Incomplete bool   `json:"incomplete,omitempty"` // The run stopped before completion; results are partial
+	Simulated  bool   `json:"simulated,omitempty"`  // Run with --simulate; not evidence of a drill
+}
+
+// ReadReport loads a run's report.json
+func ReadReport(runDir string) (*ReportData, error) {
+	raw, err := os.ReadFile(filepath.Join(runDir, "report.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := ParseReport(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Join(runDir, "report.json"), err)
+	}
+	return data, nil
+}
+
+// newIndexEntry builds an index entry from a run's report data
+func newIndexEntry(runDir string, data *ReportData) IndexEntry {
+	entry := IndexEntry{
+		RunDir:     filepath.Base(runDir),
+		StartTime:  data.StartTime,
+		EndTime:    data.EndTime,
+		RTOTarget:  data.RTOTarget,
+		RTA:        data.RTA,
+		RTOPassed:  data.RTOPassed,
+		RPOTarget:  data.RPOTarget,
+		RPOPassed:  data.RPOPassed,
+		RPOActual:  data.RPOActual,
+		Errors:     len(data.Errors),
+		Incomplete: data.Status == "incomplete",
+		Simulated:  data.Simulated,
+	}
+	if data.Scenario != nil {
+		entry.Scenario = data.Scenario.Name
+		entry.Service = data.Scenario.ServiceName()
+	}
+	return entry
+}
+
+// UpdateIndex adds or replaces the entry for runDir in the reports directory index
+func UpdateIndex(reportsDir, runDir string) error {
+	data, err := ReadReport(runDir)
+	if err != nil {
+		return err
+	}
+
+	index, err := readIndex(reportsDir)
+	if err != nil {
+		return err
+	}
+
+	entry := newIndexEntry(runDir, data)
+	replaced := false
+	for i := range index.Runs {
+		if index.Runs[i].RunDir == entry.RunDir {
+			index.Runs[i] = entry
+			replaced = true
+		}
+	}
+	if !replaced {
+		index.Runs = append(index.Runs, entry)
+	}
+
+	return writeIndex(reportsDir, index)
+}
+
+// RebuildIndex regenerates the index from every report.json under reportsDir
+// and returns the number of runs indexed
+func RebuildIndex(reportsDir string) (int, error) {
+	entries, err := os.ReadDir(reportsDir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read reports directory: %w", err)
+	}
+
+	index := &Index{Runs: []IndexEntry{}}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		runDir := filepath.Join(reportsDir, e.Name())
+		data, err := ReadReport(runDir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		index.Runs = append(index.Runs, newIndexEntry(runDir, data))
+	}
+
+	if err := writeIndex(reportsDir, index); err != nil {
+		return 0, err
+	}
+	return len(index.Runs), nil
+}
+
+func readIndex(reportsDir string) (*Index, error) {
+	raw, err := os.ReadFile(filepath.Join(reportsDir, IndexFileName))
+	if os.IsNotExist(err) {
+		return &Index{Runs: []IndexEntry{}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	var index Index
+	if err := json.Unmarshal(raw, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse index (run 'drillmeasure index rebuild'): %w", err)
+	}
+	return &index, nil
+}
+
+func writeIndex(reportsDir string, index *Index) error {
+	sort.Slice(index.Runs, func(i, j int) bool {
+		return index.Runs[i].StartTime < index.Runs[j].StartTime
+	})
+
+	raw, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write atomically so readers never see a partially written index
+	path := filepath.Join(reportsDir, IndexFileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+	return nil
+}
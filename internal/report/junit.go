@@ -0,0 +1,219 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// JUnitFileName is the JUnit XML report in the output directory
+const JUnitFileName = "junit.xml"
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Errors   int          `xml:"errors,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string      `xml:"name,attr"`
+	Tests     int         `xml:"tests,attr"`
+	Failures  int         `xml:"failures,attr"`
+	Errors    int         `xml:"errors,attr"`
+	Skipped   int         `xml:"skipped,attr"`
+	Time      string      `xml:"time,attr"`
+	Timestamp string      `xml:"timestamp,attr"`
+	Cases     []junitCase `xml:"testcase"`
+	SystemErr string      `xml:"system-err,omitempty"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure"`
+	Error     *junitProblem `xml:"error"`
+	Skipped   *junitProblem `xml:"skipped"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+}
+
+// GenerateJUnitReport renders one test suite per drill run, with a test case
+// for each check the scenario configured: RTO, RPO, guards, RPO checks,
+// consistency checks, the restore check, switchback, assertions, and the
+// RTA regression against the baseline run
+func GenerateJUnitReport(results ...*runner.DrillResult) (string, error) {
+	doc := junitSuites{}
+	var total time.Duration
+	for _, result := range results {
+		suite := junitSuiteFor(result)
+		doc.Suites = append(doc.Suites, suite)
+		doc.Tests += suite.Tests
+		doc.Failures += suite.Failures
+		doc.Errors += suite.Errors
+		doc.Skipped += suite.Skipped
+		total += result.EndTime.Sub(result.StartTime)
+	}
+	if len(results) > 0 {
+		doc.Name = results[0].Scenario.Name
+	}
+	doc.Time = junitSeconds(total)
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(data) + "\n", nil
+}
+
+// junitSuiteFor builds the test suite for one run
+func junitSuiteFor(result *runner.DrillResult) junitSuite {
+	name := result.Scenario.Name
+	if result.Iterations > 1 {
+		name = fmt.Sprintf("%s (run %d of %d)", name, result.Iteration, result.Iterations)
+	}
+	suite := junitSuite{
+		Name:      name,
+		Time:      junitSeconds(result.EndTime.Sub(result.StartTime)),
+		Timestamp: result.StartTime.UTC().Format("2006-01-02T15:04:05"),
+		SystemErr: strings.Join(result.Errors, "\n"),
+	}
+	add := func(c junitCase) {
+		c.Classname = result.Scenario.Name
+		switch {
+		case c.Failure != nil:
+			suite.Failures++
+		case c.Error != nil:
+			suite.Errors++
+		case c.Skipped != nil:
+			suite.Skipped++
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	if result.BackupFreshness != nil {
+		c := junitCase{Name: "backup freshness", Time: junitSeconds(result.BackupFreshness.Duration)}
+		if result.BackupStale {
+			c.Failure = junitFailure(fmt.Sprintf("latest backup is %s old (RPO target: %s)", formatDuration(result.BackupAge), formatDuration(result.RPOTarget)))
+		}
+		add(c)
+	}
+	for _, g := range result.Guards {
+		c := junitCase{Name: "guard: " + g.Name, Time: junitCommandSeconds(g.Result)}
+		if !g.Passed {
+			c.Failure = junitFailure(g.Reason)
+		}
+		add(c)
+	}
+
+	notRun := result.BackupStale || result.GuardFailed
+	add(junitRTOCase(result, notRun))
+
+	if result.RPOTarget > 0 && !result.OnlyRPOChecks() || result.MissingRows != nil {
+		c := junitCase{Name: "RPO", Time: junitCommandSeconds(result.RPOVerify)}
+		if notRun {
+			c.Skipped = &junitProblem{Message: "drill not run"}
+		} else if !result.RPOPassed {
+			c.Failure = junitFailure(fmt.Sprintf("data loss %s (target: %s)", formatRPOActual(result), formatDuration(result.RPOTarget)))
+		}
+		add(c)
+	}
+	for _, check := range result.RPOChecks {
+		c := junitCase{Name: "RPO check: " + check.Name, Time: junitCommandSeconds(check.Verify)}
+		if !check.Passed {
+			c.Failure = junitFailure(check.Reason)
+		}
+		add(c)
+	}
+	for _, check := range result.ConsistencyChecks {
+		c := junitCase{Name: "consistency: " + check.Name, Time: junitCommandSeconds(check.Result)}
+		if !check.Passed {
+			c.Failure = junitFailure(check.Reason)
+		}
+		add(c)
+	}
+	if rc := result.RestoreCheck; rc != nil {
+		c := junitCase{Name: "restore check", Time: junitSeconds(rc.RestoreTime)}
+		if !rc.Passed {
+			c.Failure = junitFailure(rc.Reason)
+		}
+		add(c)
+	}
+	if result.Switchback != nil {
+		c := junitCase{Name: "switchback", Time: junitSeconds(result.SwitchbackTime)}
+		if !result.SwitchbackPassed {
+			message := "service not healthy after switchback"
+			if result.SwitchbackTarget > 0 {
+				message = fmt.Sprintf("switchback time %s exceeds the target of %s", formatDuration(result.SwitchbackTime), formatDuration(result.SwitchbackTarget))
+			}
+			c.Failure = junitFailure(message)
+		}
+		add(c)
+	}
+	for _, a := range result.Assertions {
+		c := junitCase{Name: "assertion: " + a.Expression, Time: junitSeconds(0)}
+		if a.Error != "" {
+			c.Error = &junitProblem{Message: a.Error, Type: "error"}
+		} else if !a.Passed {
+			c.Failure = junitFailure("expression is false")
+		}
+		add(c)
+	}
+	if result.BaselineCompared() && result.Baseline.MaxRegression != nil {
+		add(junitBaselineCase(result))
+	}
+	return suite
+}
+
+// junitRTOCase is the recovery time check, judged on failed probes instead
+// of RTA when the scenario sets max_failed_probes
+func junitRTOCase(result *runner.DrillResult, notRun bool) junitCase {
+	c := junitCase{Name: "RTO", Time: junitSeconds(result.RTA)}
+	switch {
+	case notRun:
+		c.Skipped = &junitProblem{Message: "drill not run"}
+	case result.RTOStartTime.IsZero() && !result.Incomplete:
+		c.SystemOut = "service never went down"
+	case result.RTOPassed && !result.RTOStartTime.IsZero():
+	case result.Incomplete:
+		c.Error = &junitProblem{Message: "drill did not complete; results are partial", Type: "error"}
+	case result.Aborted:
+		c.Failure = junitFailure(fmt.Sprintf("service still down after %s (abort_after)", formatDuration(result.AbortAfter)))
+	case result.MaxFailedProbes != nil:
+		c.Failure = junitFailure(fmt.Sprintf("%d failed probes (max: %d)", result.FailedProbes, *result.MaxFailedProbes))
+	default:
+		c.Failure = junitFailure(fmt.Sprintf("RTA %s exceeds the RTO target of %s", formatRTA(result), formatDuration(result.RTOTarget)))
+	}
+	return c
+}
+
+func junitFailure(message string) *junitProblem {
+	return &junitProblem{Message: message, Type: "failure"}
+}
+
+// junitCommandSeconds is how long a command ran, or zero when it did not
+func junitCommandSeconds(res *runner.CommandResult) string {
+	if res == nil {
+		return junitSeconds(0)
+	}
+	return junitSeconds(res.Duration)
+}
+
+// junitSeconds formats a duration the way JUnit consumers expect: seconds
+// with millisecond precision
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
@@ -0,0 +1,95 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// latencyBuckets are the upper bounds of the probe latency histogram; the
+// last bucket holds everything slower
+var latencyBuckets = []time.Duration{
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// latencySpanLabels names the probe latency spans in the report
+var latencySpanLabels = map[string]string{
+	runner.LatencyAll:            "All health checks",
+	runner.LatencyBeforeDowntime: "Before downtime",
+	runner.LatencyDowntime:       "During downtime",
+	runner.LatencyAfterRecovery:  "After recovery",
+}
+
+// latencyHistogram counts latencies per bucket, with one more bucket for
+// everything slower than the last bound
+func latencyHistogram(latencies []time.Duration) []int {
+	counts := make([]int, len(latencyBuckets)+1)
+	for _, l := range latencies {
+		i := 0
+		for i < len(latencyBuckets) && l > latencyBuckets[i] {
+			i++
+		}
+		counts[i]++
+	}
+	return counts
+}
+
+// latencyBucketLabel names bucket i of the histogram
+func latencyBucketLabel(i int) string {
+	if i == len(latencyBuckets) {
+		return "> " + formatDuration(latencyBuckets[i-1])
+	}
+	return "≤ " + formatDuration(latencyBuckets[i])
+}
+
+// formatProbeLatency renders health check latency percentiles per span and a
+// histogram of all checks, trimmed to the buckets that were used
+func formatProbeLatency(result *runner.DrillResult) string {
+	var b strings.Builder
+	b.WriteString("| Span | Checks | p50 | p90 | p99 | Max |\n")
+	b.WriteString("|------|--------|-----|-----|-----|-----|\n")
+	for _, s := range result.ProbeLatencyStats() {
+		b.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %s | %s |\n",
+			latencySpanLabels[s.Span], s.Probes,
+			formatDuration(s.P50), formatDuration(s.P90), formatDuration(s.P99), formatDuration(s.Max)))
+	}
+	b.WriteString("\n")
+
+	counts := latencyHistogram(result.ProbeLatencies())
+	first, last, most := -1, -1, 0
+	for i, n := range counts {
+		if n == 0 {
+			continue
+		}
+		if first < 0 {
+			first = i
+		}
+		last = i
+		most = max(most, n)
+	}
+	if first < 0 {
+		return b.String()
+	}
+	b.WriteString("| Latency | Checks | Distribution |\n")
+	b.WriteString("|---------|--------|--------------|\n")
+	for i := first; i <= last; i++ {
+		bar := ""
+		if counts[i] > 0 {
+			bar = "`" + strings.Repeat("█", max(counts[i]*timelineWidth/most, 1)) + "`"
+		}
+		b.WriteString(fmt.Sprintf("| %s | %d | %s |\n", latencyBucketLabel(i), counts[i], bar))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
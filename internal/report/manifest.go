@@ -0,0 +1,148 @@
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ManifestFileName lists every file of a run with its SHA256 hash
+const ManifestFileName = "manifest.json"
+
+// inputsDirName holds copies of the files a run was started from
+const inputsDirName = "inputs"
+
+// InputFile is a file the drill was run from, such as the scenario or a values file
+type InputFile struct {
+	Role string // scenario, values, controls, or sla
+	Path string
+}
+
+// ManifestFile records one file of the run directory
+type ManifestFile struct {
+	Path         string `json:"path"` // Relative to the run directory
+	SHA256       string `json:"sha256"`
+	Size         int64  `json:"size"`
+	Role         string `json:"role,omitempty"`          // Inputs only: scenario, values, controls, or sla
+	Source       string `json:"source,omitempty"`        // Inputs only: path the file was copied from
+	SourceSHA256 string `json:"source_sha256,omitempty"` // Inputs only, when secrets were masked in the copy
+}
+
+// SourceHash returns the SHA256 of the file an input was copied from
+func (f ManifestFile) SourceHash() string {
+	if f.SourceSHA256 != "" {
+		return f.SourceSHA256
+	}
+	return f.SHA256
+}
+
+// Manifest makes a run directory a self-contained, verifiable evidence package
+type Manifest struct {
+	CreatedAt string         `json:"created_at"`
+	Inputs    []ManifestFile `json:"inputs"`
+	Files     []ManifestFile `json:"files"`
+}
+
+// CopyInputs copies the input files into the run directory and verifies each
+// copy against its source hash. Secret values are masked in the copies; the
+// original hash is then kept as source_sha256.
+func CopyInputs(outputDir string, inputs []InputFile, secrets []string) ([]ManifestFile, error) {
+	var copied []ManifestFile
+	for _, in := range inputs {
+		raw, err := os.ReadFile(in.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s file: %w", in.Role, err)
+		}
+		sourceHash := hashBytes(raw)
+
+		content := string(raw)
+		for _, secret := range secrets {
+			content = strings.ReplaceAll(content, secret, "********")
+		}
+
+		rel := filepath.Join(inputsDirName, in.Role, filepath.Base(in.Path))
+		dest := filepath.Join(outputDir, rel)
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(dest, []byte(content), 0644); err != nil {
+			return nil, fmt.Errorf("failed to copy %s file: %w", in.Role, err)
+		}
+
+		stored, err := os.ReadFile(dest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify copied %s file: %w", in.Role, err)
+		}
+		entry := ManifestFile{
+			Path:   filepath.ToSlash(rel),
+			SHA256: hashBytes(stored),
+			Size:   int64(len(stored)),
+			Role:   in.Role,
+			Source: in.Path,
+		}
+		if content != string(raw) {
+			entry.SourceSHA256 = sourceHash
+		} else if entry.SHA256 != sourceHash {
+			return nil, fmt.Errorf("copied %s file %s does not match its source (sha256 %s, expected %s)", in.Role, dest, entry.SHA256, sourceHash)
+		}
+		copied = append(copied, entry)
+	}
+	return copied, nil
+}
+
+// WriteManifest hashes every file in the run directory and writes manifest.json
+func WriteManifest(outputDir string, inputs []ManifestFile) error {
+	manifest := Manifest{
+		CreatedAt: time.Now().Format(time.RFC3339),
+		Inputs:    inputs,
+		Files:     []ManifestFile{},
+	}
+	if manifest.Inputs == nil {
+		manifest.Inputs = []ManifestFile{}
+	}
+
+	err := filepath.WalkDir(outputDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(outputDir, path)
+		if err != nil || rel == ManifestFileName {
+			return err
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		manifest.Files = append(manifest.Files, ManifestFile{
+			Path:   filepath.ToSlash(rel),
+			SHA256: hashBytes(raw),
+			Size:   int64(len(raw)),
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to hash run files: %w", err)
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool { return manifest.Files[i].Path < manifest.Files[j].Path })
+
+	raw, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(outputDir, ManifestFileName), raw, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+func hashBytes(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,210 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// originalReportFileName keeps report.json as an older version wrote it when
+// a migration rewrites it, so the run's manifest still verifies
+const originalReportFileName = "report.original.json"
+
+// reportMigration upgrades report.json data written by an older version in
+// place and reports whether it changed anything
+type reportMigration func(report map[string]interface{}) bool
+
+// reportMigrations are applied in order to every imported report. Each one
+// must leave reports that already have the current shape unchanged.
+var reportMigrations = []reportMigration{
+	// Reports before the failed probe budget have no failed_probes; every
+	// failed attempt after disruption counted towards downtime
+	func(report map[string]interface{}) bool {
+		if _, ok := report["failed_probes"]; ok {
+			return false
+		}
+		failed := 0
+		attempts, _ := report["health_check_attempts"].([]interface{})
+		for _, a := range attempts {
+			attempt, _ := a.(map[string]interface{})
+			if code, ok := attempt["exit_code"].(float64); ok && code != 0 {
+				failed++
+			}
+		}
+		report["failed_probes"] = failed
+		return true
+	},
+	// Early versions wrote null instead of an empty list of attempts
+	func(report map[string]interface{}) bool {
+		if v, ok := report["health_check_attempts"]; ok && v != nil {
+			return false
+		}
+		report["health_check_attempts"] = []interface{}{}
+		return true
+	},
+	// Reports before schema versioning have the version 1 structure once
+	// the migrations above have run
+	func(report map[string]interface{}) bool {
+		if _, ok := report["schema_version"]; ok {
+			return false
+		}
+		report["schema_version"] = 1
+		return true
+	},
+}
+
+// MigrateReport applies every report migration to raw report.json data and
+// returns the upgraded data, or nil if it is already current
+func MigrateReport(raw []byte) ([]byte, error) {
+	var report map[string]interface{}
+	if err := json.Unmarshal(raw, &report); err != nil {
+		return nil, err
+	}
+
+	changed := false
+	for _, migrate := range reportMigrations {
+		if migrate(report) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// ImportResult counts the runs handled by ImportRuns and lists their directories
+type ImportResult struct {
+	Imported int      // Run directories added to the reports directory
+	Migrated int      // Reports upgraded from an older schema
+	Skipped  int      // Runs already present in the reports directory
+	RunDirs  []string // Every run directory in the reports directory the import covered, skipped ones included
+}
+
+// ImportRuns backfills the reports directory with every run under srcDir that
+// has a report.json, migrating reports written by older versions, and
+// rebuilds the index. With srcDir the reports directory itself, its runs are
+// migrated in place.
+func ImportRuns(srcDir, reportsDir string) (*ImportResult, error) {
+	entries, err := os.ReadDir(srcDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", srcDir, err)
+	}
+	if err := os.MkdirAll(reportsDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create reports directory: %w", err)
+	}
+	inPlace := sameDir(srcDir, reportsDir)
+
+	result := &ImportResult{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		src := filepath.Join(srcDir, e.Name())
+		if _, err := os.Stat(filepath.Join(src, "report.json")); os.IsNotExist(err) {
+			continue
+		}
+
+		runDir := src
+		if !inPlace {
+			runDir = filepath.Join(reportsDir, e.Name())
+			if _, err := os.Stat(runDir); err == nil {
+				result.Skipped++
+				result.RunDirs = append(result.RunDirs, runDir)
+				continue
+			}
+			if err := copyDir(src, runDir); err != nil {
+				return nil, fmt.Errorf("failed to copy %s: %w", src, err)
+			}
+			result.Imported++
+		}
+
+		migrated, err := migrateRunReport(runDir)
+		if err != nil {
+			return nil, err
+		}
+		if migrated {
+			result.Migrated++
+		}
+		result.RunDirs = append(result.RunDirs, runDir)
+	}
+
+	if _, err := RebuildIndex(reportsDir); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// migrateRunReport upgrades a run's report.json, keeping the original
+func migrateRunReport(runDir string) (bool, error) {
+	path := filepath.Join(runDir, "report.json")
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	upgraded, err := MigrateReport(raw)
+	if err != nil {
+		return false, fmt.Errorf("failed to migrate %s: %w", path, err)
+	}
+	if upgraded == nil || bytes.Equal(upgraded, raw) {
+		return false, nil
+	}
+
+	original := filepath.Join(runDir, originalReportFileName)
+	if _, err := os.Stat(original); os.IsNotExist(err) {
+		if err := os.WriteFile(original, raw, 0644); err != nil {
+			return false, fmt.Errorf("failed to keep original report: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, upgraded, 0644); err != nil {
+		return false, fmt.Errorf("failed to write migrated report: %w", err)
+	}
+	return true, nil
+}
+
+// sameDir reports whether two paths name the same directory
+func sameDir(a, b string) bool {
+	ai, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	bi, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(ai, bi)
+}
+
+// copyDir copies a directory tree, preserving file modes
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, raw, info.Mode().Perm())
+	})
+}
@@ -0,0 +1,158 @@
+package report
+
+import (
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// outputsDir is the output subdirectory holding compressed command output
+const outputsDir = "outputs"
+
+// outputPreviewSize is how much of a compressed output is kept inline in reports
+const outputPreviewSize = 4 * 1024
+
+// ScanFunc checks content written to name for secrets, returning it redacted
+// or an error if it must not be written
+type ScanFunc func(name, content string) (string, error)
+
+// CompressLargeOutputs writes stdout/stderr larger than threshold bytes to
+// gzip files under the output directory and keeps only a preview inline.
+// Output already truncated by TruncateOutputs is left as it is. Output passes
+// through scan before it is written, like the reports.
+// Hashes are left untouched, so they still cover the full uncompressed output.
+func CompressLargeOutputs(result *runner.DrillResult, outputDir string, threshold int, scan ScanFunc) error {
+	if threshold <= 0 {
+		return nil
+	}
+
+	for _, cmd := range result.LabeledCommands() {
+		r := cmd.Result
+		if len(r.Stdout) > threshold && r.StdoutFile == "" && r.StdoutOmitted == 0 {
+			path, stdout, err := writeCompressed(outputDir, cmd.Label+".stdout.gz", r.Stdout, scan)
+			if err != nil {
+				return err
+			}
+			r.StdoutFile = path
+			r.Stdout = stdout[:runeStart(stdout, min(len(stdout), outputPreviewSize))]
+		}
+		if len(r.Stderr) > threshold && r.StderrFile == "" && r.StderrOmitted == 0 {
+			path, stderr, err := writeCompressed(outputDir, cmd.Label+".stderr.gz", r.Stderr, scan)
+			if err != nil {
+				return err
+			}
+			r.StderrFile = path
+			r.Stderr = stderr[:runeStart(stderr, min(len(stderr), outputPreviewSize))]
+		}
+	}
+
+	return nil
+}
+
+// TruncateOutputs cuts stdout/stderr longer than the command's max_output,
+// or else the scenario's, to its first and last halves. With spool_output the
+// full output is first written compressed to the outputs directory, through
+// scan. Hashes still cover the full output.
+func TruncateOutputs(result *runner.DrillResult, outputDir string, scan ScanFunc) error {
+	for _, cmd := range result.LabeledCommands() {
+		r := cmd.Result
+		limit := r.MaxOutput
+		if limit == 0 {
+			limit = result.Scenario.MaxOutput
+		}
+		if limit == 0 {
+			continue
+		}
+		if len(r.Stdout) > limit {
+			if result.Scenario.SpoolOutput {
+				path, stdout, err := writeCompressed(outputDir, cmd.Label+".stdout.gz", r.Stdout, scan)
+				if err != nil {
+					return err
+				}
+				r.StdoutFile = path
+				r.Stdout = stdout
+			}
+			r.Stdout, r.StdoutOmitted = truncateOutput(r.Stdout, limit)
+		}
+		if len(r.Stderr) > limit {
+			if result.Scenario.SpoolOutput {
+				path, stderr, err := writeCompressed(outputDir, cmd.Label+".stderr.gz", r.Stderr, scan)
+				if err != nil {
+					return err
+				}
+				r.StderrFile = path
+				r.Stderr = stderr
+			}
+			r.Stderr, r.StderrOmitted = truncateOutput(r.Stderr, limit)
+		}
+	}
+
+	return nil
+}
+
+// truncateOutput keeps about limit bytes of s, split between its start and
+// its end since failures are usually reported last, and returns the number
+// of bytes dropped
+func truncateOutput(s string, limit int) (string, int) {
+	head, tail := limit/2, len(s)-(limit-limit/2)
+	// Cut on line boundaries where there are any, else on character boundaries
+	if i := strings.LastIndexByte(s[:head], '\n'); i >= 0 {
+		head = i + 1
+	}
+	head = runeStart(s, head)
+	if i := strings.IndexByte(s[tail:], '\n'); i >= 0 && tail+i+1 < len(s) {
+		tail += i + 1
+	}
+	for tail < len(s) && !utf8.RuneStart(s[tail]) {
+		tail++
+	}
+	omitted := tail - head
+	marker := fmt.Sprintf("[... %d bytes omitted ...]\n", omitted)
+	if head > 0 && s[head-1] != '\n' {
+		marker = "\n" + marker
+	}
+	return s[:head] + marker + s[tail:], omitted
+}
+
+// runeStart moves index i of s back to the start of the character it falls in
+func runeStart(s string, i int) int {
+	for i > 0 && i < len(s) && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return i
+}
+
+// writeCompressed scans content and gzips it into the outputs directory,
+// returning its relative path and the content as written
+func writeCompressed(outputDir, name, content string, scan ScanFunc) (string, string, error) {
+	relPath := filepath.Join(outputsDir, name)
+	content, err := scan(relPath, content)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := os.MkdirAll(filepath.Join(outputDir, outputsDir), 0755); err != nil {
+		return "", "", fmt.Errorf("failed to create outputs directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, relPath))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create %s: %w", relPath, err)
+	}
+	defer f.Close()
+
+	zw := gzip.NewWriter(f)
+	if _, err := zw.Write([]byte(content)); err != nil {
+		return "", "", fmt.Errorf("failed to write %s: %w", relPath, err)
+	}
+	if err := zw.Close(); err != nil {
+		return "", "", fmt.Errorf("failed to write %s: %w", relPath, err)
+	}
+
+	return relPath, content, nil
+}
@@ -0,0 +1,217 @@
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// worstRunsShown is how many of the slowest recoveries a period report lists
+const worstRunsShown = 5
+
+// PeriodReport aggregates the stored runs started within a date range
+type PeriodReport struct {
+	From       time.Time
+	To         time.Time
+	Runs       int
+	Passed     int
+	Services   []ServiceSummary
+	WorstRuns  []IndexEntry
+	Unresolved []UnresolvedFailure
+}
+
+// ServiceSummary is the drill coverage of one scenario within a period
+type ServiceSummary struct {
+	Scenario string
+	Runs     int
+	Passed   int
+	LastRun  string
+	WorstRTA time.Duration
+}
+
+// UnresolvedFailure is a scenario whose most recent run in the period failed
+type UnresolvedFailure struct {
+	Scenario    string
+	FailingRuns int    // Consecutive failed runs up to the latest
+	Since       string // Start time of the first failed run of the streak
+	LatestRun   string
+	Reasons     []string
+}
+
+// entryPassed reports whether a run met its RTO and, if set, its RPO target
+func entryPassed(e IndexEntry) bool {
+	return e.RTOPassed && (e.RPOTarget == "" || e.RPOPassed)
+}
+
+// entryRTA parses the formatted RTA of an index entry, treating unparsable values as zero
+func entryRTA(e IndexEntry) time.Duration {
+	d, _ := time.ParseDuration(e.RTA)
+	return d
+}
+
+// BuildPeriodReport aggregates runs from the reports directory index whose
+// start time falls within [from, to)
+func BuildPeriodReport(reportsDir string, from, to time.Time) (*PeriodReport, error) {
+	index, err := readIndex(reportsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &PeriodReport{From: from, To: to}
+	byScenario := map[string][]IndexEntry{}
+	var runs []IndexEntry
+	for _, e := range index.Runs {
+		start, err := time.Parse(time.RFC3339, e.StartTime)
+		if err != nil || e.Simulated || start.Before(from) || !start.Before(to) {
+			continue
+		}
+		runs = append(runs, e)
+		byScenario[e.Scenario] = append(byScenario[e.Scenario], e)
+	}
+
+	p.Runs = len(runs)
+	for _, e := range runs {
+		if entryPassed(e) {
+			p.Passed++
+		}
+	}
+
+	for scenario, entries := range byScenario {
+		s := ServiceSummary{Scenario: scenario, Runs: len(entries)}
+		for _, e := range entries {
+			if entryPassed(e) {
+				s.Passed++
+			}
+			if rta := entryRTA(e); rta > s.WorstRTA {
+				s.WorstRTA = rta
+			}
+		}
+		// Index entries are kept sorted by start time
+		latest := entries[len(entries)-1]
+		s.LastRun = latest.StartTime
+		p.Services = append(p.Services, s)
+
+		if !entryPassed(latest) {
+			p.Unresolved = append(p.Unresolved, unresolvedFailure(reportsDir, entries))
+		}
+	}
+	sort.Slice(p.Services, func(i, j int) bool { return p.Services[i].Scenario < p.Services[j].Scenario })
+	sort.Slice(p.Unresolved, func(i, j int) bool { return p.Unresolved[i].Since < p.Unresolved[j].Since })
+
+	for _, e := range runs {
+		if entryRTA(e) > 0 {
+			p.WorstRuns = append(p.WorstRuns, e)
+		}
+	}
+	sort.SliceStable(p.WorstRuns, func(i, j int) bool { return entryRTA(p.WorstRuns[i]) > entryRTA(p.WorstRuns[j]) })
+	if len(p.WorstRuns) > worstRunsShown {
+		p.WorstRuns = p.WorstRuns[:worstRunsShown]
+	}
+
+	return p, nil
+}
+
+// unresolvedFailure describes the failing streak at the end of a scenario's runs
+func unresolvedFailure(reportsDir string, entries []IndexEntry) UnresolvedFailure {
+	latest := entries[len(entries)-1]
+	u := UnresolvedFailure{Scenario: latest.Scenario, LatestRun: latest.RunDir}
+	for i := len(entries) - 1; i >= 0 && !entryPassed(entries[i]); i-- {
+		u.FailingRuns++
+		u.Since = entries[i].StartTime
+	}
+
+	if !latest.RTOPassed {
+		u.Reasons = append(u.Reasons, fmt.Sprintf("RTO not met (RTA %s, target %s)", latest.RTA, latest.RTOTarget))
+	}
+	if latest.RPOTarget != "" && !latest.RPOPassed {
+		u.Reasons = append(u.Reasons, "RPO verification failed")
+	}
+	if data, err := ReadReport(filepath.Join(reportsDir, latest.RunDir)); err == nil {
+		u.Reasons = append(u.Reasons, data.Errors...)
+	}
+	return u
+}
+
+// GeneratePeriodMarkdownReport renders a period report as a Markdown document
+func GeneratePeriodMarkdownReport(p *PeriodReport) string {
+	var b strings.Builder
+
+	lastDay := p.To.AddDate(0, 0, -1)
+	b.WriteString("# DR Drill Compliance Report\n\n")
+	b.WriteString(fmt.Sprintf("**Period:** %s to %s\n\n", p.From.Format("2006-01-02"), lastDay.Format("2006-01-02")))
+	b.WriteString(fmt.Sprintf("**Generated:** %s\n\n", time.Now().Format(time.RFC3339)))
+
+	// Summary
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| Metric | Value |\n")
+	b.WriteString("|--------|-------|\n")
+	b.WriteString(fmt.Sprintf("| Drills run | %d |\n", p.Runs))
+	b.WriteString(fmt.Sprintf("| Services covered | %d |\n", len(p.Services)))
+	b.WriteString(fmt.Sprintf("| Pass rate | %s |\n", formatPassRate(p.Passed, p.Runs)))
+	b.WriteString(fmt.Sprintf("| Unresolved failures | %d |\n", len(p.Unresolved)))
+	b.WriteString("\n")
+
+	if p.Runs == 0 {
+		b.WriteString("No drills were run in this period.\n\n")
+		return b.String()
+	}
+
+	// Coverage
+	b.WriteString("## Coverage per Service\n\n")
+	b.WriteString("| Service | Drills | Passed | Pass Rate | Worst RTA | Last Drill |\n")
+	b.WriteString("|---------|--------|--------|-----------|-----------|------------|\n")
+	for _, s := range p.Services {
+		worst := "N/A (no downtime)"
+		if s.WorstRTA > 0 {
+			worst = formatDuration(s.WorstRTA)
+		}
+		b.WriteString(fmt.Sprintf("| %s | %d | %d | %s | %s | %s |\n",
+			s.Scenario, s.Runs, s.Passed, formatPassRate(s.Passed, s.Runs), worst, s.LastRun))
+	}
+	b.WriteString("\n")
+
+	// Worst RTAs
+	if len(p.WorstRuns) > 0 {
+		b.WriteString("## Worst Recovery Times\n\n")
+		b.WriteString("| Service | Started | RTA | RTO Target | Status | Run |\n")
+		b.WriteString("|---------|---------|-----|------------|--------|-----|\n")
+		for _, e := range p.WorstRuns {
+			status := "❌ FAIL"
+			if e.RTOPassed {
+				status = "✅ PASS"
+			}
+			b.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | `%s` |\n",
+				e.Scenario, e.StartTime, e.RTA, e.RTOTarget, status, e.RunDir))
+		}
+		b.WriteString("\n")
+	}
+
+	// Unresolved failures
+	b.WriteString("## Unresolved Failures\n\n")
+	if len(p.Unresolved) == 0 {
+		b.WriteString("✅ Every service's most recent drill in this period passed.\n\n")
+	}
+	for _, u := range p.Unresolved {
+		b.WriteString(fmt.Sprintf("### %s\n\n", u.Scenario))
+		b.WriteString(fmt.Sprintf("- **Failing since:** %s (%d consecutive failed drill(s))\n", u.Since, u.FailingRuns))
+		b.WriteString(fmt.Sprintf("- **Latest run:** `%s`\n", u.LatestRun))
+		for _, reason := range u.Reasons {
+			b.WriteString(fmt.Sprintf("- %s\n", reason))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("Each run referenced above has a full Markdown and JSON report with command output and SHA256 hashes ")
+	b.WriteString("in its run directory.\n")
+
+	return b.String()
+}
+
+func formatPassRate(passed, total int) string {
+	if total == 0 {
+		return "N/A"
+	}
+	return fmt.Sprintf("%.0f%% (%d/%d)", float64(passed)/float64(total)*100, passed, total)
+}
@@ -0,0 +1,71 @@
+package report
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// PromFileName is the Prometheus text format metrics file in the output directory
+const PromFileName = "metrics.prom"
+
+// promLabelEscaper escapes label values in the Prometheus text format
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// GeneratePromMetrics renders the drill outcome in the Prometheus text
+// format, as read by node_exporter's textfile collector. Every metric is
+// labeled with the scenario name.
+func GeneratePromMetrics(result *runner.DrillResult) string {
+	labels := fmt.Sprintf(`{scenario="%s"}`, promLabelEscaper.Replace(result.Scenario.Name))
+
+	var b strings.Builder
+	metric := func(name, kind, help string, value float64) {
+		b.WriteString(fmt.Sprintf("# HELP drillmeasure_%s %s\n", name, help))
+		b.WriteString(fmt.Sprintf("# TYPE drillmeasure_%s %s\n", name, kind))
+		b.WriteString(fmt.Sprintf("drillmeasure_%s%s %s\n", name, labels, strconv.FormatFloat(value, 'f', -1, 64)))
+	}
+
+	metric("rta_seconds", "gauge", "Measured downtime (Recovery Time Actual) of the last drill", result.RTA.Seconds())
+	metric("rto_target_seconds", "gauge", "Recovery Time Objective of the scenario", result.RTOTarget.Seconds())
+	metric("rto_passed", "gauge", "Whether the last drill met the RTO target (1) or not (0)", promBool(result.RTOPassed))
+	if passed, ok := rpoOutcome(result); ok {
+		metric("rpo_passed", "gauge", "Whether the last drill met the RPO target (1) or not (0)", promBool(passed))
+	}
+	if result.RPOMeasured() {
+		metric("rpo_actual_seconds", "gauge", "Measured data loss of the last drill", result.RPOActual.Seconds())
+	}
+	failed := 0
+	for _, attempt := range result.HealthCheckAttempts {
+		if !attempt.Up() {
+			failed++
+		}
+	}
+	metric("attempts_total", "counter", "Health checks run during the last drill", float64(len(result.HealthCheckAttempts)))
+	metric("failed_attempts_total", "counter", "Health checks that failed during the last drill", float64(failed))
+	metric("last_run_timestamp_seconds", "gauge", "When the last drill finished, in seconds since the Unix epoch", float64(result.EndTime.UnixNano())/float64(time.Second))
+	return b.String()
+}
+
+// rpoOutcome reports whether the drill met its RPO target and every RPO
+// check, and whether the scenario checked data loss at all
+func rpoOutcome(result *runner.DrillResult) (bool, bool) {
+	scenarioWide := result.RPOTarget > 0 && !result.OnlyRPOChecks() || result.MissingRows != nil
+	if !scenarioWide && len(result.RPOChecks) == 0 {
+		return false, false
+	}
+	passed := result.RPOChecksPassed()
+	if scenarioWide {
+		passed = passed && result.RPOPassed
+	}
+	return passed, true
+}
+
+func promBool(v bool) float64 {
+	if v {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,67 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// ResultFileName holds the raw result the run's reports were generated from
+const ResultFileName = "result.json"
+
+// rawResult is result.json: the drill result with the scenario fields that
+// are left out of its JSON encoding
+type rawResult struct {
+	Result    *runner.DrillResult    `json:"result"`
+	Variables map[string]string      `json:"variables,omitempty"` // Resolved, secrets masked
+	Controls  *config.ControlMapping `json:"controls,omitempty"`
+}
+
+// GenerateRawResult encodes the result for result.json, masking the
+// scenario's secret values
+func GenerateRawResult(result *runner.DrillResult) (string, error) {
+	raw := rawResult{Result: result}
+	if result.Scenario != nil {
+		if len(result.Scenario.Variables) > 0 {
+			raw.Variables = result.Scenario.MaskedVariables()
+		}
+		raw.Controls = result.Scenario.Controls
+	}
+
+	encoded, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	content := string(encoded)
+	if result.Scenario != nil {
+		for _, secret := range result.Scenario.SecretValues() {
+			content = strings.ReplaceAll(content, secret, "********")
+		}
+	}
+	return content, nil
+}
+
+// ReadRawResult loads the result a run's reports were generated from
+func ReadRawResult(runDir string) (*runner.DrillResult, error) {
+	path := filepath.Join(runDir, ResultFileName)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw rawResult
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if raw.Result == nil || raw.Result.Scenario == nil {
+		return nil, fmt.Errorf("%s has no drill result", path)
+	}
+	raw.Result.Scenario.Variables = raw.Variables
+	raw.Result.Scenario.Controls = raw.Controls
+	return raw.Result, nil
+}
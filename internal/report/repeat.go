@@ -0,0 +1,211 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// RepeatRun is one drill of a repeated series
+type RepeatRun struct {
+	RunDir string
+	Result *runner.DrillResult
+	Passed bool // Every target was met
+}
+
+// measured reports whether the run got far enough to measure RTA
+func (r RepeatRun) measured() bool {
+	res := r.Result
+	return !res.Incomplete && !res.GuardFailed && !res.BackupStale
+}
+
+// RepeatSummary aggregates the RTA of a repeated series of drills
+type RepeatSummary struct {
+	Scenario   string
+	Iterations int // Runs requested
+	CoolDown   time.Duration
+	RTOTarget  time.Duration
+	Runs       []RepeatRun
+	Measured   int // Runs that measured RTA; the statistics cover these
+	Passed     int
+	MinRTA     time.Duration
+	MedianRTA  time.Duration
+	P95RTA     time.Duration
+	MaxRTA     time.Duration
+}
+
+// SummarizeRepeat computes RTA statistics over the runs of a repeated series.
+// A run whose disruption caused no downtime counts as an RTA of zero.
+func SummarizeRepeat(scenario string, iterations int, coolDown time.Duration, runs []RepeatRun) *RepeatSummary {
+	s := &RepeatSummary{Scenario: scenario, Iterations: iterations, CoolDown: coolDown, Runs: runs}
+
+	var rtas []time.Duration
+	for _, run := range runs {
+		if run.Passed {
+			s.Passed++
+		}
+		if run.Result.RTOTarget > 0 {
+			s.RTOTarget = run.Result.RTOTarget
+		}
+		if run.measured() {
+			rtas = append(rtas, run.Result.RTA)
+		}
+	}
+	s.Measured = len(rtas)
+	if len(rtas) == 0 {
+		return s
+	}
+
+	sort.Slice(rtas, func(i, j int) bool { return rtas[i] < rtas[j] })
+	s.MinRTA = rtas[0]
+	s.MaxRTA = rtas[len(rtas)-1]
+	if n := len(rtas); n%2 == 1 {
+		s.MedianRTA = rtas[n/2]
+	} else {
+		s.MedianRTA = (rtas[n/2-1] + rtas[n/2]) / 2
+	}
+	// Nearest-rank percentile
+	rank := int(math.Ceil(0.95 * float64(len(rtas))))
+	s.P95RTA = rtas[rank-1]
+	return s
+}
+
+// GenerateRepeatMarkdownReport renders the summary of a repeated series
+func GenerateRepeatMarkdownReport(s *RepeatSummary) string {
+	var b strings.Builder
+
+	b.WriteString("# Repeated Drill Summary\n\n")
+	b.WriteString(fmt.Sprintf("**Scenario:** %s\n\n", s.Scenario))
+	b.WriteString(fmt.Sprintf("**Runs:** %d of %d (cool-down: %s)\n\n", len(s.Runs), s.Iterations, formatDuration(s.CoolDown)))
+	if len(s.Runs) < s.Iterations {
+		b.WriteString("> ⚠️ **Stopped Early:** The series stopped before every run completed; statistics cover the runs below.\n\n")
+	}
+
+	// Statistics
+	b.WriteString("## RTA Statistics\n\n")
+	if s.Measured == 0 {
+		b.WriteString("No run measured RTA.\n\n")
+	} else {
+		b.WriteString("| Statistic | RTA |\n")
+		b.WriteString("|-----------|-----|\n")
+		b.WriteString(fmt.Sprintf("| Min | %s |\n", formatDuration(s.MinRTA)))
+		b.WriteString(fmt.Sprintf("| Median | %s |\n", formatDuration(s.MedianRTA)))
+		b.WriteString(fmt.Sprintf("| p95 | %s |\n", formatDuration(s.P95RTA)))
+		b.WriteString(fmt.Sprintf("| Max | %s |\n", formatDuration(s.MaxRTA)))
+		if s.RTOTarget > 0 {
+			b.WriteString(fmt.Sprintf("| RTO (target) | %s |\n", formatDuration(s.RTOTarget)))
+		}
+		b.WriteString("\n")
+		b.WriteString(fmt.Sprintf("Computed over %d run(s) that measured RTA; runs without downtime count as 0ms.\n\n", s.Measured))
+	}
+	b.WriteString(fmt.Sprintf("**Pass rate:** %s\n\n", formatPassRate(s.Passed, len(s.Runs))))
+
+	// Runs
+	b.WriteString("## Runs\n\n")
+	b.WriteString("| # | Started | RTA | Status | Run |\n")
+	b.WriteString("|---|---------|-----|--------|-----|\n")
+	for i, run := range s.Runs {
+		b.WriteString(fmt.Sprintf("| %d | %s | %s | %s | `%s` |\n",
+			i+1, run.Result.StartTime.Format(time.RFC3339), formatRepeatRTA(run), formatRepeatStatus(run), run.RunDir))
+	}
+	b.WriteString("\n")
+
+	b.WriteString("Each run has a full Markdown and JSON report with command output and SHA256 hashes in its run directory.\n")
+
+	return b.String()
+}
+
+func formatRepeatRTA(run RepeatRun) string {
+	if !run.measured() {
+		return "N/A"
+	}
+	if run.Result.RTOStartTime.IsZero() {
+		return "0ms (no downtime)"
+	}
+	return formatDuration(run.Result.RTA)
+}
+
+func formatRepeatStatus(run RepeatRun) string {
+	switch {
+	case run.Result.Incomplete:
+		return "⚠️ INCOMPLETE"
+	case run.Passed:
+		return "✅ PASS"
+	default:
+		return "❌ FAIL"
+	}
+}
+
+// RepeatSummaryData represents a repeated series in JSON
+type RepeatSummaryData struct {
+	Scenario   string          `json:"scenario"`
+	Iterations int             `json:"iterations"`
+	Completed  int             `json:"completed"` // Runs that were started; fewer than iterations if the series stopped early
+	CoolDown   string          `json:"cool_down"`
+	RTOTarget  string          `json:"rto_target,omitempty"`
+	Measured   int             `json:"measured"` // Runs the statistics cover
+	Passed     int             `json:"passed"`
+	MinRTA     string          `json:"rta_min,omitempty"`
+	MedianRTA  string          `json:"rta_median,omitempty"`
+	P95RTA     string          `json:"rta_p95,omitempty"`
+	MaxRTA     string          `json:"rta_max,omitempty"`
+	Runs       []RepeatRunData `json:"runs"`
+}
+
+// RepeatRunData represents one run of a repeated series in JSON
+type RepeatRunData struct {
+	Iteration  int    `json:"iteration"`
+	RunDir     string `json:"run_dir"`
+	StartTime  string `json:"start_time"`
+	RTA        string `json:"rta,omitempty"` // Omitted when the run did not measure RTA
+	Downtime   bool   `json:"downtime"`
+	Passed     bool   `json:"passed"`
+	Incomplete bool   `json:"incomplete,omitempty"`
+}
+
+// GenerateRepeatJSONReport renders the summary of a repeated series as JSON
+func GenerateRepeatJSONReport(s *RepeatSummary) (string, error) {
+	data := RepeatSummaryData{
+		Scenario:   s.Scenario,
+		Iterations: s.Iterations,
+		Completed:  len(s.Runs),
+		CoolDown:   formatDuration(s.CoolDown),
+		Measured:   s.Measured,
+		Passed:     s.Passed,
+		Runs:       []RepeatRunData{},
+	}
+	if s.RTOTarget > 0 {
+		data.RTOTarget = formatDuration(s.RTOTarget)
+	}
+	if s.Measured > 0 {
+		data.MinRTA = formatDuration(s.MinRTA)
+		data.MedianRTA = formatDuration(s.MedianRTA)
+		data.P95RTA = formatDuration(s.P95RTA)
+		data.MaxRTA = formatDuration(s.MaxRTA)
+	}
+	for i, run := range s.Runs {
+		rd := RepeatRunData{
+			Iteration:  i + 1,
+			RunDir:     run.RunDir,
+			StartTime:  run.Result.StartTime.Format(time.RFC3339),
+			Downtime:   !run.Result.RTOStartTime.IsZero(),
+			Passed:     run.Passed,
+			Incomplete: run.Result.Incomplete,
+		}
+		if run.measured() {
+			rd.RTA = formatDuration(run.Result.RTA)
+		}
+		data.Runs = append(data.Runs, rd)
+	}
+
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
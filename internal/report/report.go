@@ -20,13 +20,77 @@ func GenerateMarkdownReport(result *runner.DrillResult) string {
 		b.WriteString(fmt.Sprintf("**Description:** %s\n\n", result.Scenario.Description))
 	}
 	b.WriteString(fmt.Sprintf("**Execution Time:** %s\n\n", result.StartTime.Format(time.RFC3339)))
+	if result.Iterations > 1 {
+		b.WriteString(fmt.Sprintf("**Iteration:** %d of %d\n\n", result.Iteration, result.Iterations))
+	}
+	if result.Simulated {
+		b.WriteString("> 🧪 **Simulated:** Run with --simulate. No commands were executed; their outcomes come from the scenario's simulate block, so this is not evidence of a drill.\n\n")
+	}
+	if result.Interrupted {
+		b.WriteString("> ⚠️ **Interrupted:** The drill was stopped before completion. Results below are partial; see Errors for where it stopped and whether recovery ran.\n\n")
+	} else if result.Incomplete {
+		b.WriteString("> ⚠️ **Incomplete:** The drill stopped with an error before completion. Results below are partial; see Errors for the cause.\n\n")
+	}
+	if result.Aborted {
+		b.WriteString(fmt.Sprintf("> ❌ **Aborted:** The service was still down %s after it went down (abort_after), so measurement stopped and recovery was forced. RTA below is a lower bound.\n\n", formatDuration(result.AbortAfter)))
+	}
+	if result.WindowOverride {
+		b.WriteString(fmt.Sprintf("> ⚠️ **Maintenance Window Override:** Run with --force outside the allowed windows (%s).\n\n", result.Scenario.DescribeAllowedWindows()))
+	}
+	if !result.ResumedAt.IsZero() {
+		b.WriteString(fmt.Sprintf("**Resumed:** %s (from %s; time the controller was down counts towards RTA)\n\n", result.ResumedAt.Format(time.RFC3339), result.ResumedFrom))
+	}
+	if accounts := result.Scenario.CloudAccounts(); len(accounts) > 0 {
+		b.WriteString(fmt.Sprintf("**Cloud Accounts:** %s\n\n", strings.Join(accounts, ", ")))
+	}
+	if result.Scenario.TargetSource != nil {
+		b.WriteString(fmt.Sprintf("**Targets From:** %s\n\n", result.Scenario.TargetSource))
+	}
+
+	// Resolved variables (secrets masked)
+	if len(result.Scenario.Variables) > 0 {
+		masked := result.Scenario.MaskedVariables()
+		b.WriteString("## Resolved Variables\n\n")
+		b.WriteString("| Variable | Value |\n")
+		b.WriteString("|----------|-------|\n")
+		for _, name := range result.Scenario.VariableNames() {
+			b.WriteString(fmt.Sprintf("| %s | `%s` |\n", name, masked[name]))
+		}
+		b.WriteString("\n")
+	}
 
 	// Summary
 	b.WriteString("## Summary\n\n")
 	b.WriteString("| Metric | Target (RTO) | Actual (RTA) | Status |\n")
 	b.WriteString("|--------|--------------|--------------|--------|\n")
 
-	if result.RTOStartTime.IsZero() {
+	if result.BackupFreshness != nil {
+		backupStatus := "✅ PASS"
+		if result.BackupStale {
+			backupStatus = "❌ FAIL"
+		}
+		b.WriteString(fmt.Sprintf("| Backup Age | %s | %s | %s |\n",
+			formatDuration(result.RPOTarget),
+			formatBackupAge(result),
+			backupStatus))
+	}
+
+	if len(result.Guards) > 0 {
+		guardStatus := "✅ PASS"
+		if result.GuardFailed {
+			guardStatus = "❌ FAIL"
+		}
+		b.WriteString(fmt.Sprintf("| Guards | all pass | %d/%d passed | %s |\n",
+			passedGuards(result), len(result.Guards), guardStatus))
+	}
+
+	if result.BackupStale || result.GuardFailed {
+		b.WriteString(fmt.Sprintf("| Recovery Time | %s | N/A (drill not run) | - |\n",
+			formatDuration(result.RTOTarget)))
+	} else if result.RTOStartTime.IsZero() && result.Incomplete {
+		b.WriteString(fmt.Sprintf("| Recovery Time | %s | N/A (incomplete) | ⚠️ INCOMPLETE |\n",
+			formatDuration(result.RTOTarget)))
+	} else if result.RTOStartTime.IsZero() {
 		// Service never went down
 		b.WriteString(fmt.Sprintf("| Recovery Time | %s | N/A (no downtime) | ✅ PASS |\n",
 			formatDuration(result.RTOTarget)))
@@ -34,100 +98,278 @@ func GenerateMarkdownReport(result *runner.DrillResult) string {
 		rtoStatus := "❌ FAIL"
 		if result.RTOPassed {
 			rtoStatus = "✅ PASS"
+		} else if result.Incomplete {
+			rtoStatus = "⚠️ INCOMPLETE"
+		} else if result.Aborted {
+			rtoStatus = "❌ ABORTED"
 		}
-		b.WriteString(fmt.Sprintf("| Recovery Time | %s | %s | %s |\n",
-			formatDuration(result.RTOTarget),
-			formatDuration(result.RTA),
-			rtoStatus))
+		if result.MaxFailedProbes != nil {
+			b.WriteString(fmt.Sprintf("| Recovery Time | - | %s | - |\n", formatRTA(result)))
+			b.WriteString(fmt.Sprintf("| Failed Probes | ≤ %d | %d | %s |\n",
+				*result.MaxFailedProbes, result.FailedProbes, rtoStatus))
+		} else {
+			b.WriteString(fmt.Sprintf("| Recovery Time | %s | %s | %s |\n",
+				formatDuration(result.RTOTarget),
+				formatRTA(result),
+				rtoStatus))
+		}
+	}
+	if result.BaselineCompared() {
+		b.WriteString(baselineSummaryRow(result))
 	}
 
-	if result.RPOTarget > 0 {
+	if result.RPOTarget > 0 && !result.OnlyRPOChecks() {
 		rpoStatus := "❌ FAIL"
 		if result.RPOPassed {
 			rpoStatus = "✅ PASS"
 		}
-		b.WriteString(fmt.Sprintf("| RPO | %s | N/A | %s |\n",
+		b.WriteString(fmt.Sprintf("| RPO | %s | %s | %s |\n",
 			formatDuration(result.RPOTarget),
+			formatRPOActual(result),
 			rpoStatus))
+	} else if result.MissingRows != nil {
+		rowsStatus := "❌ FAIL"
+		if result.RPOPassed {
+			rowsStatus = "✅ PASS"
+		}
+		b.WriteString(fmt.Sprintf("| Missing Rows | <= %d | %d | %s |\n", result.MaxMissingRows, *result.MissingRows, rowsStatus))
+	} else if result.RPOMeasured() {
+		b.WriteString(fmt.Sprintf("| Data Loss | - | %s | - |\n", formatRPOActual(result)))
+	}
+
+	if rc := result.RestoreCheck; rc != nil {
+		restoreStatus := "✅ PASS"
+		if !rc.Passed {
+			restoreStatus = "❌ FAIL"
+		}
+		target := "-"
+		if rc.Target > 0 {
+			target = formatDuration(rc.Target)
+		}
+		b.WriteString(fmt.Sprintf("| Restore Time | %s | %s | %s |\n", target, formatDuration(rc.RestoreTime), restoreStatus))
+	}
+
+	if len(result.ConsistencyChecks) > 0 {
+		consistencyStatus := "✅ PASS"
+		if !result.ConsistencyPassed() {
+			consistencyStatus = "❌ FAIL"
+		}
+		b.WriteString(fmt.Sprintf("| Consistency Checks | all pass | %d/%d passed | %s |\n",
+			passedConsistencyChecks(result), len(result.ConsistencyChecks), consistencyStatus))
+	}
+
+	if len(result.Assertions) > 0 {
+		assertionsStatus := "✅ PASS"
+		if !result.AssertionsPassed() {
+			assertionsStatus = "❌ FAIL"
+		}
+		b.WriteString(fmt.Sprintf("| Assertions | all hold | %d/%d passed | %s |\n",
+			passedAssertions(result), len(result.Assertions), assertionsStatus))
+	}
+
+	if len(result.RPOChecks) > 0 {
+		checksStatus := "✅ PASS"
+		if !result.RPOChecksPassed() {
+			checksStatus = "❌ FAIL"
+		}
+		b.WriteString(fmt.Sprintf("| RPO Checks | all pass | %d/%d passed | %s |\n",
+			passedRPOChecks(result), len(result.RPOChecks), checksStatus))
+	}
+
+	if budget, ok := result.ErrorBudget(); ok {
+		b.WriteString(fmt.Sprintf("| Error Minutes (est.) | - | %s (%.1f%% of probes failed) | - |\n",
+			formatErrorMinutes(budget.ErrorMinutes()), budget.FailedPercent()))
+	}
+
+	if result.Scenario.Degraded != nil {
+		checks, durations := result.HealthStateTimes()
+		b.WriteString(fmt.Sprintf("| Time Degraded (health checks) | - | %s (%d checks) | %s |\n",
+			formatDuration(durations[runner.StateDegraded]), checks[runner.StateDegraded], formatDegradedStatus(checks)))
+	}
+
+	if !result.DegradedStartTime.IsZero() {
+		b.WriteString(fmt.Sprintf("| Time Degraded | p%g <= %s | %s (p%g %s) | ⚠️ DEGRADED |\n",
+			result.Scenario.LatencyBudget.Percentile,
+			result.Scenario.LatencyBudget.Max,
+			formatDuration(result.DegradedDuration),
+			result.Scenario.LatencyBudget.Percentile,
+			formatDuration(result.LatencyPercentile)))
+	}
+
+	if result.Switchback != nil {
+		b.WriteString(fmt.Sprintf("| Switchback Time | %s | %s | %s |\n",
+			formatSwitchbackTarget(result),
+			formatDuration(result.SwitchbackTime),
+			formatSwitchbackStatus(result)))
 	}
 
 	b.WriteString("\n")
 
-	// Timeline
-	b.WriteString("## Timeline\n\n")
-	b.WriteString("| Event | Timestamp | Duration |\n")
-	b.WriteString("|-------|-----------|----------|\n")
-	b.WriteString(fmt.Sprintf("| Start | %s | - |\n", result.StartTime.Format(time.RFC3339)))
+	// Recovery breakdown
+	if breakdown := formatBreakdown(result); breakdown != "" {
+		b.WriteString("## Recovery Breakdown\n\n")
+		b.WriteString(breakdown)
+	}
 
-	if result.PreSnapshot != nil {
-		b.WriteString(fmt.Sprintf("| Pre-snapshot | %s | %s |\n",
-			result.PreSnapshot.Timestamp.Format(time.RFC3339),
-			formatDuration(result.PreSnapshot.Duration)))
+	// Client-observed errors
+	if budget, ok := result.ErrorBudget(); ok {
+		b.WriteString("## Error Budget\n\n")
+		b.WriteString("Each health check stands in for a user request during the outage window.\n\n")
+		b.WriteString("| Measure | Value |\n")
+		b.WriteString("|---------|-------|\n")
+		b.WriteString(fmt.Sprintf("| Outage window (disruption issued to confirmed recovery) | %s |\n", formatDuration(budget.Window)))
+		b.WriteString(fmt.Sprintf("| Requests (health checks) in window | %d |\n", budget.Probes))
+		b.WriteString(fmt.Sprintf("| Failed requests | %d (%.1f%%) |\n", budget.Failed, budget.FailedPercent()))
+		b.WriteString(fmt.Sprintf("| **Estimated user-facing error minutes** | **%s** |\n\n", formatErrorMinutes(budget.ErrorMinutes())))
 	}
 
-	if result.Disrupt != nil {
-		b.WriteString(fmt.Sprintf("| Disruption | %s | %s |\n",
-			result.Disrupt.Timestamp.Format(time.RFC3339),
-			formatDuration(result.Disrupt.Duration)))
+	// Guards
+	if len(result.Guards) > 0 {
+		b.WriteString("## Guards\n\n")
+		b.WriteString(formatGuardTable("Guard", result.Guards))
+	}
+
+	// Per-data-store RPO checks
+	if len(result.RPOChecks) > 0 {
+		b.WriteString("## RPO Checks\n\n")
+		b.WriteString("| Data Store | Target | Data Loss | Status |\n")
+		b.WriteString("|------------|--------|-----------|--------|\n")
+		for i := range result.RPOChecks {
+			c := &result.RPOChecks[i]
+			target := "-"
+			if c.MissingRows != nil {
+				target = fmt.Sprintf("<= %d rows", c.MaxMissingRows)
+			} else if c.Target > 0 {
+				target = formatDuration(c.Target)
+			}
+			status := "✅ PASS"
+			if !c.Passed {
+				status = "❌ FAIL: " + c.Reason
+			}
+			b.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", c.Name, target, formatRPOCheckLoss(c), status))
+		}
+		b.WriteString("\n")
 	}
 
-	if result.PostDisruptDelay > 0 {
-		delayStart := result.Disrupt.Timestamp.Add(result.Disrupt.Duration)
-		b.WriteString(fmt.Sprintf("| Post-disrupt delay | %s | %s |\n",
-			delayStart.Format(time.RFC3339),
-			formatDuration(result.PostDisruptDelay)))
+	// Consistency checks
+	if len(result.ConsistencyChecks) > 0 {
+		b.WriteString("## Consistency Checks\n\n")
+		b.WriteString("| Check | Expected | Actual | Status |\n")
+		b.WriteString("|-------|----------|--------|--------|\n")
+		for _, c := range result.ConsistencyChecks {
+			expected := "exit code 0"
+			switch {
+			case c.Match != "":
+				expected = fmt.Sprintf("matches %s: `%s`", c.Match, shortValue(c.Expected))
+			case c.Condition != "":
+				expected = "`" + c.Condition + "`"
+			}
+			actual := "-"
+			if c.Value != "" {
+				actual = "`" + shortValue(c.Value) + "`"
+			} else if c.Result != nil {
+				actual = fmt.Sprintf("exit code %d", c.Result.ExitCode)
+			}
+			status := "✅ PASS"
+			if !c.Passed {
+				status = "❌ FAIL: " + c.Reason
+			}
+			b.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", c.Name, expected, actual, status))
+		}
+		b.WriteString("\n")
 	}
 
-	if result.Recover != nil {
-		b.WriteString(fmt.Sprintf("| Recovery | %s | %s |\n",
-			result.Recover.Timestamp.Format(time.RFC3339),
-			formatDuration(result.Recover.Duration)))
+	// Custom metrics
+	if len(result.Metrics) > 0 {
+		b.WriteString("## Custom Metrics\n\n")
+		b.WriteString("| Metric | Before | After | Change |\n")
+		b.WriteString("|--------|--------|-------|--------|\n")
+		for i := range result.Metrics {
+			m := &result.Metrics[i]
+			change := "-"
+			if delta, ok := m.Delta(); ok {
+				change = fmt.Sprintf("%+g", delta)
+			}
+			b.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", m.Name, formatMetricSample(m.Before), formatMetricSample(m.After), change))
+		}
+		b.WriteString("\n")
 	}
 
-	// RTA end is shown after all other events
-	if !result.RTOStartTime.IsZero() {
-		b.WriteString(fmt.Sprintf("| RTA end (service healthy) | %s | %s |\n",
-			result.RTOEndTime.Format(time.RFC3339),
-			formatDuration(result.RTA)))
-		b.WriteString(fmt.Sprintf("| RTA (measured downtime) | - | %s |\n",
-			formatDuration(result.RTA)))
-		b.WriteString(fmt.Sprintf("| RTO (target) | - | %s |\n",
-			formatDuration(result.RTOTarget)))
-	} else {
-		b.WriteString("| RTA | Disruption did not cause downtime | N/A |\n")
-		b.WriteString(fmt.Sprintf("| RTO (target) | - | %s |\n", formatDuration(result.RTOTarget)))
+	// Assertions
+	if len(result.Assertions) > 0 {
+		b.WriteString("## Assertions\n\n")
+		b.WriteString("| Assertion | Status |\n")
+		b.WriteString("|-----------|--------|\n")
+		for _, a := range result.Assertions {
+			status := "✅ PASS"
+			switch {
+			case a.Error != "":
+				status = "❌ FAIL: " + a.Error
+			case !a.Passed:
+				status = "❌ FAIL"
+			}
+			b.WriteString(fmt.Sprintf("| `%s` | %s |\n", strings.ReplaceAll(a.Expression, "|", "\\|"), status))
+		}
+		b.WriteString("\n")
 	}
 
-	if result.PostSnapshot != nil {
-		b.WriteString(fmt.Sprintf("| Post-snapshot | %s | %s |\n",
-			result.PostSnapshot.Timestamp.Format(time.RFC3339),
-			formatDuration(result.PostSnapshot.Duration)))
+	// Restore check
+	if rc := result.RestoreCheck; rc != nil {
+		b.WriteString("## Restore Check\n\n")
+		b.WriteString(fmt.Sprintf("**Restore Time:** %s", formatDuration(rc.RestoreTime)))
+		if rc.Target > 0 {
+			b.WriteString(fmt.Sprintf(" (target: %s)", formatDuration(rc.Target)))
+		}
+		if rc.Passed {
+			b.WriteString(" - ✅ PASS\n\n")
+		} else {
+			b.WriteString(fmt.Sprintf(" - ❌ FAIL: %s\n\n", rc.Reason))
+		}
+		if len(rc.IntegrityChecks) > 0 {
+			b.WriteString(formatGuardTable("Integrity Check", rc.IntegrityChecks))
+		}
 	}
 
-	if result.RPOVerify != nil {
-		b.WriteString(fmt.Sprintf("| RPO verification | %s | %s |\n",
-			result.RPOVerify.Timestamp.Format(time.RFC3339),
-			formatDuration(result.RPOVerify.Duration)))
+	// Timeline
+	b.WriteString("## Timeline\n\n")
+	b.WriteString(formatTimeline(result))
+	if len(result.HealthCheckAttempts) > 0 {
+		b.WriteString(fmt.Sprintf("![Availability and health check latency over the drill](%s)\n\n", ChartFileName))
 	}
+	b.WriteString(formatDowntimeDetails(result))
 
-	b.WriteString(fmt.Sprintf("| End | %s | %s |\n",
-		result.EndTime.Format(time.RFC3339),
-		formatDuration(result.EndTime.Sub(result.StartTime))))
+	// Health states
+	if result.Scenario.Degraded != nil && len(result.HealthCheckAttempts) > 0 {
+		b.WriteString("## Health States\n\n")
+		b.WriteString("Each state is counted from a health check until the next one, so times are approximate to the probe interval.\n\n")
+		checks, durations := result.HealthStateTimes()
+		b.WriteString("| State | Checks | Time |\n")
+		b.WriteString("|-------|--------|------|\n")
+		for _, state := range []string{runner.StateHealthy, runner.StateDegraded, runner.StateDown} {
+			b.WriteString(fmt.Sprintf("| %s | %d | %s |\n", state, checks[state], formatDuration(durations[state])))
+		}
+		b.WriteString("\n")
+	}
 
-	b.WriteString("\n")
+	// Health check latency
+	if len(result.HealthCheckAttempts) > 0 {
+		b.WriteString("## Health Check Latency\n\n")
+		b.WriteString(formatProbeLatency(result))
+	}
 
 	// Health Check Attempts
 	if len(result.HealthCheckAttempts) > 0 {
 		b.WriteString("## Health Check Attempts\n\n")
 		b.WriteString(fmt.Sprintf("Total attempts: %d\n\n", len(result.HealthCheckAttempts)))
-		b.WriteString("| Attempt | Timestamp | Exit Code | Duration |\n")
-		b.WriteString("|---------|-----------|-----------|----------|\n")
+		b.WriteString("| Attempt | Timestamp | Exit Code | Duration | State |\n")
+		b.WriteString("|---------|-----------|-----------|----------|-------|\n")
 		for i, attempt := range result.HealthCheckAttempts {
-			b.WriteString(fmt.Sprintf("| %d | %s | %d | %s |\n",
+			b.WriteString(fmt.Sprintf("| %d | %s | %d | %s | %s |\n",
 				i+1,
 				attempt.Timestamp.Format(time.RFC3339),
 				attempt.ExitCode,
-				formatDuration(attempt.Duration)))
+				formatDuration(attempt.Duration),
+				attempt.State()))
 		}
 		b.WriteString("\n")
 	}
@@ -135,11 +377,40 @@ func GenerateMarkdownReport(result *runner.DrillResult) string {
 	// Command Details
 	b.WriteString("## Command Execution Details\n\n")
 
+	if result.BackupFreshness != nil {
+		b.WriteString("### Backup Freshness\n\n")
+		b.WriteString(formatCommandResult(result.BackupFreshness))
+		b.WriteString("\n")
+	}
+
+	if rc := result.RestoreCheck; rc != nil {
+		b.WriteString("### Backup Restore\n\n")
+		b.WriteString(formatCommandResult(rc.Restore))
+		for _, g := range rc.IntegrityChecks {
+			b.WriteString(fmt.Sprintf("### Integrity Check: %s\n\n", g.Name))
+			b.WriteString(formatCommandResult(g.Result))
+		}
+		if rc.Cleanup != nil {
+			b.WriteString("### Restore Cleanup\n\n")
+			b.WriteString(formatCommandResult(rc.Cleanup))
+		}
+	}
+
+	if result.Seed != nil {
+		b.WriteString(fmt.Sprintf("### Seed Data (%d written, %d failed; last run shown)\n\n", result.SeedsWritten, result.SeedsFailed))
+		b.WriteString(formatCommandResult(result.Seed))
+	}
+
 	if result.PreSnapshot != nil {
 		b.WriteString("### Pre-snapshot\n\n")
 		b.WriteString(formatCommandResult(result.PreSnapshot))
 	}
 
+	for _, g := range result.Guards {
+		b.WriteString(fmt.Sprintf("### Guard: %s\n\n", g.Name))
+		b.WriteString(formatCommandResult(g.Result))
+	}
+
 	if result.Disrupt != nil {
 		b.WriteString("### Disruption\n\n")
 		b.WriteString(formatCommandResult(result.Disrupt))
@@ -150,25 +421,119 @@ func GenerateMarkdownReport(result *runner.DrillResult) string {
 		b.WriteString(formatCommandResult(result.Recover))
 	}
 
+	if result.Abort != nil {
+		b.WriteString("### Abort\n\n")
+		b.WriteString(formatCommandResult(result.Abort))
+	}
+
 	if result.PostSnapshot != nil {
 		b.WriteString("### Post-snapshot\n\n")
 		b.WriteString(formatCommandResult(result.PostSnapshot))
 	}
 
+	if result.SnapshotDiff != nil {
+		b.WriteString("### Snapshot Diff\n\n")
+		b.WriteString(formatSnapshotDiff(result.SnapshotDiff))
+	}
+
+	if len(result.SnapshotSamples) > 0 {
+		b.WriteString(fmt.Sprintf("### Continuous Snapshots (%d)\n\n", len(result.SnapshotSamples)))
+		b.WriteString(formatSnapshotSamples(result))
+	}
+
+	if result.MarkerRead != nil {
+		b.WriteString(fmt.Sprintf("### Marker Read (%d written, %d failed)\n\n", result.MarkersWritten, result.MarkersFailed))
+		b.WriteString(formatCommandResult(result.MarkerRead))
+	}
+
 	if result.RPOVerify != nil {
 		b.WriteString("### RPO Verification\n\n")
 		b.WriteString(formatCommandResult(result.RPOVerify))
 	}
 
-	// Factor Logs
-	if len(result.FactorLogs) > 0 {
+	for _, c := range result.ConsistencyChecks {
+		if c.Baseline != nil {
+			b.WriteString(fmt.Sprintf("### Consistency Check: %s (Baseline)\n\n", c.Name))
+			b.WriteString(formatCommandResult(c.Baseline))
+		}
+		if c.Result != nil {
+			b.WriteString(fmt.Sprintf("### Consistency Check: %s\n\n", c.Name))
+			b.WriteString(formatCommandResult(c.Result))
+		}
+		if c.Compare != nil {
+			b.WriteString(fmt.Sprintf("### Consistency Check: %s (Compare)\n\n", c.Name))
+			b.WriteString(formatCommandResult(c.Compare))
+		}
+	}
+
+	for _, m := range result.Metrics {
+		if m.Before != nil {
+			b.WriteString(fmt.Sprintf("### Metric: %s (Before)\n\n", m.Name))
+			b.WriteString(formatCommandResult(m.Before.Result))
+		}
+		if m.After != nil {
+			b.WriteString(fmt.Sprintf("### Metric: %s (After)\n\n", m.Name))
+			b.WriteString(formatCommandResult(m.After.Result))
+		}
+	}
+
+	for _, c := range result.RPOChecks {
+		for _, step := range []struct {
+			title  string
+			result *runner.CommandResult
+		}{
+			{"Pre-snapshot", c.PreSnapshot},
+			{"Post-snapshot", c.PostSnapshot},
+			{"Verification", c.Verify},
+		} {
+			if step.result != nil {
+				b.WriteString(fmt.Sprintf("### RPO Check: %s (%s)\n\n", c.Name, step.title))
+				b.WriteString(formatCommandResult(step.result))
+			}
+		}
+		if c.SnapshotDiff != nil {
+			b.WriteString(fmt.Sprintf("### RPO Check: %s (Snapshot Diff)\n\n", c.Name))
+			b.WriteString(formatSnapshotDiff(c.SnapshotDiff))
+		}
+	}
+
+	if result.Switchback != nil {
+		b.WriteString("### Switchback\n\n")
+		b.WriteString(formatCommandResult(result.Switchback))
+	}
+
+	// Influencing factors
+	if len(result.FactorLogs) > 0 || len(result.SystemSamples) > 0 {
 		b.WriteString("## Influencing Factors\n\n")
+		if len(result.SystemSamples) > 0 {
+			b.WriteString("### System Metrics\n\n")
+			b.WriteString(formatSystemMetrics(result))
+		}
 		for i, log := range result.FactorLogs {
 			b.WriteString(fmt.Sprintf("### Factor Log %d\n\n", i+1))
 			b.WriteString(formatCommandResult(&log))
 		}
 	}
 
+	// Artifacts
+	if len(result.Artifacts) > 0 {
+		b.WriteString("## Artifacts\n\n")
+		b.WriteString("| File | Source | Size | SHA256 |\n")
+		b.WriteString("|------|--------|------|--------|\n")
+		var missing []string
+		for _, a := range result.Artifacts {
+			if a.Path == "" {
+				missing = append(missing, a.Pattern)
+				continue
+			}
+			b.WriteString(fmt.Sprintf("| `%s` | `%s` | %d bytes | `%s` |\n", a.Path, a.Source, a.Size, a.SHA256))
+		}
+		b.WriteString("\n")
+		for _, pattern := range missing {
+			b.WriteString(fmt.Sprintf("⚠️ `%s` matched no files.\n\n", pattern))
+		}
+	}
+
 	// Errors
 	if len(result.Errors) > 0 {
 		b.WriteString("## Errors\n\n")
@@ -180,51 +545,523 @@ func GenerateMarkdownReport(result *runner.DrillResult) string {
 
 	// Compliance Notes
 	b.WriteString("## Compliance Notes\n\n")
-	b.WriteString("This drill measures Recovery Time Objective (RTO) and Recovery Point Objective (RPO) ")
-	b.WriteString("as part of disaster recovery and business continuity planning.\n\n")
+	if sla := evaluateSLA(result); sla != nil {
+		b.WriteString(formatSLA(result, sla))
+	}
+	controls := evaluateControls(result)
+	if len(controls) > 0 {
+		b.WriteString(fmt.Sprintf("Drill outcomes mapped to controls by `%s`:\n\n", result.Scenario.Controls.Path))
+		b.WriteString(formatControls(controls))
+		b.WriteString("\n")
+	} else {
+		b.WriteString("This drill measures Recovery Time Objective (RTO) and Recovery Point Objective (RPO) ")
+		b.WriteString("as part of disaster recovery and business continuity planning.\n\n")
+	}
 
-	if result.RTOStartTime.IsZero() {
+	if result.BackupStale {
+		b.WriteString(fmt.Sprintf("- ❌ **Backup Freshness**: The latest backup does not meet the RPO target (age: %s > RPO: %s); the disruption was not run.\n",
+			formatBackupAge(result), formatDuration(result.RPOTarget)))
+	} else if result.BackupFreshness != nil {
+		b.WriteString(fmt.Sprintf("- ✅ **Backup Freshness**: The latest backup was within the RPO target before disruption (age: %s <= RPO: %s).\n",
+			formatBackupAge(result), formatDuration(result.RPOTarget)))
+	}
+
+	if result.GuardFailed {
+		var failed []string
+		for _, g := range result.Guards {
+			if !g.Passed {
+				failed = append(failed, fmt.Sprintf("%s (%s)", g.Name, g.Reason))
+			}
+		}
+		b.WriteString(fmt.Sprintf("- ❌ **Guards**: Preconditions for the disruption were not met: %s; the disruption was not run.\n",
+			strings.Join(failed, "; ")))
+	} else if len(result.Guards) > 0 {
+		b.WriteString("- ✅ **Guards**: All preconditions for the disruption were met.\n")
+	}
+
+	if result.BackupStale {
+		b.WriteString("- ➖ **RTO Compliance**: Not measured because the drill stopped at the backup freshness check.\n")
+	} else if result.GuardFailed {
+		b.WriteString("- ➖ **RTO Compliance**: Not measured because a guard failed before the disruption.\n")
+	} else if result.Aborted {
+		b.WriteString(fmt.Sprintf("- ❌ **RTO Compliance**: Service did not recover; the drill was aborted after %s of downtime and recovery was forced (RTO: %s).\n",
+			formatDuration(result.AbortAfter), formatDuration(result.RTOTarget)))
+	} else if result.Incomplete {
+		b.WriteString(fmt.Sprintf("- ⚠️ **RTO Compliance**: Not demonstrated because the drill did not complete (RTA so far: %s).\n",
+			formatDuration(result.RTA)))
+	} else if result.RTOStartTime.IsZero() {
 		b.WriteString("- ✅ **RTO Compliance**: Disruption did not cause downtime - service remained healthy.\n")
+	} else if result.MaxFailedProbes != nil {
+		if result.RTOPassed {
+			b.WriteString(fmt.Sprintf("- ✅ **Recovery Compliance**: Service recovered within the failed probe budget (%d <= %d failed probes, RTA: %s).\n",
+				result.FailedProbes, *result.MaxFailedProbes, formatDuration(result.RTA)))
+		} else {
+			b.WriteString(fmt.Sprintf("- ❌ **Recovery Compliance**: Service did not recover within the failed probe budget (%d > %d failed probes, RTA: %s).\n",
+				result.FailedProbes, *result.MaxFailedProbes, formatDuration(result.RTA)))
+		}
 	} else if result.RTOPassed {
-		b.WriteString(fmt.Sprintf("- ✅ **RTO Compliance**: Service recovered within the target RTO (RTA: %s <= RTO: %s).\n",
-			formatDuration(result.RTA), formatDuration(result.RTOTarget)))
+		b.WriteString(fmt.Sprintf("- ✅ **RTO Compliance**: Service recovered within the target RTO (%s <= RTO: %s).\n",
+			formatCountedRTA(result), formatDuration(result.RTOTarget)))
 	} else {
-		b.WriteString(fmt.Sprintf("- ❌ **RTO Compliance**: Service did not recover within the target RTO (RTA: %s > RTO: %s).\n",
-			formatDuration(result.RTA), formatDuration(result.RTOTarget)))
+		b.WriteString(fmt.Sprintf("- ❌ **RTO Compliance**: Service did not recover within the target RTO (%s > RTO: %s).\n",
+			formatCountedRTA(result), formatDuration(result.RTOTarget)))
 	}
 
-	if result.RPOTarget > 0 {
-		if result.RPOPassed {
+	if result.RPOTarget > 0 && !result.OnlyRPOChecks() {
+		switch {
+		case result.RPOMeasured() && result.RPOPassed:
+			b.WriteString(fmt.Sprintf("- ✅ **RPO Compliance**: Measured data loss within the target RPO (%s <= RPO: %s).\n",
+				formatDuration(result.RPOActual), formatDuration(result.RPOTarget)))
+		case result.RPOMeasured() && result.RPOActual > result.RPOTarget:
+			b.WriteString(fmt.Sprintf("- ❌ **RPO Compliance**: Measured data loss exceeded the target RPO (%s > RPO: %s).\n",
+				formatDuration(result.RPOActual), formatDuration(result.RPOTarget)))
+		case result.RPOPassed:
 			b.WriteString("- ✅ **RPO Compliance**: Data loss verified to be within acceptable limits.\n")
-		} else {
+		default:
 			b.WriteString("- ❌ **RPO Compliance**: Data loss verification failed or exceeded limits.\n")
 		}
+	} else if result.MissingRows != nil {
+		if result.RPOPassed {
+			b.WriteString(fmt.Sprintf("- ✅ **RPO Compliance**: Missing rows within the limit (%d <= %d).\n", *result.MissingRows, result.MaxMissingRows))
+		} else {
+			b.WriteString(fmt.Sprintf("- ❌ **RPO Compliance**: Missing rows exceeded the limit (%d > %d).\n", *result.MissingRows, result.MaxMissingRows))
+		}
+	}
+
+	if len(result.ConsistencyChecks) > 0 {
+		if result.ConsistencyPassed() {
+			b.WriteString(fmt.Sprintf("- ✅ **Data Consistency**: All %d consistency checks passed after recovery.\n", len(result.ConsistencyChecks)))
+		} else {
+			var failed []string
+			for _, c := range result.ConsistencyChecks {
+				if !c.Passed {
+					failed = append(failed, fmt.Sprintf("%s (%s)", c.Name, c.Reason))
+				}
+			}
+			b.WriteString(fmt.Sprintf("- ❌ **Data Consistency**: Consistency checks failed after recovery: %s.\n", strings.Join(failed, "; ")))
+		}
+	}
+
+	if len(result.Assertions) > 0 {
+		if result.AssertionsPassed() {
+			b.WriteString(fmt.Sprintf("- ✅ **Assertions**: All %d assertions held.\n", len(result.Assertions)))
+		} else {
+			var failed []string
+			for _, a := range result.Assertions {
+				if !a.Passed {
+					failed = append(failed, "`"+a.Expression+"`")
+				}
+			}
+			b.WriteString(fmt.Sprintf("- ❌ **Assertions**: Assertions did not hold: %s.\n", strings.Join(failed, ", ")))
+		}
+	}
+
+	if rc := result.RestoreCheck; rc != nil {
+		if rc.Passed {
+			b.WriteString(fmt.Sprintf("- ✅ **Backup Restore**: The backup was restored in %s and passed %d integrity check(s).\n",
+				formatDuration(rc.RestoreTime), len(rc.IntegrityChecks)))
+		} else {
+			b.WriteString(fmt.Sprintf("- ❌ **Backup Restore**: %s.\n", rc.Reason))
+		}
+	}
+
+	for i := range result.RPOChecks {
+		c := &result.RPOChecks[i]
+		if c.Passed {
+			b.WriteString(fmt.Sprintf("- ✅ **RPO Compliance (%s)**: %s.\n", c.Name, formatRPOCheckLoss(c)))
+		} else {
+			b.WriteString(fmt.Sprintf("- ❌ **RPO Compliance (%s)**: %s.\n", c.Name, c.Reason))
+		}
+	}
+
+	if result.Switchback != nil {
+		switch {
+		case !result.SwitchbackPassed && result.SwitchbackTarget > 0:
+			b.WriteString(fmt.Sprintf("- ❌ **Switchback**: Return to the original primary was not completed within the target (%s, target: %s).\n",
+				formatDuration(result.SwitchbackTime), formatDuration(result.SwitchbackTarget)))
+		case !result.SwitchbackPassed:
+			b.WriteString(fmt.Sprintf("- ❌ **Switchback**: Service was not healthy after returning to the original primary (waited %s).\n",
+				formatDuration(result.SwitchbackTime)))
+		default:
+			b.WriteString(fmt.Sprintf("- ✅ **Switchback**: Returned to the original primary in %s.\n", formatDuration(result.SwitchbackTime)))
+		}
+	}
+
+	b.WriteString("\n")
+	if len(controls) == 0 {
+		b.WriteString("This report can be used as evidence for:\n")
+		b.WriteString("- SOC 2 Type II audits\n")
+		b.WriteString("- ISO 27001 compliance\n")
+		b.WriteString("- Internal disaster recovery planning\n")
+		b.WriteString("- Service level agreement (SLA) validation\n\n")
+	}
+
+	return b.String()
+}
+
+// formatRTA formats the measured RTA, noting the counted RTA when a downtime
+// grace or pauses are excluded
+func formatRTA(result *runner.DrillResult) string {
+	excluded := excludedDowntime(result, "grace")
+	if excluded == "" {
+		return formatDuration(result.RTA)
+	}
+	return fmt.Sprintf("%s (%s counted after %s)",
+		formatDuration(result.RTA), formatDuration(result.CountedRTA()), excluded)
+}
+
+// formatCountedRTA describes the RTA compared against the RTO target in compliance notes
+func formatCountedRTA(result *runner.DrillResult) string {
+	excluded := excludedDowntime(result, "expected downtime grace")
+	if excluded == "" {
+		return "RTA: " + formatDuration(result.RTA)
+	}
+	return fmt.Sprintf("RTA: %s measured, %s, counted: %s",
+		formatDuration(result.RTA), excluded, formatDuration(result.CountedRTA()))
+}
+
+// excludedDowntime lists the downtime left out of the RTO comparison, e.g.
+// "30s grace, 2m paused", or returns "" if there is none
+func excludedDowntime(result *runner.DrillResult, graceLabel string) string {
+	var parts []string
+	if result.DowntimeGrace > 0 {
+		parts = append(parts, formatDuration(result.DowntimeGrace)+" "+graceLabel)
+	}
+	if paused := result.PausedDowntime(); paused > 0 {
+		parts = append(parts, formatDuration(paused)+" paused")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatSnapshotDiff renders the record count delta, changed checksums, and
+// changed lines between the pre- and post-snapshot output
+func formatSnapshotDiff(diff *runner.SnapshotDiff) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("**Records:** %d before, %d after (%+d)\n\n", diff.PreRecords, diff.PostRecords, diff.RecordDelta()))
+	if diff.Identical() {
+		b.WriteString("✅ Both snapshots have identical content\n\n")
+		return b.String()
+	}
+
+	if len(diff.Checksums) > 0 {
+		b.WriteString(fmt.Sprintf("**Changed Checksums:** %d\n\n", len(diff.Checksums)))
+		b.WriteString("| Key | Before | After |\n")
+		b.WriteString("|-----|--------|-------|\n")
+		for _, c := range diff.Checksums {
+			b.WriteString(fmt.Sprintf("| %s | %s | %s |\n", strings.ReplaceAll(c.Key, "|", "\\|"), formatChecksum(c.Before), formatChecksum(c.After)))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(fmt.Sprintf("**Changed Lines:** %d removed, %d added\n\n", diff.LinesRemoved, diff.LinesAdded))
+	b.WriteString("```diff\n")
+	b.WriteString(strings.Join(diff.Lines, "\n"))
+	b.WriteString("\n```\n\n")
+	if diff.Truncated {
+		b.WriteString(fmt.Sprintf("_Showing the first %d changed lines._\n\n", len(diff.Lines)))
+	}
+
+	return b.String()
+}
+
+// formatDegradedStatus marks the degraded row when any health check was degraded
+func formatDegradedStatus(checks map[string]int) string {
+	if checks[runner.StateDegraded] > 0 {
+		return "⚠️ DEGRADED"
+	}
+	return "✅ NONE"
+}
+
+// formatErrorMinutes formats estimated error minutes to two decimals
+func formatErrorMinutes(minutes float64) string {
+	return fmt.Sprintf("%.2f", minutes)
+}
+
+// formatBreakdown renders the parts of the outage that can be measured
+// separately, or nothing if none can
+func formatBreakdown(result *runner.DrillResult) string {
+	var rows []string
+	if d, ok := result.TimeToDetect(); ok {
+		rows = append(rows, fmt.Sprintf("| Time to detect | Disruption issued | First failed health check | %s |", formatDuration(d)))
+	}
+	if d, ok := result.RecoveryExecution(); ok {
+		rows = append(rows, fmt.Sprintf("| Recovery execution | recover_command started | recover_command finished | %s |", formatDuration(d)))
+	}
+	if d, ok := result.TimeToValidate(); ok {
+		validate := formatDuration(d)
+		if d < 0 {
+			validate = fmt.Sprintf("0ms (healthy %s before recover_command finished)", formatDuration(-d))
+		}
+		rows = append(rows, fmt.Sprintf("| Time to validate | recover_command finished | First healthy check | %s |", validate))
+	}
+	if len(rows) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString("| Phase | From | To | Duration |\n")
+	b.WriteString("|-------|------|----|----------|\n")
+	for _, row := range rows {
+		b.WriteString(row + "\n")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// formatSnapshotSamples renders the snapshots taken during the drill, marking
+// each change in output and when it settled on the final post-snapshot
+func formatSnapshotSamples(result *runner.DrillResult) string {
+	var b strings.Builder
+
+	disrupted := result.StartTime
+	if result.Disrupt != nil {
+		disrupted = result.Disrupt.Timestamp
+	}
+
+	if stable := result.DataStableAt(); !stable.IsZero() {
+		b.WriteString(fmt.Sprintf("**Data stable since:** %s (%s after the disruption)\n\n",
+			stable.Format(time.RFC3339), formatOffset(stable.Sub(disrupted))))
+	} else {
+		b.WriteString("**Data stable since:** not before the post-snapshot\n\n")
 	}
 
+	b.WriteString("| Time | Offset | Exit Code | Output Hash | Changed |\n")
+	b.WriteString("|------|--------|-----------|-------------|---------|\n")
+	prev := ""
+	for i, s := range result.SnapshotSamples {
+		changed := ""
+		if i > 0 && s.StdoutHash != prev {
+			changed = "yes"
+		}
+		prev = s.StdoutHash
+		b.WriteString(fmt.Sprintf("| %s | +%s | %d | %s | %s |\n",
+			s.Time.Format(time.RFC3339), formatOffset(s.Time.Sub(disrupted)),
+			s.ExitCode, formatChecksum(s.StdoutHash), changed))
+	}
 	b.WriteString("\n")
-	b.WriteString("This report can be used as evidence for:\n")
-	b.WriteString("- SOC 2 Type II audits\n")
-	b.WriteString("- ISO 27001 compliance\n")
-	b.WriteString("- Internal disaster recovery planning\n")
-	b.WriteString("- Service level agreement (SLA) validation\n\n")
 
 	return b.String()
 }
 
+// formatOffset formats a time since the disruption; the first snapshot can
+// start a moment before the disruption command does
+func formatOffset(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return formatDuration(d)
+}
+
+// formatMetricSample formats a metric reading for a table cell
+func formatMetricSample(s *runner.MetricSample) string {
+	switch {
+	case s == nil:
+		return "-"
+	case s.Error != "":
+		return "⚠️ " + strings.ReplaceAll(s.Error, "|", "\\|")
+	default:
+		return "`" + shortValue(s.Value) + "`"
+	}
+}
+
+// formatChecksum shortens a checksum for a table cell
+func formatChecksum(sum string) string {
+	if sum == "" {
+		return "(missing)"
+	}
+	if len(sum) > 12 {
+		sum = sum[:12] + "…"
+	}
+	return "`" + sum + "`"
+}
+
+// formatRPOActual formats the measured data loss with the latest write times it was computed from
+func formatRPOActual(result *runner.DrillResult) string {
+	if result.MissingRows != nil {
+		return fmt.Sprintf("%d missing row(s) (max: %d)", *result.MissingRows, result.MaxMissingRows)
+	}
+	if !result.RPOMeasured() {
+		return "N/A"
+	}
+	if result.RPOFromOutput {
+		return fmt.Sprintf("%s (printed by verify_command)", formatDuration(result.RPOActual))
+	}
+	return fmt.Sprintf("%s (latest write %s before, %s after)", formatDuration(result.RPOActual),
+		result.PreSnapshotTime.UTC().Format(time.RFC3339), result.PostSnapshotTime.UTC().Format(time.RFC3339))
+}
+
+// formatSwitchbackTarget formats the switchback target, which is optional
+func formatSwitchbackTarget(result *runner.DrillResult) string {
+	if result.SwitchbackTarget == 0 {
+		return "-"
+	}
+	return formatDuration(result.SwitchbackTarget)
+}
+
+// formatSwitchbackStatus reports PASS/FAIL against the target, or only whether
+// the service came back when no target is set
+func formatSwitchbackStatus(result *runner.DrillResult) string {
+	if result.SwitchbackPassed {
+		return "✅ PASS"
+	}
+	return "❌ FAIL"
+}
+
+// passedAssertions counts the assertions that held
+func passedAssertions(result *runner.DrillResult) int {
+	n := 0
+	for _, a := range result.Assertions {
+		if a.Passed {
+			n++
+		}
+	}
+	return n
+}
+
+// passedConsistencyChecks counts the consistency checks that passed
+func passedConsistencyChecks(result *runner.DrillResult) int {
+	n := 0
+	for _, c := range result.ConsistencyChecks {
+		if c.Passed {
+			n++
+		}
+	}
+	return n
+}
+
+// shortValue truncates command output shown in a table cell to one short line
+func shortValue(s string) string {
+	s = strings.ReplaceAll(strings.ReplaceAll(s, "\n", " "), "|", "\\|")
+	if len(s) > 60 {
+		s = s[:60] + "…"
+	}
+	return s
+}
+
+// passedRPOChecks counts the rpo_checks entries that passed
+func passedRPOChecks(result *runner.DrillResult) int {
+	n := 0
+	for _, c := range result.RPOChecks {
+		if c.Passed {
+			n++
+		}
+	}
+	return n
+}
+
+// formatRPOCheckLoss formats the data loss one rpo_checks entry measured
+func formatRPOCheckLoss(c *runner.RPOCheckResult) string {
+	switch {
+	case c.MissingRows != nil:
+		return fmt.Sprintf("%d missing row(s) (max: %d)", *c.MissingRows, c.MaxMissingRows)
+	case c.Measured && !c.PreSnapshotTime.IsZero():
+		return fmt.Sprintf("%s (latest write %s before, %s after)", formatDuration(c.Actual),
+			c.PreSnapshotTime.UTC().Format(time.RFC3339), c.PostSnapshotTime.UTC().Format(time.RFC3339))
+	case c.Measured:
+		return fmt.Sprintf("%s (printed by verify_command)", formatDuration(c.Actual))
+	case c.Verify != nil:
+		return fmt.Sprintf("verify_command exit code %d", c.Verify.ExitCode)
+	default:
+		return "N/A"
+	}
+}
+
+// formatGuardTable renders guard-style checks with their condition and value
+func formatGuardTable(kind string, guards []runner.GuardResult) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("| %s | Condition | Value | Status |\n", kind))
+	b.WriteString(fmt.Sprintf("|%s|-----------|-------|--------|\n", strings.Repeat("-", len(kind)+2)))
+	for _, g := range guards {
+		condition := g.Condition
+		if condition == "" {
+			condition = "exit code 0"
+		}
+		value := g.Value
+		if value == "" {
+			value = fmt.Sprintf("exit code %d", g.Result.ExitCode)
+		}
+		status := "✅ PASS"
+		if !g.Passed {
+			status = "❌ FAIL: " + g.Reason
+		}
+		b.WriteString(fmt.Sprintf("| %s | `%s` | %s | %s |\n", g.Name, condition, value, status))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// passedGuards counts the guards that passed
+func passedGuards(result *runner.DrillResult) int {
+	passed := 0
+	for _, g := range result.Guards {
+		if g.Passed {
+			passed++
+		}
+	}
+	return passed
+}
+
+// formatBackupAge formats the measured backup age, which is unknown if the check failed
+func formatBackupAge(result *runner.DrillResult) string {
+	if result.BackupAge == 0 && result.BackupStale {
+		return "unknown"
+	}
+	return formatDuration(result.BackupAge)
+}
+
 // formatCommandResult formats a command result for Markdown
 func formatCommandResult(result *runner.CommandResult) string {
 	var b strings.Builder
 
 	b.WriteString(fmt.Sprintf("**Command:** `%s`\n\n", result.Command))
+	if result.Host != "" {
+		b.WriteString(fmt.Sprintf("**Host:** `%s`\n\n", result.Host))
+	}
+	if result.RunAs != "" {
+		b.WriteString(fmt.Sprintf("**Run As:** `%s` (sudo)\n\n", result.RunAs))
+	}
 	b.WriteString(fmt.Sprintf("**Timestamp:** %s\n\n", result.Timestamp.Format(time.RFC3339)))
 	b.WriteString(fmt.Sprintf("**Duration:** %s\n\n", formatDuration(result.Duration)))
-	b.WriteString(fmt.Sprintf("**Exit Code:** %d\n\n", result.ExitCode))
+	if len(result.ExpectedExitCodes) > 0 {
+		b.WriteString(fmt.Sprintf("**Exit Code:** %d (expected: %s)\n\n", result.ExitCode, formatExitCodes(result.ExpectedExitCodes)))
+	} else {
+		b.WriteString(fmt.Sprintf("**Exit Code:** %d\n\n", result.ExitCode))
+	}
+
+	if len(result.ExpectedStdout) > 0 {
+		if len(result.StdoutMismatches) == 0 {
+			b.WriteString(fmt.Sprintf("**Output Assertions:** ✅ stdout matched all %d expected patterns\n\n", len(result.ExpectedStdout)))
+		} else {
+			b.WriteString("**Output Assertions:** ❌ stdout did not match:\n\n")
+			for _, pattern := range result.StdoutMismatches {
+				b.WriteString(fmt.Sprintf("- `%s`\n", pattern))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(result.Members) > 0 {
+		b.WriteString("| Host | Exit Code | Duration |\n")
+		b.WriteString("|------|-----------|----------|\n")
+		for _, m := range result.Members {
+			b.WriteString(fmt.Sprintf("| %s | %d | %s |\n", m.Host, m.ExitCode, formatDuration(m.Duration)))
+		}
+		b.WriteString("\n")
+	}
 
 	if result.Stdout != "" {
 		b.WriteString("**Stdout:**\n\n")
 		b.WriteString("```\n")
 		b.WriteString(result.Stdout)
 		b.WriteString("\n```\n\n")
+		if result.StdoutOmitted > 0 && result.StdoutFile != "" {
+			b.WriteString(fmt.Sprintf("_Truncated by max_output; full stdout is stored compressed in `%s`._\n\n", result.StdoutFile))
+		} else if result.StdoutOmitted > 0 {
+			b.WriteString(fmt.Sprintf("_Truncated by max_output; %d bytes of stdout were not kept._\n\n", result.StdoutOmitted))
+		} else if result.StdoutFile != "" {
+			b.WriteString(fmt.Sprintf("_Preview only; full stdout is stored compressed in `%s`._\n\n", result.StdoutFile))
+		}
 	}
 
 	if result.Stderr != "" {
@@ -232,6 +1069,13 @@ func formatCommandResult(result *runner.CommandResult) string {
 		b.WriteString("```\n")
 		b.WriteString(result.Stderr)
 		b.WriteString("\n```\n\n")
+		if result.StderrOmitted > 0 && result.StderrFile != "" {
+			b.WriteString(fmt.Sprintf("_Truncated by max_output; full stderr is stored compressed in `%s`._\n\n", result.StderrFile))
+		} else if result.StderrOmitted > 0 {
+			b.WriteString(fmt.Sprintf("_Truncated by max_output; %d bytes of stderr were not kept._\n\n", result.StderrOmitted))
+		} else if result.StderrFile != "" {
+			b.WriteString(fmt.Sprintf("_Preview only; full stderr is stored compressed in `%s`._\n\n", result.StderrFile))
+		}
 	}
 
 	b.WriteString(fmt.Sprintf("**Stdout Hash (SHA256):** `%s`\n\n", result.StdoutHash))
@@ -240,6 +1084,15 @@ func formatCommandResult(result *runner.CommandResult) string {
 	return b.String()
 }
 
+// formatExitCodes formats a list of exit codes as "0, 2"
+func formatExitCodes(codes []int) string {
+	parts := make([]string, len(codes))
+	for i, code := range codes {
+		parts[i] = fmt.Sprintf("%d", code)
+	}
+	return strings.Join(parts, ", ")
+}
+
 // formatDuration formats a duration in a human-readable way
 func formatDuration(d time.Duration) string {
 	if d < time.Second {
@@ -256,23 +1109,267 @@ func formatDuration(d time.Duration) string {
 
 // ReportData represents the JSON structure for reports
 type ReportData struct {
+	SchemaVersion     int                     `json:"schema_version"`  // ReportSchemaVersion when written
 	Scenario          *config.Scenario        `json:"scenario"`
+	Variables         map[string]string       `json:"variables,omitempty"`  // Resolved, secrets masked
 	StartTime         string                  `json:"start_time"`
 	EndTime           string                  `json:"end_time"`
 	RTOTarget         string                  `json:"rto_target"`
 	RTA               string                  `json:"rta"`  // Recovery Time Actual
 	RTOPassed         bool                    `json:"rto_passed"`
+	DowntimeGrace     string                  `json:"expected_downtime_grace,omitempty"`
+	CountedRTA        string                  `json:"counted_rta,omitempty"`  // RTA minus the expected downtime grace and paused downtime
+	Phases            []PhaseData             `json:"phases,omitempty"`  // Each phase entered, in order
+	Pauses            []PauseData             `json:"pauses,omitempty"`  // Times the operator held the drill
+	PausedDowntime    string                  `json:"paused_downtime,omitempty"`  // Part of the RTA spent paused
+	RTAStart          string                  `json:"rta_start,omitempty"`  // Wall-clock time of the first failed health check
+	RTAEnd            string                  `json:"rta_end,omitempty"`
+	WallClockRTA      string                  `json:"rta_wall_clock"`  // rta_end minus rta_start on the wall clock; rta uses the monotonic clock
+	FailedProbes      int                     `json:"failed_probes"`
+	MaxFailedProbes   *int                    `json:"max_failed_probes,omitempty"`  // Success criterion used instead of RTA when set
+	HealthyAfter      int                     `json:"healthy_after,omitempty"`  // Consecutive healthy checks that confirmed recovery, when more than one
+	RecoveryConfirmed string                  `json:"recovery_confirmed,omitempty"`
+	DegradedStart     string                  `json:"degraded_start,omitempty"`
+	DegradedEnd       string                  `json:"degraded_end,omitempty"`
+	DegradedDuration  string                  `json:"degraded_duration,omitempty"`  // Healthy but over latency budget; not part of RTA
+	LatencyPercentile string                  `json:"latency_percentile,omitempty"`
 	RPOTarget         string                  `json:"rpo_target,omitempty"`
+	RPOActual         string                  `json:"rpo_actual,omitempty"`  // Data loss measured from snapshot timestamps, marker records, or verify_command output
+	PreSnapshotTime   string                  `json:"pre_snapshot_time,omitempty"`  // Latest write before the disruption
+	PostSnapshotTime  string                  `json:"post_snapshot_time,omitempty"`  // Latest write after recovery
+	MissingRows       *int                    `json:"missing_rows,omitempty"`  // Printed by verify_command with verify_output unit rows
+	MaxMissingRows    *int                    `json:"max_missing_rows,omitempty"`
 	RPOPassed         bool                    `json:"rpo_passed,omitempty"`
+	BackupFreshness   *CommandResultData      `json:"backup_freshness,omitempty"`
+	BackupAge         string                  `json:"backup_age,omitempty"`
+	BackupStale       bool                    `json:"backup_stale,omitempty"`
 	PreSnapshot       *CommandResultData      `json:"pre_snapshot,omitempty"`
+	Guards            []GuardData             `json:"guards,omitempty"`
+	RPOChecks         []RPOCheckData          `json:"rpo_checks,omitempty"`  // Per-data-store RPO checks
+	RestoreCheck      *RestoreData            `json:"restore_check,omitempty"`
+	ConsistencyChecks []ConsistencyData       `json:"consistency_checks,omitempty"`
+	Metrics           []MetricData            `json:"metrics,omitempty"`
+	Assertions        []AssertionData         `json:"assertions,omitempty"`
+	GuardFailed       bool                    `json:"guard_failed,omitempty"`  // A guard failed; the disruption was not run
 	Disrupt           *CommandResultData      `json:"disrupt"`
 	Recover           *CommandResultData      `json:"recover,omitempty"`
+	AbortAfter        string                  `json:"abort_after,omitempty"`
+	Aborted           bool                    `json:"aborted,omitempty"`  // Still down after abort_after; measurement stopped and the abort command ran
+	Abort             *CommandResultData      `json:"abort,omitempty"`
 	PostDisruptDelay  string                  `json:"post_disrupt_delay,omitempty"`
 	PostSnapshot      *CommandResultData      `json:"post_snapshot,omitempty"`
+	SnapshotDiff      *SnapshotDiffData       `json:"snapshot_diff,omitempty"`
+	SnapshotSamples   []SnapshotSampleData    `json:"snapshot_samples,omitempty"`  // post_snapshot runs taken during the drill
+	SystemSamples     []SystemSampleData      `json:"system_samples,omitempty"`  // Host metrics sampled throughout the drill
+	DataStableAt      string                  `json:"data_stable_at,omitempty"`  // When snapshot output settled on the final post-snapshot's
+	SeedsWritten      int                     `json:"seeds_written,omitempty"`  // Successful seed_command runs before the disruption
+	SeedsFailed       int                     `json:"seeds_failed,omitempty"`
+	Seed              *CommandResultData      `json:"seed,omitempty"`  // Last seed_command run
+	MarkersWritten    int                     `json:"markers_written,omitempty"`  // Marker records written before the disruption
+	MarkersFailed     int                     `json:"markers_failed,omitempty"`
+	MarkerRead        *CommandResultData      `json:"marker_read,omitempty"`
 	RPOVerify         *CommandResultData      `json:"rpo_verify,omitempty"`
+	Switchback        *CommandResultData      `json:"switchback,omitempty"`
+	SwitchbackStart   string                  `json:"switchback_start,omitempty"`
+	SwitchbackEnd     string                  `json:"switchback_end,omitempty"`
+	SwitchbackTime    string                  `json:"switchback_time,omitempty"`
+	SwitchbackTarget  string                  `json:"switchback_target,omitempty"`
+	SwitchbackPassed  bool                    `json:"switchback_passed,omitempty"`
 	HealthCheckAttempts []CommandResultData   `json:"health_check_attempts"`
 	FactorLogs        []CommandResultData     `json:"factor_logs,omitempty"`
+	Artifacts         []ArtifactData          `json:"artifacts,omitempty"`  // Files collected into the run directory after the drill
 	Errors            []string                `json:"errors,omitempty"`
+	IdempotencyKey    string                  `json:"idempotency_key,omitempty"`
+	WindowOverride    bool                    `json:"window_override,omitempty"`  // Forced outside allowed_windows
+	TimeToDetect      string                  `json:"time_to_detect,omitempty"`  // Disruption issued to first failed health check
+	RecoveryExecution string                  `json:"recovery_execution,omitempty"`  // recover_command duration during the outage
+	TimeToValidate    string                  `json:"time_to_validate,omitempty"`  // recover_command finished to first healthy check; negative if healthy earlier
+	ErrorBudget       *ErrorBudgetData        `json:"error_budget,omitempty"`  // Client-observed errors from the disruption to confirmed recovery
+	ProbeLatency      *ProbeLatencyData       `json:"probe_latency,omitempty"`  // Health check latency percentiles and histogram
+	HealthStates      map[string]HealthStateData `json:"health_states,omitempty"`  // Health checks and time per state, with a degraded block
+	Iteration         int                     `json:"iteration,omitempty"`  // This run's number in a repeated series
+	Iterations        int                     `json:"iterations,omitempty"`
+	Baseline          *BaselineData           `json:"baseline,omitempty"`  // RTA relative to the scenario's baseline run
+	Simulated         bool                    `json:"simulated,omitempty"`  // Outcomes were simulated; no commands ran
+	Status            string                  `json:"status"`  // complete, or incomplete when the run stopped with an error and results are partial
+	Interrupted       bool                    `json:"interrupted,omitempty"`  // Stopped by an interrupt
+	ResumedAt         string                  `json:"resumed_at,omitempty"`  // When the run was resumed from a checkpoint
+	ResumedFrom       string                  `json:"resumed_from,omitempty"`  // Phase the run was resumed in
+	SLA               *SLAData                `json:"sla,omitempty"`  // SLA from the scenario's sla_file, with whether the drill met its commitments
+	Controls          []ControlData           `json:"controls,omitempty"`  // Compliance controls from the scenario's controls_file, with whether the drill met them
+	Annotations       []Annotation            `json:"annotations,omitempty"`  // Reviewer notes, tickets, and sign-offs added with drillmeasure annotate
+	Anonymized        bool                    `json:"anonymized,omitempty"`  // Infrastructure details and command bodies were removed by report --anonymize
+}
+
+// PhaseData represents a drill phase in JSON
+type PhaseData struct {
+	Name     string `json:"name"`
+	Start    string `json:"start"`
+	End      string `json:"end,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// PauseData represents a pause in JSON
+type PauseData struct {
+	Phase    string `json:"phase"` // Phase the drill was about to enter
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Duration string `json:"duration"`
+}
+
+// SnapshotDiffData represents the pre/post snapshot comparison in JSON
+type SnapshotDiffData struct {
+	PreRecords   int                  `json:"pre_records"`
+	PostRecords  int                  `json:"post_records"`
+	RecordDelta  int                  `json:"record_delta"`
+	LinesAdded   int                  `json:"lines_added"`
+	LinesRemoved int                  `json:"lines_removed"`
+	Lines        []string             `json:"lines,omitempty"`      // Changed lines prefixed with "-" or "+"
+	Truncated    bool                 `json:"truncated,omitempty"`  // More lines changed than are listed
+	Checksums    []ChecksumChangeData `json:"checksums,omitempty"`
+}
+
+// ArtifactData represents a collected artifact in JSON
+type ArtifactData struct {
+	Pattern      string `json:"pattern"`
+	Source       string `json:"source,omitempty"`
+	Path         string `json:"path,omitempty"`  // Relative to the run directory; empty when the pattern matched no files
+	SHA256       string `json:"sha256,omitempty"`
+	SourceSHA256 string `json:"source_sha256,omitempty"`  // When secrets were masked in the copy
+	Size         int64  `json:"size,omitempty"`
+}
+
+// SystemSampleData represents one sample of system metrics in JSON
+type SystemSampleData struct {
+	Time   string             `json:"time"`
+	Values map[string]float64 `json:"values"`
+	Error  string             `json:"error,omitempty"`
+}
+
+// SnapshotSampleData represents a snapshot taken during the drill in JSON
+type SnapshotSampleData struct {
+	Time       string `json:"time"`
+	Duration   string `json:"duration"`
+	ExitCode   int    `json:"exit_code"`
+	StdoutHash string `json:"stdout_hash"`
+}
+
+// ChecksumChangeData represents a changed checksum in JSON
+type ChecksumChangeData struct {
+	Key    string `json:"key"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// ConsistencyData represents a consistency check in JSON
+type ConsistencyData struct {
+	Name      string             `json:"name"`
+	Condition string             `json:"condition,omitempty"`
+	Match     string             `json:"match,omitempty"`     // compare_command or baseline
+	Expected  string             `json:"expected,omitempty"`  // Output the check had to match
+	Value     string             `json:"value,omitempty"`
+	Passed    bool               `json:"passed"`
+	Reason    string             `json:"reason,omitempty"`
+	Result    *CommandResultData `json:"result,omitempty"`
+	Baseline  *CommandResultData `json:"baseline,omitempty"`
+	Compare   *CommandResultData `json:"compare,omitempty"`
+}
+
+// HealthStateData represents the health checks that found one state in JSON
+type HealthStateData struct {
+	Checks int    `json:"checks"`
+	Time   string `json:"time"`
+}
+
+// ProbeLatencyData represents health check latency in JSON
+type ProbeLatencyData struct {
+	Spans     []LatencyStatsData  `json:"spans"`
+	Histogram []LatencyBucketData `json:"histogram"`
+}
+
+// LatencyStatsData represents latency percentiles for one span of the drill in JSON
+type LatencyStatsData struct {
+	Span   string `json:"span"` // all, before_downtime, downtime, or after_recovery
+	Probes int    `json:"probes"`
+	P50    string `json:"p50"`
+	P90    string `json:"p90"`
+	P99    string `json:"p99"`
+	Max    string `json:"max"`
+}
+
+// LatencyBucketData represents one histogram bucket in JSON
+type LatencyBucketData struct {
+	LE    string `json:"le,omitempty"` // Upper bound; empty for the bucket of slower checks
+	Count int    `json:"count"`
+}
+
+// ErrorBudgetData represents the client-observed errors during the outage in JSON
+type ErrorBudgetData struct {
+	Window        string  `json:"window"`
+	Probes        int     `json:"probes"`
+	FailedProbes  int     `json:"failed_probes"`
+	FailedPercent float64 `json:"failed_percent"`
+	ErrorMinutes  float64 `json:"error_minutes"` // Estimated user-facing error minutes
+}
+
+// AssertionData represents a scenario assertion in JSON
+type AssertionData struct {
+	Expression string `json:"expression"`
+	Passed     bool   `json:"passed"`
+	Error      string `json:"error,omitempty"`
+}
+
+// MetricData represents a custom metric in JSON
+type MetricData struct {
+	Name   string            `json:"name"`
+	Before *MetricSampleData `json:"before,omitempty"`
+	After  *MetricSampleData `json:"after,omitempty"`
+	Delta  *float64          `json:"delta,omitempty"` // After minus before, when both are numbers
+}
+
+// MetricSampleData represents one reading of a custom metric in JSON
+type MetricSampleData struct {
+	Value  string             `json:"value,omitempty"`
+	Error  string             `json:"error,omitempty"`
+	Result *CommandResultData `json:"result"`
+}
+
+// RestoreData represents restore_check in JSON
+type RestoreData struct {
+	RestoreTime     string             `json:"restore_time"`
+	Target          string             `json:"target,omitempty"`
+	Passed          bool               `json:"passed"`
+	Reason          string             `json:"reason,omitempty"`
+	Restore         *CommandResultData `json:"restore"`
+	IntegrityChecks []GuardData        `json:"integrity_checks,omitempty"`
+	Cleanup         *CommandResultData `json:"cleanup,omitempty"`
+}
+
+// RPOCheckData represents one data store's RPO check in JSON
+type RPOCheckData struct {
+	Name             string             `json:"name"`
+	Target           string             `json:"target,omitempty"`
+	Passed           bool               `json:"passed"`
+	Reason           string             `json:"reason,omitempty"`
+	RPOActual        string             `json:"rpo_actual,omitempty"`
+	PreSnapshotTime  string             `json:"pre_snapshot_time,omitempty"`
+	PostSnapshotTime string             `json:"post_snapshot_time,omitempty"`
+	MissingRows      *int               `json:"missing_rows,omitempty"`
+	MaxMissingRows   *int               `json:"max_missing_rows,omitempty"`
+	PreSnapshot      *CommandResultData `json:"pre_snapshot,omitempty"`
+	PostSnapshot     *CommandResultData `json:"post_snapshot,omitempty"`
+	SnapshotDiff     *SnapshotDiffData  `json:"snapshot_diff,omitempty"`
+	Verify           *CommandResultData `json:"verify,omitempty"`
+}
+
+// GuardData represents a precondition guard in JSON
+type GuardData struct {
+	Name      string             `json:"name"`
+	Condition string             `json:"condition,omitempty"`
+	Value     string             `json:"value,omitempty"`
+	Passed    bool               `json:"passed"`
+	Reason    string             `json:"reason,omitempty"`
+	Result    *CommandResultData `json:"result"`
 }
 
 // CommandResultData represents command execution data in JSON
@@ -285,32 +1382,292 @@ type CommandResultData struct {
 	Timestamp   string `json:"timestamp"`
 	StdoutHash  string `json:"stdout_hash"`
 	StderrHash  string `json:"stderr_hash"`
+	Probe       *ProbeData `json:"probe,omitempty"`
+	StdoutFile  string `json:"stdout_file,omitempty"`
+	StderrFile  string `json:"stderr_file,omitempty"`
+	StdoutOmitted int  `json:"stdout_omitted,omitempty"`  // Bytes of stdout dropped by max_output
+	StderrOmitted int  `json:"stderr_omitted,omitempty"`  // Bytes of stderr dropped by max_output
+	ExpectedExitCodes []int `json:"expected_exit_codes,omitempty"`
+	Host        string `json:"host,omitempty"`
+	RunAs       string `json:"run_as,omitempty"`
+	Members     []MemberResultData `json:"members,omitempty"`  // Per-host results of a host group; output is in stdout/stderr
+	ExpectedStdout   []string `json:"expected_stdout,omitempty"`
+	StdoutMismatches []string `json:"stdout_mismatches,omitempty"`  // Expected patterns stdout did not match
+	Degraded    bool   `json:"degraded,omitempty"`  // Health check found the service up but impaired
+}
+
+// MemberResultData represents one host's result of a host group command in JSON
+type MemberResultData struct {
+	Host       string `json:"host"`
+	ExitCode   int    `json:"exit_code"`
+	Duration   string `json:"duration"`
+	StdoutHash string `json:"stdout_hash"`
+	StderrHash string `json:"stderr_hash"`
+}
+
+// ProbeData represents structured built-in probe data in JSON
+type ProbeData struct {
+	Type       string  `json:"type"`
+	Target     string  `json:"target"`
+	StatusCode int     `json:"status_code,omitempty"`
+	LatencyMs  float64 `json:"latency_ms"`
+	Value      *float64 `json:"value,omitempty"`
+	SignaledAt string  `json:"signaled_at,omitempty"`  // Push-based probes: when the latest event arrived
 }
 
 // GenerateJSONReport creates a machine-readable JSON report
 func GenerateJSONReport(result *runner.DrillResult) (string, error) {
 	data := ReportData{
+		SchemaVersion:     ReportSchemaVersion,
 		Scenario:          result.Scenario,
 		StartTime:         result.StartTime.Format(time.RFC3339),
 		EndTime:           result.EndTime.Format(time.RFC3339),
 		RTOTarget:         formatDuration(result.RTOTarget),
 		RTA:               formatDuration(result.RTA),
+		WallClockRTA:      formatDuration(result.WallClockRTA),
 		RTOPassed:         result.RTOPassed,
+		FailedProbes:      result.FailedProbes,
+		MaxFailedProbes:   result.MaxFailedProbes,
 		RPOPassed:         result.RPOPassed,
 		PostDisruptDelay:  formatDuration(result.PostDisruptDelay),
 		HealthCheckAttempts: make([]CommandResultData, 0, len(result.HealthCheckAttempts)),
 		FactorLogs:        make([]CommandResultData, 0, len(result.FactorLogs)),
 		Errors:            result.Errors,
+		IdempotencyKey:    result.IdempotencyKey,
+		WindowOverride:    result.WindowOverride,
+		Iteration:         result.Iteration,
+		Iterations:        result.Iterations,
+		Simulated:         result.Simulated,
+		Status:            "complete",
+		Interrupted:       result.Interrupted,
+		Baseline:          baselineData(result),
+	}
+
+	if d, ok := result.TimeToDetect(); ok {
+		data.TimeToDetect = formatDuration(d)
+	}
+	if d, ok := result.RecoveryExecution(); ok {
+		data.RecoveryExecution = formatDuration(d)
+	}
+	if d, ok := result.TimeToValidate(); ok {
+		data.TimeToValidate = formatDuration(d)
+	}
+	if result.Scenario.Degraded != nil {
+		checks, durations := result.HealthStateTimes()
+		data.HealthStates = map[string]HealthStateData{}
+		for _, state := range []string{runner.StateHealthy, runner.StateDegraded, runner.StateDown} {
+			data.HealthStates[state] = HealthStateData{Checks: checks[state], Time: formatDuration(durations[state])}
+		}
+	}
+	if stats := result.ProbeLatencyStats(); len(stats) > 0 {
+		data.ProbeLatency = &ProbeLatencyData{}
+		for _, s := range stats {
+			data.ProbeLatency.Spans = append(data.ProbeLatency.Spans, LatencyStatsData{
+				Span:   s.Span,
+				Probes: s.Probes,
+				P50:    formatDuration(s.P50),
+				P90:    formatDuration(s.P90),
+				P99:    formatDuration(s.P99),
+				Max:    formatDuration(s.Max),
+			})
+		}
+		for i, n := range latencyHistogram(result.ProbeLatencies()) {
+			bucket := LatencyBucketData{Count: n}
+			if i < len(latencyBuckets) {
+				bucket.LE = formatDuration(latencyBuckets[i])
+			}
+			data.ProbeLatency.Histogram = append(data.ProbeLatency.Histogram, bucket)
+		}
+	}
+	if budget, ok := result.ErrorBudget(); ok {
+		data.ErrorBudget = &ErrorBudgetData{
+			Window:        formatDuration(budget.Window),
+			Probes:        budget.Probes,
+			FailedProbes:  budget.Failed,
+			FailedPercent: budget.FailedPercent(),
+			ErrorMinutes:  budget.ErrorMinutes(),
+		}
 	}
 
 	if result.RPOTarget > 0 {
 		data.RPOTarget = formatDuration(result.RPOTarget)
 	}
+	if result.RPOMeasured() {
+		data.RPOActual = formatDuration(result.RPOActual)
+	}
+	if !result.PreSnapshotTime.IsZero() && !result.PostSnapshotTime.IsZero() {
+		data.PreSnapshotTime = result.PreSnapshotTime.UTC().Format(time.RFC3339Nano)
+		data.PostSnapshotTime = result.PostSnapshotTime.UTC().Format(time.RFC3339Nano)
+	}
+	if result.MissingRows != nil {
+		data.MissingRows = result.MissingRows
+		data.MaxMissingRows = &result.MaxMissingRows
+	}
+
+	if result.Incomplete {
+		data.Status = "incomplete"
+	}
+
+	if !result.ResumedAt.IsZero() {
+		data.ResumedAt = result.ResumedAt.Format(time.RFC3339)
+		data.ResumedFrom = result.ResumedFrom
+	}
+
+	data.SLA = evaluateSLA(result)
+	data.Controls = evaluateControls(result)
+
+	if !result.RTOStartTime.IsZero() {
+		data.RTAStart = result.RTOStartTime.Format(time.RFC3339Nano)
+		data.RTAEnd = result.RTOEndTime.Format(time.RFC3339Nano)
+	}
+
+	if result.HealthyAfter > 1 {
+		data.HealthyAfter = result.HealthyAfter
+		if !result.RecoveryConfirmedTime.IsZero() {
+			data.RecoveryConfirmed = result.RecoveryConfirmedTime.Format(time.RFC3339)
+		}
+	}
+
+	if result.DowntimeGrace > 0 {
+		data.DowntimeGrace = formatDuration(result.DowntimeGrace)
+		data.CountedRTA = formatDuration(result.CountedRTA())
+	}
+	for _, p := range result.Phases {
+		pd := PhaseData{Name: p.Name, Start: p.Start.Format(time.RFC3339)}
+		if !p.End.IsZero() {
+			pd.End = p.End.Format(time.RFC3339)
+			pd.Duration = formatDuration(p.Duration)
+		}
+		data.Phases = append(data.Phases, pd)
+	}
+	for _, p := range result.Pauses {
+		data.Pauses = append(data.Pauses, PauseData{
+			Phase:    p.Phase,
+			Start:    p.Start.Format(time.RFC3339),
+			End:      p.End.Format(time.RFC3339),
+			Duration: formatDuration(p.Duration()),
+		})
+	}
+	if paused := result.PausedDowntime(); paused > 0 {
+		data.PausedDowntime = formatDuration(paused)
+		data.CountedRTA = formatDuration(result.CountedRTA())
+	}
+
+	if !result.DegradedStartTime.IsZero() {
+		data.DegradedStart = result.DegradedStartTime.Format(time.RFC3339)
+		data.DegradedEnd = result.DegradedEndTime.Format(time.RFC3339)
+		data.DegradedDuration = formatDuration(result.DegradedDuration)
+	}
+	if result.LatencyPercentile > 0 {
+		data.LatencyPercentile = formatDuration(result.LatencyPercentile)
+	}
+
+	if len(result.Scenario.Variables) > 0 {
+		data.Variables = result.Scenario.MaskedVariables()
+	}
+
+	if result.BackupFreshness != nil {
+		data.BackupFreshness = commandResultToData(result.BackupFreshness)
+		data.BackupAge = formatBackupAge(result)
+		data.BackupStale = result.BackupStale
+	}
 
 	if result.PreSnapshot != nil {
 		data.PreSnapshot = commandResultToData(result.PreSnapshot)
 	}
 
+	data.Guards = guardsToData(result.Guards)
+	data.GuardFailed = result.GuardFailed
+
+	for _, c := range result.ConsistencyChecks {
+		check := ConsistencyData{
+			Name:      c.Name,
+			Condition: c.Condition,
+			Match:     c.Match,
+			Expected:  c.Expected,
+			Value:     c.Value,
+			Passed:    c.Passed,
+			Reason:    c.Reason,
+		}
+		if c.Result != nil {
+			check.Result = commandResultToData(c.Result)
+		}
+		if c.Baseline != nil {
+			check.Baseline = commandResultToData(c.Baseline)
+		}
+		if c.Compare != nil {
+			check.Compare = commandResultToData(c.Compare)
+		}
+		data.ConsistencyChecks = append(data.ConsistencyChecks, check)
+	}
+
+	for i := range result.Metrics {
+		m := &result.Metrics[i]
+		metric := MetricData{
+			Name:   m.Name,
+			Before: metricSampleToData(m.Before),
+			After:  metricSampleToData(m.After),
+		}
+		if delta, ok := m.Delta(); ok {
+			metric.Delta = &delta
+		}
+		data.Metrics = append(data.Metrics, metric)
+	}
+
+	for _, a := range result.Assertions {
+		data.Assertions = append(data.Assertions, AssertionData{Expression: a.Expression, Passed: a.Passed, Error: a.Error})
+	}
+
+	if rc := result.RestoreCheck; rc != nil {
+		data.RestoreCheck = &RestoreData{
+			RestoreTime:     formatDuration(rc.RestoreTime),
+			Passed:          rc.Passed,
+			Reason:          rc.Reason,
+			Restore:         commandResultToData(rc.Restore),
+			IntegrityChecks: guardsToData(rc.IntegrityChecks),
+		}
+		if rc.Target > 0 {
+			data.RestoreCheck.Target = formatDuration(rc.Target)
+		}
+		if rc.Cleanup != nil {
+			data.RestoreCheck.Cleanup = commandResultToData(rc.Cleanup)
+		}
+	}
+
+	for i := range result.RPOChecks {
+		c := &result.RPOChecks[i]
+		check := RPOCheckData{
+			Name:         c.Name,
+			Passed:       c.Passed,
+			Reason:       c.Reason,
+			SnapshotDiff: snapshotDiffToData(c.SnapshotDiff),
+		}
+		if c.Target > 0 {
+			check.Target = formatDuration(c.Target)
+		}
+		if c.Measured {
+			check.RPOActual = formatDuration(c.Actual)
+		}
+		if !c.PreSnapshotTime.IsZero() {
+			check.PreSnapshotTime = c.PreSnapshotTime.UTC().Format(time.RFC3339Nano)
+			check.PostSnapshotTime = c.PostSnapshotTime.UTC().Format(time.RFC3339Nano)
+		}
+		if c.MissingRows != nil {
+			check.MissingRows = c.MissingRows
+			check.MaxMissingRows = &c.MaxMissingRows
+		}
+		if c.PreSnapshot != nil {
+			check.PreSnapshot = commandResultToData(c.PreSnapshot)
+		}
+		if c.PostSnapshot != nil {
+			check.PostSnapshot = commandResultToData(c.PostSnapshot)
+		}
+		if c.Verify != nil {
+			check.Verify = commandResultToData(c.Verify)
+		}
+		data.RPOChecks = append(data.RPOChecks, check)
+	}
+
 	if result.Disrupt != nil {
 		data.Disrupt = commandResultToData(result.Disrupt)
 	}
@@ -319,14 +1676,67 @@ func GenerateJSONReport(result *runner.DrillResult) (string, error) {
 		data.Recover = commandResultToData(result.Recover)
 	}
 
+	if result.AbortAfter > 0 {
+		data.AbortAfter = formatDuration(result.AbortAfter)
+		data.Aborted = result.Aborted
+	}
+	if result.Abort != nil {
+		data.Abort = commandResultToData(result.Abort)
+	}
+
 	if result.PostSnapshot != nil {
 		data.PostSnapshot = commandResultToData(result.PostSnapshot)
 	}
 
+	data.SnapshotDiff = snapshotDiffToData(result.SnapshotDiff)
+	for _, s := range result.SnapshotSamples {
+		data.SnapshotSamples = append(data.SnapshotSamples, SnapshotSampleData{
+			Time:       s.Time.Format(time.RFC3339),
+			Duration:   formatDuration(s.Duration),
+			ExitCode:   s.ExitCode,
+			StdoutHash: s.StdoutHash,
+		})
+	}
+	for _, a := range result.Artifacts {
+		data.Artifacts = append(data.Artifacts, ArtifactData(a))
+	}
+	for _, s := range result.SystemSamples {
+		data.SystemSamples = append(data.SystemSamples, SystemSampleData{
+			Time:   s.Time.Format(time.RFC3339Nano),
+			Values: s.Values,
+			Error:  s.Error,
+		})
+	}
+	if stable := result.DataStableAt(); !stable.IsZero() {
+		data.DataStableAt = stable.Format(time.RFC3339)
+	}
+
+	data.SeedsWritten = result.SeedsWritten
+	data.SeedsFailed = result.SeedsFailed
+	if result.Seed != nil {
+		data.Seed = commandResultToData(result.Seed)
+	}
+	data.MarkersWritten = result.MarkersWritten
+	data.MarkersFailed = result.MarkersFailed
+	if result.MarkerRead != nil {
+		data.MarkerRead = commandResultToData(result.MarkerRead)
+	}
+
 	if result.RPOVerify != nil {
 		data.RPOVerify = commandResultToData(result.RPOVerify)
 	}
 
+	if result.Switchback != nil {
+		data.Switchback = commandResultToData(result.Switchback)
+		data.SwitchbackStart = result.SwitchbackStartTime.Format(time.RFC3339)
+		data.SwitchbackEnd = result.SwitchbackEndTime.Format(time.RFC3339)
+		data.SwitchbackTime = formatDuration(result.SwitchbackTime)
+		data.SwitchbackPassed = result.SwitchbackPassed
+		if result.SwitchbackTarget > 0 {
+			data.SwitchbackTarget = formatDuration(result.SwitchbackTarget)
+		}
+	}
+
 	for _, attempt := range result.HealthCheckAttempts {
 		data.HealthCheckAttempts = append(data.HealthCheckAttempts, *commandResultToData(&attempt))
 	}
@@ -343,9 +1753,53 @@ func GenerateJSONReport(result *runner.DrillResult) (string, error) {
 	return string(jsonBytes), nil
 }
 
+// guardsToData converts guard-style check results for the JSON report
+func guardsToData(guards []runner.GuardResult) []GuardData {
+	var data []GuardData
+	for _, g := range guards {
+		data = append(data, GuardData{
+			Name:      g.Name,
+			Condition: g.Condition,
+			Value:     g.Value,
+			Passed:    g.Passed,
+			Reason:    g.Reason,
+			Result:    commandResultToData(g.Result),
+		})
+	}
+	return data
+}
+
+// metricSampleToData converts a metric reading for the JSON report
+func metricSampleToData(s *runner.MetricSample) *MetricSampleData {
+	if s == nil {
+		return nil
+	}
+	return &MetricSampleData{Value: s.Value, Error: s.Error, Result: commandResultToData(s.Result)}
+}
+
+// snapshotDiffToData converts a snapshot diff for the JSON report
+func snapshotDiffToData(d *runner.SnapshotDiff) *SnapshotDiffData {
+	if d == nil {
+		return nil
+	}
+	data := &SnapshotDiffData{
+		PreRecords:   d.PreRecords,
+		PostRecords:  d.PostRecords,
+		RecordDelta:  d.RecordDelta(),
+		LinesAdded:   d.LinesAdded,
+		LinesRemoved: d.LinesRemoved,
+		Lines:        d.Lines,
+		Truncated:    d.Truncated,
+	}
+	for _, c := range d.Checksums {
+		data.Checksums = append(data.Checksums, ChecksumChangeData{Key: c.Key, Before: c.Before, After: c.After})
+	}
+	return data
+}
+
 // commandResultToData converts a CommandResult to CommandResultData
 func commandResultToData(result *runner.CommandResult) *CommandResultData {
-	return &CommandResultData{
+	data := &CommandResultData{
 		Command:    result.Command,
 		ExitCode:   result.ExitCode,
 		Stdout:     result.Stdout,
@@ -354,6 +1808,41 @@ func commandResultToData(result *runner.CommandResult) *CommandResultData {
 		Timestamp:  result.Timestamp.Format(time.RFC3339),
 		StdoutHash: result.StdoutHash,
 		StderrHash: result.StderrHash,
+		StdoutFile: result.StdoutFile,
+		StderrFile: result.StderrFile,
+		StdoutOmitted: result.StdoutOmitted,
+		StderrOmitted: result.StderrOmitted,
+		ExpectedExitCodes: result.ExpectedExitCodes,
+		Host:       result.Host,
+		RunAs:      result.RunAs,
+		ExpectedStdout:   result.ExpectedStdout,
+		StdoutMismatches: result.StdoutMismatches,
+		Degraded:   result.Degraded,
 	}
+
+	for _, m := range result.Members {
+		data.Members = append(data.Members, MemberResultData{
+			Host:       m.Host,
+			ExitCode:   m.ExitCode,
+			Duration:   formatDuration(m.Duration),
+			StdoutHash: m.StdoutHash,
+			StderrHash: m.StderrHash,
+		})
+	}
+
+	if result.Probe != nil {
+		data.Probe = &ProbeData{
+			Type:       result.Probe.Type,
+			Target:     result.Probe.Target,
+			StatusCode: result.Probe.StatusCode,
+			LatencyMs:  float64(result.Probe.Latency.Microseconds()) / 1000,
+			Value:      result.Probe.Value,
+		}
+		if !result.Probe.SignaledAt.IsZero() {
+			data.Probe.SignaledAt = result.Probe.SignaledAt.Format(time.RFC3339)
+		}
+	}
+
+	return data
 }
 
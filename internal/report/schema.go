@@ -0,0 +1,33 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ReportSchemaVersion is the version of the report.json structure. Fields may
+// be added within a version, but none are removed, renamed, or change type or
+// meaning; a change that would break readers bumps the version.
+const ReportSchemaVersion = 1
+
+// ParseReport decodes report.json data, upgrading reports written by older
+// versions. Reports from a newer schema version are rejected rather than
+// misread.
+func ParseReport(raw []byte) (*ReportData, error) {
+	migrated, err := MigrateReport(raw)
+	if err != nil {
+		return nil, err
+	}
+	if migrated != nil {
+		raw = migrated
+	}
+
+	var data ReportData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+	if data.SchemaVersion > ReportSchemaVersion {
+		return nil, fmt.Errorf("report schema version %d is newer than this version of drillmeasure supports (%d)", data.SchemaVersion, ReportSchemaVersion)
+	}
+	return &data, nil
+}
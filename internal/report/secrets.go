@@ -0,0 +1,142 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SecretFinding describes a likely secret found in report content
+type SecretFinding struct {
+	Rule string
+	Line int
+}
+
+// secretRule matches a kind of secret; Group selects the submatch to redact (0 = whole match)
+type secretRule struct {
+	Name    string
+	Pattern *regexp.Regexp
+	Group   int
+}
+
+// wordStart matches a word boundary, including one that follows an escape
+// sequence such as \n inside a JSON string
+const wordStart = `(?:\b|\\[nrt])`
+
+var secretRules = []secretRule{
+	{Name: "private-key", Pattern: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`)},
+	{Name: "aws-access-key-id", Pattern: regexp.MustCompile(wordStart + `((?:AKIA|ASIA)[0-9A-Z]{16})\b`), Group: 1},
+	{Name: "github-token", Pattern: regexp.MustCompile(wordStart + `(gh[pousr]_[A-Za-z0-9]{36,})\b`), Group: 1},
+	{Name: "slack-token", Pattern: regexp.MustCompile(wordStart + `(xox[abprs]-[A-Za-z0-9-]{10,})\b`), Group: 1},
+	{Name: "jwt", Pattern: regexp.MustCompile(wordStart + `(eyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,})\b`), Group: 1},
+	{Name: "bearer-token", Pattern: regexp.MustCompile(`(?i)` + wordStart + `bearer\s+([A-Za-z0-9._~+/-]{20,}=*)`), Group: 1},
+	{Name: "url-credentials", Pattern: regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s:/@"']+:([^\s@/"']+)@`), Group: 1},
+	{Name: "assigned-secret", Pattern: regexp.MustCompile(`(?i)` + wordStart + `(?:password|passwd|pwd|secret|token|api[_-]?key|access[_-]?key)\s*[:=]\s*([^\s"'&;,\\]{4,})`), Group: 1},
+}
+
+// highEntropyCandidate matches long opaque tokens that are checked for entropy
+var highEntropyCandidate = regexp.MustCompile(`[A-Za-z0-9+/_=-]{32,}`)
+
+// hexOnly matches strings such as SHA256 hashes, which are never flagged by entropy
+var hexOnly = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+// minSecretEntropy is the Shannon entropy (bits per character) above which a token is treated as a secret
+const minSecretEntropy = 4.5
+
+// ScanSecrets reports likely secrets in content without modifying it
+func ScanSecrets(content string) []SecretFinding {
+	_, findings := scanSecrets(content, false)
+	return findings
+}
+
+// RedactSecrets replaces likely secrets in content with placeholders
+func RedactSecrets(content string) (string, []SecretFinding) {
+	return scanSecrets(content, true)
+}
+
+// secretSpan is a byte range of content matched by a rule
+type secretSpan struct {
+	start, end int
+	rule       string
+}
+
+func scanSecrets(content string, redact bool) (string, []SecretFinding) {
+	var spans []secretSpan
+	for _, rule := range secretRules {
+		for _, m := range rule.Pattern.FindAllStringSubmatchIndex(content, -1) {
+			start, end := m[2*rule.Group], m[2*rule.Group+1]
+			if start < 0 || isPlaceholder(content[start:end]) {
+				continue
+			}
+			spans = append(spans, secretSpan{start: start, end: end, rule: rule.Name})
+		}
+	}
+	for _, m := range highEntropyCandidate.FindAllStringIndex(content, -1) {
+		token := content[m[0]:m[1]]
+		if hexOnly.MatchString(token) || !hasLettersAndDigits(token) || shannonEntropy(token) < minSecretEntropy {
+			continue
+		}
+		spans = append(spans, secretSpan{start: m[0], end: m[1], rule: "high-entropy"})
+	}
+
+	// Earlier and longer matches win; anything overlapping them is dropped
+	sort.Slice(spans, func(i, j int) bool {
+		if spans[i].start != spans[j].start {
+			return spans[i].start < spans[j].start
+		}
+		return spans[i].end > spans[j].end
+	})
+
+	var findings []SecretFinding
+	var b strings.Builder
+	last := 0
+	for _, span := range spans {
+		if span.start < last {
+			continue
+		}
+		findings = append(findings, SecretFinding{
+			Rule: span.rule,
+			Line: strings.Count(content[:span.start], "\n") + 1,
+		})
+		b.WriteString(content[last:span.start])
+		b.WriteString(placeholder(span.rule))
+		last = span.end
+	}
+	b.WriteString(content[last:])
+
+	if !redact {
+		return content, findings
+	}
+	return b.String(), findings
+}
+
+func placeholder(rule string) string {
+	return fmt.Sprintf("[REDACTED:%s]", rule)
+}
+
+// isPlaceholder reports whether a value is already masked or redacted
+func isPlaceholder(s string) bool {
+	return strings.Trim(s, "*") == "" || strings.HasPrefix(s, "[REDACTED")
+}
+
+func hasLettersAndDigits(s string) bool {
+	return strings.ContainsAny(s, "0123456789") &&
+		strings.ContainsAny(strings.ToLower(s), "abcdefghijklmnopqrstuvwxyz")
+}
+
+// shannonEntropy returns the entropy of s in bits per character
+func shannonEntropy(s string) float64 {
+	counts := map[rune]int{}
+	for _, r := range s {
+		counts[r]++
+	}
+	entropy := 0.0
+	n := float64(len(s))
+	for _, c := range counts {
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,100 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// SLAData is the SLA a drill validated in JSON, with whether the drill met
+// each of its commitments
+type SLAData struct {
+	ID          string `json:"id"`
+	Service     string `json:"service,omitempty"`
+	Description string `json:"description,omitempty"`
+	Path        string `json:"path,omitempty"` // SLA registry it was defined in
+	RTO         string `json:"rto"`
+	RTOMet      *bool  `json:"rto_met,omitempty"` // Absent when the drill did not measure recovery
+	RPO         string `json:"rpo,omitempty"`
+	RPOMet      *bool  `json:"rpo_met,omitempty"` // Absent when the SLA has no RPO or the drill did not measure data loss
+	Status      string `json:"status"`            // met, not_met, or not_measured
+}
+
+// evaluateSLA compares the drill's results with its SLA's commitments, which
+// may be looser than the scenario's own targets
+func evaluateSLA(result *runner.DrillResult) *SLAData {
+	if result.Scenario == nil || result.Scenario.SLADefinition == nil {
+		return nil
+	}
+	sla := result.Scenario.SLADefinition
+	data := &SLAData{
+		ID:          sla.ID,
+		Service:     sla.Service,
+		Description: sla.Description,
+		Path:        sla.Path,
+		RTO:         sla.RTO,
+		RPO:         sla.RPO,
+	}
+
+	var met bool
+	switch {
+	case result.BackupStale || result.GuardFailed || result.Incomplete:
+	case result.RTOStartTime.IsZero():
+		met = true
+		data.RTOMet = &met
+	default:
+		met = !result.Aborted && result.CountedRTA() <= sla.GetRTO()
+		data.RTOMet = &met
+	}
+	if sla.RPO != "" && result.RPOMeasured() {
+		rpoMet := result.RPOActual <= sla.GetRPO()
+		data.RPOMet = &rpoMet
+	}
+
+	switch {
+	case (data.RTOMet != nil && !*data.RTOMet) || (data.RPOMet != nil && !*data.RPOMet):
+		data.Status = ControlNotMet
+	case data.RTOMet == nil || (sla.RPO != "" && data.RPOMet == nil):
+		data.Status = ControlNotMeasured
+	default:
+		data.Status = ControlMet
+	}
+	return data
+}
+
+// formatSLA renders the SLA section of the Markdown report
+func formatSLA(result *runner.DrillResult, data *SLAData) string {
+	var b strings.Builder
+	name := data.ID
+	if data.Description != "" {
+		name += " — " + data.Description
+	}
+	b.WriteString(fmt.Sprintf("**SLA:** %s (from `%s`)\n\n", name, data.Path))
+	b.WriteString("| Commitment | SLA | Actual | Status |\n")
+	b.WriteString("|------------|-----|--------|--------|\n")
+	b.WriteString(fmt.Sprintf("| RTO | %s | %s | %s |\n", data.RTO, formatRTA(result), slaCommitmentStatus(data.RTOMet)))
+	if data.RPO != "" {
+		b.WriteString(fmt.Sprintf("| RPO | %s | %s | %s |\n", data.RPO, formatRPOActual(result), slaCommitmentStatus(data.RPOMet)))
+	}
+	b.WriteString("\n")
+	switch data.Status {
+	case ControlMet:
+		b.WriteString("✅ The drill validated this SLA.\n\n")
+	case ControlNotMet:
+		b.WriteString("❌ The drill did not meet this SLA.\n\n")
+	default:
+		b.WriteString("➖ The drill did not measure every commitment of this SLA.\n\n")
+	}
+	return b.String()
+}
+
+func slaCommitmentStatus(met *bool) string {
+	switch {
+	case met == nil:
+		return "➖ Not measured"
+	case *met:
+		return "✅ MET"
+	}
+	return "❌ NOT MET"
+}
@@ -0,0 +1,152 @@
+package report
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// SystemMetricsFileName is the time series of system metric samples in the output directory
+const SystemMetricsFileName = "system-metrics.csv"
+
+// sparkLevels draw a value from the lowest to the highest of its series
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// WriteSystemMetrics writes the system metric samples as CSV: a time column,
+// one column per metric, and the sampling error if any
+func WriteSystemMetrics(result *runner.DrillResult, outputDir string) error {
+	if len(result.SystemSamples) == 0 {
+		return nil
+	}
+	names := result.SystemMetricNames()
+
+	f, err := os.Create(filepath.Join(outputDir, SystemMetricsFileName))
+	if err != nil {
+		return fmt.Errorf("failed to write system metrics: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write(append(append([]string{"time"}, names...), "error"))
+	for _, s := range result.SystemSamples {
+		row := []string{s.Time.Format(time.RFC3339Nano)}
+		for _, name := range names {
+			v, ok := s.Values[name]
+			if !ok {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+		w.Write(append(row, s.Error))
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write system metrics: %w", err)
+	}
+	return f.Close()
+}
+
+// formatSystemMetrics summarizes each system metric and charts it over the
+// run, on the same scale as the timeline so spikes line up with the downtime
+func formatSystemMetrics(result *runner.DrillResult) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%d samples, full series in `%s`.\n\n", len(result.SystemSamples), SystemMetricsFileName))
+	b.WriteString("| Metric | Min | Avg | Max | Max During Downtime | Chart |\n")
+	b.WriteString("|--------|-----|-----|-----|---------------------|-------|\n")
+
+	for _, name := range result.SystemMetricNames() {
+		lowest, highest := math.Inf(1), math.Inf(-1)
+		sum, n := 0.0, 0
+		downtimeMax, inDowntime := math.Inf(-1), false
+		for _, s := range result.SystemSamples {
+			v, ok := s.Values[name]
+			if !ok {
+				continue
+			}
+			lowest, highest = math.Min(lowest, v), math.Max(highest, v)
+			sum += v
+			n++
+			if !result.RTOStartTime.IsZero() && !s.Time.Before(result.RTOStartTime) && !s.Time.After(result.RTOEndTime) {
+				downtimeMax, inDowntime = math.Max(downtimeMax, v), true
+			}
+		}
+		downtime := "-"
+		if inDowntime {
+			downtime = formatSystemValue(downtimeMax)
+		}
+		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | `%s` |\n",
+			name, formatSystemValue(lowest), formatSystemValue(sum/float64(n)), formatSystemValue(highest),
+			downtime, systemSparkline(result, name, lowest, highest)))
+	}
+	if !result.RTOStartTime.IsZero() && !result.RTOEndTime.IsZero() {
+		b.WriteString(fmt.Sprintf("| **Downtime (RTA)** | | | | | `%s` |\n",
+			timelineBar(result, timelineRow{start: result.RTOStartTime, end: result.RTOEndTime})))
+	}
+	b.WriteString("\n")
+
+	var incomplete []runner.SystemSample
+	for _, s := range result.SystemSamples {
+		if s.Error != "" {
+			incomplete = append(incomplete, s)
+		}
+	}
+	if len(incomplete) > 0 {
+		b.WriteString(fmt.Sprintf("%d of %d samples were incomplete; the first at %s: %s\n\n",
+			len(incomplete), len(result.SystemSamples), incomplete[0].Time.Format(time.RFC3339), incomplete[0].Error))
+	}
+	return b.String()
+}
+
+// systemSparkline draws the highest value in each timeline column; columns
+// without a sample are left blank
+func systemSparkline(result *runner.DrillResult, name string, lowest, highest float64) string {
+	span := result.EndTime.Sub(result.StartTime)
+	columns := make([]float64, timelineWidth)
+	filled := make([]bool, timelineWidth)
+	for _, s := range result.SystemSamples {
+		v, ok := s.Values[name]
+		if !ok {
+			continue
+		}
+		col := 0
+		if span > 0 {
+			col = min(max(int(float64(s.Time.Sub(result.StartTime))/float64(span)*timelineWidth), 0), timelineWidth-1)
+		}
+		if !filled[col] || v > columns[col] {
+			columns[col], filled[col] = v, true
+		}
+	}
+
+	var b strings.Builder
+	for i, v := range columns {
+		if !filled[i] {
+			b.WriteRune(' ')
+			continue
+		}
+		// Scaled as displayed, so noise below the shown precision stays flat
+		level := 0
+		if low, high := roundSystemValue(lowest), roundSystemValue(highest); high > low {
+			level = int((roundSystemValue(v) - low) / (high - low) * float64(len(sparkLevels)-1))
+		}
+		b.WriteRune(sparkLevels[level])
+	}
+	return b.String()
+}
+
+// roundSystemValue rounds a system metric value to the two decimals shown
+func roundSystemValue(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// formatSystemValue formats a system metric value with up to two decimals
+func formatSystemValue(v float64) string {
+	return strconv.FormatFloat(roundSystemValue(v), 'f', -1, 64)
+}
@@ -0,0 +1,184 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// timelineWidth is the number of characters in a timeline bar
+const timelineWidth = 30
+
+// phaseLabels names the drill phases in the timeline
+var phaseLabels = map[string]string{
+	runner.PhaseBackupFreshness:  "Backup freshness check",
+	runner.PhaseRestore:          "Backup restore",
+	runner.PhaseSeed:             "Seeding",
+	runner.PhasePreSnapshot:      "Pre-snapshot",
+	runner.PhaseGuards:           "Guards",
+	runner.PhaseMarkers:          "Marker lead",
+	runner.PhaseDisrupt:          "Disruption",
+	runner.PhasePostDisruptDelay: "Post-disrupt delay",
+	runner.PhaseDetectDowntime:   "Detect downtime",
+	runner.PhaseRecover:          "Recovery",
+	runner.PhaseRTAMeasurement:   "RTA measurement",
+	runner.PhaseAbort:            "Abort (forced recovery)",
+	runner.PhasePostSnapshot:     "Post-snapshot",
+	runner.PhaseRPOVerify:        "RPO verification",
+	runner.PhaseConsistency:      "Consistency checks",
+	runner.PhaseSwitchback:       "Switchback",
+	runner.PhaseFactors:          "Factor collection",
+}
+
+// Timeline rows that are not drill phases
+const (
+	timelineDowntime = "downtime"
+	timelineDegraded = "degraded"
+	timelinePaused   = "paused"
+)
+
+// timelineRow is one bar of the timeline
+type timelineRow struct {
+	name  string // Phase name, or one of the timeline row names above
+	label string
+	start time.Time
+	end   time.Time
+}
+
+// timelineRows lists the drill's phases, downtime, and pauses in start order
+func timelineRows(result *runner.DrillResult) []timelineRow {
+	var rows []timelineRow
+	for _, p := range result.Phases {
+		label := phaseLabels[p.Name]
+		if label == "" {
+			label = p.Name
+		}
+		rows = append(rows, timelineRow{name: p.Name, label: label, start: p.Start, end: p.End})
+	}
+	if !result.RTOStartTime.IsZero() && !result.RTOEndTime.IsZero() {
+		rows = append(rows, timelineRow{name: timelineDowntime, label: "Downtime (RTA)", start: result.RTOStartTime, end: result.RTOEndTime})
+	}
+	if !result.DegradedStartTime.IsZero() {
+		rows = append(rows, timelineRow{name: timelineDegraded, label: "Degraded (latency over budget)", start: result.DegradedStartTime, end: result.DegradedEndTime})
+	}
+	for _, p := range result.Pauses {
+		rows = append(rows, timelineRow{name: timelinePaused, label: fmt.Sprintf("Paused (before %s)", p.Phase), start: p.Start, end: p.End})
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].start.Before(rows[j].start) })
+	return rows
+}
+
+// formatTimeline renders the drill's phases, downtime, and pauses as a
+// Gantt-style table over the run from start to end
+func formatTimeline(result *runner.DrillResult) string {
+	rows := timelineRows(result)
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("**Start:** %s, **End:** %s (%s)\n\n",
+		result.StartTime.Format(time.RFC3339),
+		result.EndTime.Format(time.RFC3339),
+		formatDuration(result.EndTime.Sub(result.StartTime))))
+	if len(rows) == 0 {
+		return b.String()
+	}
+
+	b.WriteString("| Phase | Start | End | Duration | Timeline |\n")
+	b.WriteString("|-------|-------|-----|----------|----------|\n")
+	for _, row := range rows {
+		end := "-"
+		duration := "-"
+		if !row.end.IsZero() {
+			end = row.end.Format(time.RFC3339)
+			duration = formatDuration(row.end.Sub(row.start))
+		}
+		label := row.label
+		if row.name == timelineDowntime {
+			label = "**" + label + "**"
+		}
+		b.WriteString(fmt.Sprintf("| %s | %s | %s | %s | `%s` |\n",
+			label, row.start.Format(time.RFC3339), end, duration, timelineBar(result, row)))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// timelineBar draws row's position within the run; every row gets at least
+// one mark so short phases stay visible
+func timelineBar(result *runner.DrillResult, row timelineRow) string {
+	span := result.EndTime.Sub(result.StartTime)
+	if span <= 0 {
+		return strings.Repeat("█", timelineWidth)
+	}
+	end := row.end
+	if end.IsZero() {
+		end = result.EndTime
+	}
+	column := func(t time.Time) float64 {
+		return float64(t.Sub(result.StartTime)) / float64(span) * timelineWidth
+	}
+	from := min(max(int(column(row.start)), 0), timelineWidth-1)
+	to := min(max(int(math.Ceil(column(end))), from+1), timelineWidth)
+	return strings.Repeat("·", from) + strings.Repeat("█", to-from) + strings.Repeat("·", timelineWidth-to)
+}
+
+// formatDowntimeDetails renders how RTA was measured and counted, and when
+// the data became stable
+func formatDowntimeDetails(result *runner.DrillResult) string {
+	var b strings.Builder
+	b.WriteString("| Measure | Timestamp | Duration |\n")
+	b.WriteString("|---------|-----------|----------|\n")
+
+	switch {
+	case !result.RTOStartTime.IsZero():
+		b.WriteString(fmt.Sprintf("| RTA start (service down) | %s | - |\n",
+			result.RTOStartTime.Format(time.RFC3339)))
+		b.WriteString(fmt.Sprintf("| RTA end (service healthy) | %s | %s |\n",
+			result.RTOEndTime.Format(time.RFC3339),
+			formatDuration(result.RTA)))
+		if result.HealthyAfter > 1 && !result.RecoveryConfirmedTime.IsZero() {
+			b.WriteString(fmt.Sprintf("| Recovery confirmed (%d consecutive healthy checks) | %s | - |\n",
+				result.HealthyAfter, result.RecoveryConfirmedTime.Format(time.RFC3339)))
+		}
+		b.WriteString(fmt.Sprintf("| RTA (measured downtime) | - | %s |\n",
+			formatDuration(result.RTA)))
+		if skew := result.ClockSkew(); skew >= time.Second || skew <= -time.Second {
+			b.WriteString(fmt.Sprintf("| RTA on the wall clock (system clock changed by %s; RTA above uses the monotonic clock) | - | %s |\n",
+				formatDuration(skew), formatDuration(result.WallClockRTA)))
+		}
+		if result.DowntimeGrace > 0 {
+			b.WriteString(fmt.Sprintf("| Expected downtime grace | - | %s |\n",
+				formatDuration(result.DowntimeGrace)))
+		}
+		if paused := result.PausedDowntime(); paused > 0 {
+			b.WriteString(fmt.Sprintf("| Paused during downtime | - | %s |\n",
+				formatDuration(paused)))
+		}
+		if result.DowntimeGrace > 0 || result.PausedDowntime() > 0 {
+			b.WriteString(fmt.Sprintf("| RTA counted against RTO | - | %s |\n",
+				formatDuration(result.CountedRTA())))
+		}
+	case result.BackupStale:
+		b.WriteString("| RTA | Drill not run (stale backup) | N/A |\n")
+	case result.GuardFailed:
+		b.WriteString("| RTA | Drill not run (guard failed) | N/A |\n")
+	default:
+		b.WriteString("| RTA | Disruption did not cause downtime | N/A |\n")
+	}
+	b.WriteString(fmt.Sprintf("| RTO (target) | - | %s |\n", formatDuration(result.RTOTarget)))
+
+	if stable := result.DataStableAt(); !stable.IsZero() {
+		b.WriteString(fmt.Sprintf("| Data stable | %s | - |\n", stable.Format(time.RFC3339)))
+	}
+	if result.Switchback != nil {
+		b.WriteString(fmt.Sprintf("| Switchback end (service healthy) | %s | %s |\n",
+			result.SwitchbackEndTime.Format(time.RFC3339),
+			formatDuration(result.SwitchbackTime)))
+	}
+
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,277 @@
+package report
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// TrendRun is one run of a scenario in an RTA trend
+type TrendRun struct {
+	StartTime  time.Time
+	RTA        time.Duration
+	RTOTarget  time.Duration // Zero if the run had no RTO target
+	Passed     bool
+	ReportPath string
+}
+
+// Trend is the RTA of a scenario's runs over time, with a line fitted through it
+type Trend struct {
+	Scenario string
+	Runs     []TrendRun // Oldest first
+
+	Start     time.Duration // Fitted RTA of the first run
+	End       time.Duration // Fitted RTA of the latest run
+	PerRun    time.Duration // Fitted RTA change from one run to the next
+	Change    float64       // Fitted RTA change across the runs, as a fraction of Start
+	Worsening bool
+
+	// RunsToBreach is how many more runs it takes the fitted RTA to exceed
+	// the latest RTO target at the current rate, if worsening and still
+	// within it; 0 otherwise
+	RunsToBreach int
+}
+
+const (
+	// TrendMinRuns is the fewest runs a trend is fitted through
+	TrendMinRuns = 3
+
+	// trendWorseningChange is the fitted RTA growth across the runs that
+	// counts as worsening, so noise between runs is not flagged
+	trendWorseningChange = 0.10
+)
+
+// AnalyzeTrend fits a least-squares line through the RTAs of runs, oldest
+// first, and flags the trend as worsening when the fitted RTA grew by 10% or
+// more, whether or not the runs met their RTO target
+func AnalyzeTrend(scenario string, runs []TrendRun) *Trend {
+	t := &Trend{Scenario: scenario, Runs: runs}
+	n := len(runs)
+	if n < TrendMinRuns {
+		return t
+	}
+
+	var meanX, meanY float64
+	for i, r := range runs {
+		meanX += float64(i)
+		meanY += float64(r.RTA)
+	}
+	meanX /= float64(n)
+	meanY /= float64(n)
+	var cov, variance float64
+	for i, r := range runs {
+		cov += (float64(i) - meanX) * (float64(r.RTA) - meanY)
+		variance += (float64(i) - meanX) * (float64(i) - meanX)
+	}
+	slope := cov / variance
+	start := meanY - slope*meanX
+	end := start + slope*float64(n-1)
+
+	t.Start, t.End, t.PerRun = time.Duration(start), time.Duration(end), time.Duration(slope)
+	base := start
+	if base <= 0 {
+		base = meanY
+	}
+	if base > 0 {
+		t.Change = (end - start) / base
+	}
+	t.Worsening = slope > 0 && t.Change >= trendWorseningChange
+
+	target := runs[n-1].RTOTarget
+	if t.Worsening && target > 0 && t.End < target {
+		t.RunsToBreach = int(math.Floor(float64(target-t.End)/slope)) + 1
+	}
+	return t
+}
+
+// Summary states in one line whether the RTA is worsening
+func (t *Trend) Summary() string {
+	n := len(t.Runs)
+	if n < TrendMinRuns {
+		return fmt.Sprintf("Not enough runs for a trend (%d of at least %d)", n, TrendMinRuns)
+	}
+	change := fmt.Sprintf("%+.0f%% over %d runs", t.Change*100, n)
+	if !t.Worsening {
+		if t.PerRun < 0 {
+			return "✅ RTA improving: " + change
+		}
+		return "✅ RTA stable: " + change
+	}
+
+	s := fmt.Sprintf("⚠️ RTA worsening: %s (+%s per run)", change, formatDuration(t.PerRun))
+	latest := t.Runs[n-1]
+	if latest.Passed {
+		s += ", although the latest run passed"
+	}
+	if t.RunsToBreach > 0 {
+		s += fmt.Sprintf("; at this rate the %s RTO target is exceeded in about %d runs", formatDuration(latest.RTOTarget), t.RunsToBreach)
+	}
+	return s
+}
+
+// sparkBlocks are the bars of a sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws the runs' RTAs as one bar each, scaled from the fastest
+// run to the slowest
+func (t *Trend) Sparkline() string {
+	if len(t.Runs) == 0 {
+		return ""
+	}
+	lo, hi := t.Runs[0].RTA, t.Runs[0].RTA
+	for _, r := range t.Runs {
+		lo, hi = min(lo, r.RTA), max(hi, r.RTA)
+	}
+	var b strings.Builder
+	for _, r := range t.Runs {
+		i := len(sparkBlocks) / 2
+		if hi > lo {
+			i = int(float64(r.RTA-lo) / float64(hi-lo) * float64(len(sparkBlocks)-1))
+		}
+		b.WriteRune(sparkBlocks[i])
+	}
+	return b.String()
+}
+
+// GenerateTrendHTMLReport renders the trend as a standalone HTML page, with
+// the chart embedded
+func GenerateTrendHTMLReport(t *Trend) string {
+	var s strings.Builder
+	s.WriteString(fmt.Sprintf("# RTA Trend: %s\n\n", t.Scenario))
+	s.WriteString(fmt.Sprintf("**Trend:** %s\n\n", t.Summary()))
+	if len(t.Runs) > 0 {
+		chart := base64.StdEncoding.EncodeToString([]byte(RenderTrendChart(t)))
+		s.WriteString(fmt.Sprintf("![RTA trend](data:image/svg+xml;base64,%s)\n\n", chart))
+	}
+
+	s.WriteString("## Runs\n\n")
+	s.WriteString("| # | Started | RTA | RTO Target | Result | Report |\n")
+	s.WriteString("|---|---------|-----|------------|--------|--------|\n")
+	for i, r := range t.Runs {
+		target := "-"
+		if r.RTOTarget > 0 {
+			target = formatDuration(r.RTOTarget)
+		}
+		s.WriteString(fmt.Sprintf("| %d | %s | %s | %s | %s | `%s` |\n",
+			i+1, r.StartTime.Format(time.RFC3339), formatDuration(r.RTA), target, passFail(r.Passed), r.ReportPath))
+	}
+	s.WriteString("\n")
+	return GenerateHTMLReport(s.String())
+}
+
+// Trend chart geometry, in pixels; the width and margins match the health
+// check chart
+const (
+	trendChartTop    = 40
+	trendChartH      = 220
+	trendChartAxisY  = trendChartTop + trendChartH
+	trendChartHeight = trendChartAxisY + 60
+)
+
+// RenderTrendChart draws the RTA of each run, colored by whether it passed,
+// with the RTO target and the fitted trend line
+func RenderTrendChart(t *Trend) string {
+	n := len(t.Runs)
+	x := func(i float64) float64 {
+		if n < 2 {
+			return chartLeft + chartPlotWidth/2
+		}
+		return chartLeft + i/float64(n-1)*chartPlotWidth
+	}
+	slowest := time.Duration(0)
+	for _, r := range t.Runs {
+		slowest = max(slowest, r.RTA, r.RTOTarget)
+	}
+	slowest = max(slowest, t.Start, t.End)
+	top := chartLatencyScale(slowest)
+	y := func(d time.Duration) float64 {
+		return trendChartAxisY - float64(max(d, 0))/float64(top)*trendChartH
+	}
+
+	var b strings.Builder
+	title := html.EscapeString(t.Scenario + ": RTA trend")
+	b.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" role="img" aria-label="%s">`+"\n",
+		chartWidth, trendChartHeight, chartWidth, trendChartHeight, title))
+	b.WriteString(fmt.Sprintf("  <title>%s</title>\n", title))
+	b.WriteString(`  <rect width="100%" height="100%" fill="#fff"/>` + "\n")
+	b.WriteString(`  <g font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11" fill="#333">` + "\n")
+	b.WriteString(fmt.Sprintf(`    <text x="%d" y="20" font-size="13" font-weight="bold">%s</text>`+"\n", chartLeft, title))
+
+	// RTA axis and gridlines
+	for _, f := range []float64{0, 0.5, 1} {
+		d := time.Duration(float64(top) * f)
+		gy := y(d)
+		b.WriteString(fmt.Sprintf(`    <line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#ddd"/>`+"\n", chartLeft, gy, chartWidth-chartRight, gy))
+		b.WriteString(fmt.Sprintf(`    <text x="%d" y="%.1f" text-anchor="end">%s</text>`+"\n", chartLeft-8, gy+4, formatDuration(d)))
+	}
+
+	// RTO target of the latest run
+	if n > 0 && t.Runs[n-1].RTOTarget > 0 {
+		target := t.Runs[n-1].RTOTarget
+		b.WriteString(fmt.Sprintf(`    <line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#e05d44" stroke-dasharray="6,4"/>`+"\n",
+			chartLeft, y(target), chartWidth-chartRight, y(target)))
+		b.WriteString(fmt.Sprintf(`    <text x="%d" y="%.1f" text-anchor="end" fill="#e05d44">RTO %s</text>`+"\n",
+			chartWidth-chartRight, y(target)-4, formatDuration(target)))
+	}
+
+	// Fitted trend, amber when worsening
+	trendColor := "#888"
+	if t.Worsening {
+		trendColor = chartStateColors[runner.StateDegraded]
+	}
+	if n >= TrendMinRuns {
+		b.WriteString(fmt.Sprintf(`    <line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="2" stroke-dasharray="3,3"/>`+"\n",
+			x(0), y(t.Start), x(float64(n-1)), y(t.End), trendColor))
+	}
+
+	// RTA line, with each run colored by whether it passed
+	points := make([]string, n)
+	for i, r := range t.Runs {
+		points[i] = fmt.Sprintf("%.1f,%.1f", x(float64(i)), y(r.RTA))
+	}
+	b.WriteString(fmt.Sprintf(`    <polyline points="%s" fill="none" stroke="#888" stroke-width="1"/>`+"\n", strings.Join(points, " ")))
+	for i, r := range t.Runs {
+		b.WriteString(fmt.Sprintf(`    <circle cx="%.1f" cy="%.1f" r="3.5" fill="%s"><title>%s: %s</title></circle>`+"\n",
+			x(float64(i)), y(r.RTA), trendRunColor(r), r.StartTime.Format("2006-01-02 15:04"), formatDuration(r.RTA)))
+	}
+
+	// Run axis, labelled with the start date of up to five runs
+	b.WriteString(fmt.Sprintf(`    <line x1="%d" y1="%d" x2="%d" y2="%d" stroke="#333"/>`+"\n", chartLeft, trendChartAxisY, chartWidth-chartRight, trendChartAxisY))
+	labelled := map[int]bool{}
+	for k := 0; k <= 4 && n > 0; k++ {
+		i := int(math.Round(float64(k) / 4 * float64(n-1)))
+		if labelled[i] {
+			continue
+		}
+		labelled[i] = true
+		b.WriteString(fmt.Sprintf(`    <text x="%.1f" y="%d" text-anchor="middle">%s</text>`+"\n",
+			x(float64(i)), trendChartAxisY+16, t.Runs[i].StartTime.Format("2006-01-02")))
+	}
+
+	// Legend
+	lx := chartLeft
+	for _, item := range []struct{ label, color string }{
+		{"passed", chartStateColors[runner.StateHealthy]},
+		{"failed", chartStateColors[runner.StateDown]},
+		{"trend", trendColor},
+	} {
+		b.WriteString(fmt.Sprintf(`    <rect x="%d" y="%d" width="10" height="10" fill="%s"/><text x="%d" y="%d">%s</text>`+"\n",
+			lx, trendChartAxisY+32, item.color, lx+14, trendChartAxisY+41, item.label))
+		lx += 90
+	}
+	b.WriteString("  </g>\n")
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+func trendRunColor(r TrendRun) string {
+	if r.Passed {
+		return chartStateColors[runner.StateHealthy]
+	}
+	return chartStateColors[runner.StateDown]
+}
@@ -0,0 +1,123 @@
+package runner
+
+import (
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// AssertionResult is the outcome of one scenario assertion
+type AssertionResult struct {
+	Expression string
+	Passed     bool
+	Error      string // Why the expression could not be evaluated; the assertion failed
+}
+
+// AssertionsPassed reports whether every assertion held
+func (d *DrillResult) AssertionsPassed() bool {
+	for _, a := range d.Assertions {
+		if !a.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// checkAssertions evaluates the scenario's assertions against the result.
+// An assertion that can't be evaluated, for example because it reads a value
+// that was not measured, fails.
+func checkAssertions(assertions []string, result *DrillResult) {
+	result.Assertions = nil
+	env := result.assertionEnv()
+	for _, source := range assertions {
+		a := AssertionResult{Expression: source}
+		expr, err := config.ParseExpression(source)
+		if err == nil {
+			a.Passed, err = expr.EvalBool(env)
+		}
+		if err != nil {
+			a.Error = err.Error()
+		}
+		result.Assertions = append(result.Assertions, a)
+	}
+}
+
+// assertionEnv returns the variables in config.AssertionVariables. Values
+// that were not measured are nil.
+func (d *DrillResult) assertionEnv() map[string]interface{} {
+	seconds := func(v time.Duration, ok bool) interface{} {
+		if !ok {
+			return nil
+		}
+		return v.Seconds()
+	}
+
+	env := map[string]interface{}{
+		"rta":                d.CountedRTA().Seconds(),
+		"rto_target":         d.RTOTarget.Seconds(),
+		"rto_passed":         d.RTOStartTime.IsZero() || d.RTOPassed,
+		"went_down":          !d.RTOStartTime.IsZero(),
+		"failed_probes":      float64(d.FailedProbes),
+		"time_to_detect":     seconds(d.TimeToDetect()),
+		"recovery_execution": seconds(d.RecoveryExecution()),
+		"time_to_validate":   seconds(d.TimeToValidate()),
+		"degraded":           d.DegradedDuration.Seconds(),
+		"rpo_actual":         seconds(d.RPOActual, d.RPOMeasured()),
+		"rpo_target":         seconds(d.RPOTarget, d.RPOTarget > 0),
+		"rpo_passed":         d.RPOPassed,
+		"missing_rows":       nil,
+		"switchback_time":    seconds(d.SwitchbackTime, d.Switchback != nil),
+		"aborted":            d.Aborted,
+		"errors":             float64(len(d.Errors)),
+	}
+	if d.MissingRows != nil {
+		env["missing_rows"] = float64(*d.MissingRows)
+	}
+	env["failed_percent"], env["error_minutes"] = 0.0, 0.0
+	if budget, ok := d.ErrorBudget(); ok {
+		env["failed_percent"], env["error_minutes"] = budget.FailedPercent(), budget.ErrorMinutes()
+	}
+
+	latency := map[string]interface{}{}
+	for _, s := range d.ProbeLatencyStats() {
+		latency[s.Span] = map[string]interface{}{
+			"p50": s.P50.Seconds(),
+			"p90": s.P90.Seconds(),
+			"p99": s.P99.Seconds(),
+			"max": s.Max.Seconds(),
+		}
+	}
+	env["probe_latency"] = latency
+
+	_, durations := d.HealthStateTimes()
+	env["time_degraded"] = durations[StateDegraded].Seconds()
+
+	after := map[string]interface{}{}
+	before := map[string]interface{}{}
+	change := map[string]interface{}{}
+	for i := range d.Metrics {
+		m := &d.Metrics[i]
+		after[m.Name] = m.After.exprValue()
+		before[m.Name] = m.Before.exprValue()
+		change[m.Name] = nil
+		if delta, ok := m.Delta(); ok {
+			change[m.Name] = delta
+		}
+	}
+	env["metrics"] = after
+	env["metrics_before"] = before
+	env["metrics_change"] = change
+	return env
+}
+
+// exprValue returns the sample as an expression value: a number when it is
+// one, otherwise text, and nil when it was not read
+func (s *MetricSample) exprValue() interface{} {
+	if s == nil || s.Value == "" {
+		return nil
+	}
+	if v, ok := s.Number(); ok {
+		return v
+	}
+	return s.Value
+}
@@ -0,0 +1,58 @@
+package runner
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// azureCommand renders a built-in Azure action as an az CLI invocation, for
+// the same reasons gcpCommand uses gcloud
+func azureCommand(a *config.AzureAction) string {
+	var args []string
+	switch a.Action {
+	case config.AzureVMDeallocate:
+		args = []string{"az", "vm", "deallocate", "--resource-group", a.ResourceGroup, "--name", a.Name}
+	case config.AzureVMRestart:
+		args = []string{"az", "vm", "restart", "--resource-group", a.ResourceGroup, "--name", a.Name}
+	case config.AzureVMStart:
+		args = []string{"az", "vm", "start", "--resource-group", a.ResourceGroup, "--name", a.Name}
+	case config.AzureSQLFailoverGroup:
+		args = []string{"az", "sql", "failover-group", "set-primary",
+			"--resource-group", a.ResourceGroup, "--server", a.Server, "--name", a.Name}
+	case config.AzureMonitorMetrics:
+		args = []string{"az", "monitor", "metrics", "list",
+			"--resource", a.Resource, "--metrics", a.Metric,
+			"--offset", azureOffset(a.GetWindow()), "--output", "json"}
+	case config.AzureActivityLog:
+		args = []string{"az", "monitor", "activity-log", "list",
+			"--resource-group", a.ResourceGroup,
+			"--offset", azureOffset(a.GetWindow()), "--output", "json"}
+	default:
+		return fmt.Sprintf("echo %s >&2; exit 1", shellQuote(fmt.Sprintf("unknown azure action %q", a.Action)))
+	}
+
+	if a.Subscription != "" {
+		args = append(args, "--subscription", a.Subscription)
+	}
+
+	command := quoteArgs(args)
+	if a.ManagedIdentity {
+		login := []string{"az", "login", "--identity"}
+		if a.ClientID != "" {
+			login = append(login, "--username", a.ClientID)
+		}
+		command = quoteArgs(login) + " --output none && " + command
+	}
+	return command
+}
+
+// azureOffset formats a look-back period in the az CLI's ##h##m offset format
+func azureOffset(d time.Duration) string {
+	minutes := int(d.Minutes())
+	if minutes < 1 {
+		minutes = 1
+	}
+	return fmt.Sprintf("%dh%dm", minutes/60, minutes%60)
+}
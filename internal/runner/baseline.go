@@ -0,0 +1,37 @@
+package runner
+
+import "time"
+
+// Baseline is the run of the same scenario a drill's RTA is compared against,
+// set by the caller from the run history
+type Baseline struct {
+	ReportPath    string // Run directory of the baseline run
+	StartTime     time.Time
+	RTA           time.Duration
+	MaxRegression *float64 // Percent the RTA may exceed the baseline's by; nil if not gated
+}
+
+// RTAChange returns how much rta differs from the baseline's, as a percentage
+// of it. It is only defined when the baseline had downtime.
+func (b *Baseline) RTAChange(rta time.Duration) (float64, bool) {
+	if b.RTA <= 0 {
+		return 0, false
+	}
+	return float64(rta-b.RTA) / float64(b.RTA) * 100, true
+}
+
+// BaselineCompared reports whether the drill measured an RTA that can be
+// compared against its baseline
+func (d *DrillResult) BaselineCompared() bool {
+	return d.Baseline != nil && !d.Incomplete && !d.BackupStale && !d.GuardFailed
+}
+
+// BaselineRegressed reports whether the RTA grew past the baseline's by more
+// than the allowed regression
+func (d *DrillResult) BaselineRegressed() bool {
+	if !d.BaselineCompared() || d.Baseline.MaxRegression == nil {
+		return false
+	}
+	change, ok := d.Baseline.RTAChange(d.RTA)
+	return ok && change > *d.Baseline.MaxRegression
+}
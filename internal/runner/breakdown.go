@@ -0,0 +1,34 @@
+package runner
+
+import "time"
+
+// The recovery breakdown splits an outage into the parts a team can improve
+// separately: noticing the failure, running the recovery, and confirming it
+// worked. Each part is only defined when the events that bound it happened.
+
+// TimeToDetect returns the time from issuing the disruption to the first
+// failed health check
+func (d *DrillResult) TimeToDetect() (time.Duration, bool) {
+	if d.Disrupt == nil || d.RTOStartTime.IsZero() {
+		return 0, false
+	}
+	return max(d.RTOStartTime.Sub(d.Disrupt.Timestamp), 0), true
+}
+
+// RecoveryExecution returns how long recover_command ran
+func (d *DrillResult) RecoveryExecution() (time.Duration, bool) {
+	if d.Recover == nil || d.RTOStartTime.IsZero() {
+		return 0, false
+	}
+	return d.Recover.Duration, true
+}
+
+// TimeToValidate returns the time from recover_command finishing to the first
+// healthy check of the recovery. It is negative when the service was healthy
+// before recover_command finished.
+func (d *DrillResult) TimeToValidate() (time.Duration, bool) {
+	if d.Recover == nil || d.RTOStartTime.IsZero() || d.RecoveryConfirmedTime.IsZero() {
+		return 0, false
+	}
+	return d.RTOEndTime.Sub(d.Recover.Timestamp.Add(d.Recover.Duration)), true
+}
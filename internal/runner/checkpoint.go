@@ -0,0 +1,92 @@
+package runner
+
+import (
+	"context"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// phaseOrder lists the phases in the order a drill runs them
+var phaseOrder = []string{
+	PhaseBackupFreshness,
+	PhaseRestore,
+	PhaseSeed,
+	PhasePreSnapshot,
+	PhaseGuards,
+	PhaseMarkers,
+	PhaseDisrupt,
+	PhasePostDisruptDelay,
+	PhaseDetectDowntime,
+	PhaseRecover,
+	PhaseRTAMeasurement,
+	PhaseAbort,
+	PhasePostSnapshot,
+	PhaseRPOVerify,
+	PhaseConsistency,
+	PhaseSwitchback,
+	PhaseFactors,
+}
+
+// Phases returns the phase names in the order a drill runs them
+func Phases() []string {
+	return append([]string(nil), phaseOrder...)
+}
+
+// checkpointInterval limits how often health checks trigger a checkpoint
+const checkpointInterval = time.Second
+
+func phaseIndex(phase string) int {
+	for i, p := range phaseOrder {
+		if p == phase {
+			return i
+		}
+	}
+	return -1
+}
+
+// Checkpoint is the state of a drill in progress, persisted so a run whose
+// controller stopped can be resumed without disrupting again
+type Checkpoint struct {
+	Phase  string       `json:"phase"` // Last phase entered
+	Time   time.Time    `json:"time"`
+	Result *DrillResult `json:"result"`
+}
+
+// Disrupted reports whether the disruption had been issued
+func (c *Checkpoint) Disrupted() bool {
+	return phaseIndex(c.Phase) >= phaseIndex(PhaseDisrupt)
+}
+
+// Resume continues a drill from a checkpoint. Completed steps are kept and
+// the step in progress is repeated, except that disrupt_command and the
+// switchback command are never issued again. A checkpoint taken before the
+// disruption runs the drill from the start.
+func (r *Runner) Resume(ctx context.Context, scenario *config.Scenario, checkpoint *Checkpoint) (*DrillResult, error) {
+	if checkpoint.Result == nil || !checkpoint.Disrupted() {
+		return r.Run(ctx, scenario)
+	}
+
+	r.resume = checkpoint
+	defer func() { r.resume = nil }()
+	return r.Run(ctx, scenario)
+}
+
+// resumedPast reports whether the run being resumed had already finished phase
+func (r *Runner) resumedPast(phase string) bool {
+	return r.resume != nil && phaseIndex(phase) < phaseIndex(r.resume.Phase)
+}
+
+// checkpoint reports the current state to Hooks.OnCheckpoint. Unless forced,
+// it is skipped if the last checkpoint was under checkpointInterval ago.
+func (r *Runner) checkpoint(force bool) {
+	if r.hooks.OnCheckpoint == nil || r.current == nil {
+		return
+	}
+	now := time.Now()
+	if !force && now.Sub(r.lastCheckpoint) < checkpointInterval {
+		return
+	}
+	r.lastCheckpoint = now
+	r.hooks.OnCheckpoint(&Checkpoint{Phase: r.phase, Time: now, Result: r.current})
+}
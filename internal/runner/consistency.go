@@ -0,0 +1,117 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// maxShownOutput caps how much of a compared output a failure reason quotes
+const maxShownOutput = 80
+
+// ConsistencyResult is the outcome of one consistency check
+type ConsistencyResult struct {
+	GuardResult
+	Match    string         // "compare_command" or "baseline" when the output had to match another
+	Expected string         // Output the check had to match
+	Baseline *CommandResult // The command before the disruption, for match_baseline
+	Compare  *CommandResult // compare_command after recovery
+}
+
+// ConsistencyPassed reports whether every consistency check passed
+func (d *DrillResult) ConsistencyPassed() bool {
+	for _, c := range d.ConsistencyChecks {
+		if !c.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// hasBaselines reports whether any check compares against its own baseline
+func hasBaselines(checks []config.ConsistencyCheck) bool {
+	for _, check := range checks {
+		if check.MatchBaseline {
+			return true
+		}
+	}
+	return false
+}
+
+// consistencyBaselines runs the checks that compare against their own output
+// before the disruption
+func (r *Runner) consistencyBaselines(ctx context.Context, checks []config.ConsistencyCheck, result *DrillResult) {
+	result.ConsistencyChecks = make([]ConsistencyResult, len(checks))
+	for i, check := range checks {
+		result.ConsistencyChecks[i].Name = check.Name
+		if check.MatchBaseline {
+			result.ConsistencyChecks[i].Baseline = r.runStep(ctx, check.Command)
+		}
+	}
+}
+
+// checkConsistency runs every consistency check after recovery. All checks
+// run even after a failure, so the report shows each one.
+func (r *Runner) checkConsistency(ctx context.Context, checks []config.ConsistencyCheck, result *DrillResult) {
+	if len(result.ConsistencyChecks) != len(checks) {
+		result.ConsistencyChecks = make([]ConsistencyResult, len(checks))
+	}
+	for i, check := range checks {
+		c := &result.ConsistencyChecks[i]
+		c.Name = check.Name
+		c.Condition = check.Condition
+		c.Result = r.runStep(ctx, check.Command)
+
+		switch {
+		case check.CompareCommand.IsSet():
+			c.Match = "compare_command"
+			c.Compare = r.runStep(ctx, check.CompareCommand)
+			c.Reason = compareOutputs(c, c.Compare)
+		case check.MatchBaseline:
+			c.Match = "baseline"
+			c.Reason = compareOutputs(c, c.Baseline)
+		default:
+			c.Reason = evaluateGuard(&c.GuardResult)
+		}
+
+		c.Passed = c.Reason == ""
+		if c.Passed {
+			fmt.Printf("✅ Consistency check %q passed\n", c.Name)
+		} else {
+			result.Errors = append(result.Errors, fmt.Sprintf("consistency check %q failed: %s", c.Name, c.Reason))
+			fmt.Printf("❌ Consistency check %q failed: %s\n", c.Name, c.Reason)
+		}
+	}
+}
+
+// compareOutputs returns why the check's output differs from expected's, or
+// "" if both commands succeeded with the same output
+func compareOutputs(c *ConsistencyResult, expected *CommandResult) string {
+	source := c.Match
+	if !c.Result.Succeeded() {
+		return fmt.Sprintf("command failed with exit code %d", c.Result.ExitCode)
+	}
+	if expected == nil {
+		return fmt.Sprintf("no %s output to compare with", source)
+	}
+	if !expected.Succeeded() {
+		return fmt.Sprintf("%s failed with exit code %d", source, expected.ExitCode)
+	}
+
+	c.Value = strings.TrimSpace(c.Result.Stdout)
+	c.Expected = strings.TrimSpace(expected.Stdout)
+	if c.Value != c.Expected {
+		return fmt.Sprintf("output %q does not match %s output %q", shortOutput(c.Value), source, shortOutput(c.Expected))
+	}
+	return ""
+}
+
+// shortOutput truncates output quoted in a failure reason
+func shortOutput(s string) string {
+	if len(s) > maxShownOutput {
+		return s[:maxShownOutput] + "..."
+	}
+	return s
+}
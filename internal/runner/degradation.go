@@ -0,0 +1,120 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// defaultLatencyWindow is the number of probes evaluated when latency_budget sets no window
+const defaultLatencyWindow = 5
+
+// latencyBudget is the parsed form of config.LatencyBudget
+type latencyBudget struct {
+	percentile float64
+	max        time.Duration
+	window     int
+	maxWait    time.Duration
+}
+
+func newLatencyBudget(lb *config.LatencyBudget, rtoTarget time.Duration) (*latencyBudget, error) {
+	max, err := time.ParseDuration(lb.Max)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latency_budget max: %w", err)
+	}
+	budget := &latencyBudget{
+		percentile: lb.Percentile,
+		max:        max,
+		window:     lb.Window,
+		maxWait:    rtoTarget,
+	}
+	if budget.window == 0 {
+		budget.window = defaultLatencyWindow
+	}
+	if lb.MaxWait != "" {
+		if budget.maxWait, err = time.ParseDuration(lb.MaxWait); err != nil {
+			return nil, fmt.Errorf("invalid latency_budget max_wait: %w", err)
+		}
+	}
+	return budget, nil
+}
+
+// measureDegradation keeps probing a healthy service while its probe latency
+// exceeds the budget, recording the time spent DEGRADED separately from RTA
+func (r *Runner) measureDegradation(ctx context.Context, probe Probe, budget *latencyBudget, result *DrillResult) {
+	latencies := []time.Duration{}
+	if n := len(result.HealthCheckAttempts); n > 0 && result.HealthCheckAttempts[n-1].Up() {
+		latencies = append(latencies, result.HealthCheckAttempts[n-1].Duration)
+	}
+
+	deadline := time.Now().Add(budget.maxWait)
+	for {
+		if len(latencies) > 0 {
+			window := latencies
+			if len(window) > budget.window {
+				window = window[len(window)-budget.window:]
+			}
+			observed := percentile(window, budget.percentile)
+			result.LatencyPercentile = observed
+
+			if observed <= budget.max {
+				if !result.DegradedStartTime.IsZero() {
+					result.DegradedEndTime = time.Now()
+					result.DegradedDuration = result.DegradedEndTime.Sub(result.DegradedStartTime)
+					fmt.Printf("✅ Latency within budget (p%g %s <= %s) - degraded for %s\n",
+						budget.percentile, formatDuration(observed), formatDuration(budget.max), formatDuration(result.DegradedDuration))
+				}
+				return
+			}
+
+			if result.DegradedStartTime.IsZero() {
+				result.DegradedStartTime = time.Now()
+				fmt.Printf("⚠️  Service is DEGRADED (p%g latency %s > budget %s)\n",
+					budget.percentile, formatDuration(observed), formatDuration(budget.max))
+			}
+		}
+
+		if time.Now().After(deadline) {
+			result.DegradedEndTime = time.Now()
+			result.DegradedDuration = result.DegradedEndTime.Sub(result.DegradedStartTime)
+			result.Errors = append(result.Errors, fmt.Sprintf("service still degraded after %s (p%g latency %s > budget %s)",
+				formatDuration(budget.maxWait), budget.percentile, formatDuration(result.LatencyPercentile), formatDuration(budget.max)))
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			if !result.DegradedStartTime.IsZero() {
+				result.DegradedEndTime = time.Now()
+				result.DegradedDuration = result.DegradedEndTime.Sub(result.DegradedStartTime)
+			}
+			return
+		case <-time.After(r.probeInterval):
+		}
+
+		checkCtx, cancel := context.WithTimeout(ctx, r.healthCheckTimeout)
+		attempt := probe.Check(checkCtx)
+		cancel()
+		result.HealthCheckAttempts = append(result.HealthCheckAttempts, *attempt)
+		r.healthChecked(len(result.HealthCheckAttempts), attempt)
+
+		if attempt.Up() {
+			latencies = append(latencies, attempt.Duration)
+		}
+	}
+}
+
+// percentile returns the nearest-rank percentile p (0-100] of the samples
+func percentile(samples []time.Duration, p float64) time.Duration {
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
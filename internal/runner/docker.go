@@ -0,0 +1,198 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// defaultDockerHost is the Docker daemon socket used when neither the
+// execution nor DOCKER_HOST names one
+const defaultDockerHost = "unix:///var/run/docker.sock"
+
+// dockerAPIVersion is the Engine API version requested; 1.41 is supported by Docker 20.10 and later
+const dockerAPIVersion = "v1.41"
+
+// dockerClient talks to the Docker Engine API over its unix socket or TCP
+type dockerClient struct {
+	http    *http.Client
+	baseURL string
+}
+
+func newDockerClient(host string) (*dockerClient, error) {
+	if host == "" {
+		host = os.Getenv("DOCKER_HOST")
+	}
+	if host == "" {
+		host = defaultDockerHost
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid docker host %q: %w", host, err)
+	}
+
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		}
+		return &dockerClient{http: &http.Client{Transport: transport}, baseURL: "http://docker/" + dockerAPIVersion}, nil
+	case "tcp":
+		return &dockerClient{http: &http.Client{}, baseURL: "http://" + u.Host + "/" + dockerAPIVersion}, nil
+	default:
+		return nil, fmt.Errorf("unsupported docker host %q (expected unix:// or tcp://)", host)
+	}
+}
+
+// post sends a JSON request and returns the response for the caller to close
+func (c *dockerClient) post(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, dockerError(resp)
+	}
+	return resp, nil
+}
+
+// dockerError extracts the daemon's error message from a failed response
+func dockerError(resp *http.Response) error {
+	var body struct {
+		Message string `json:"message"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxProbeBodySize))
+	if json.Unmarshal(data, &body) == nil && body.Message != "" {
+		return fmt.Errorf("docker API: %s", body.Message)
+	}
+	return fmt.Errorf("docker API: unexpected status %d", resp.StatusCode)
+}
+
+// exec runs cmd in the container and returns its stdout, stderr, and exit code
+func (c *dockerClient) exec(ctx context.Context, container string, cmd []string) (string, string, int, error) {
+	resp, err := c.post(ctx, "/containers/"+url.PathEscape(container)+"/exec", map[string]interface{}{
+		"AttachStdout": true,
+		"AttachStderr": true,
+		"Cmd":          cmd,
+	})
+	if err != nil {
+		return "", "", -1, err
+	}
+	var created struct {
+		ID string `json:"Id"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&created)
+	resp.Body.Close()
+	if err != nil {
+		return "", "", -1, fmt.Errorf("docker API: invalid exec create response: %w", err)
+	}
+
+	resp, err = c.post(ctx, "/exec/"+created.ID+"/start", map[string]interface{}{"Detach": false, "Tty": false})
+	if err != nil {
+		return "", "", -1, err
+	}
+	var stdout, stderr strings.Builder
+	err = demuxDockerStream(resp.Body, &stdout, &stderr)
+	resp.Body.Close()
+	if err != nil {
+		return stdout.String(), stderr.String(), -1, fmt.Errorf("docker API: reading exec output: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/exec/"+created.ID+"/json", nil)
+	if err != nil {
+		return stdout.String(), stderr.String(), -1, err
+	}
+	resp, err = c.http.Do(req)
+	if err != nil {
+		return stdout.String(), stderr.String(), -1, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return stdout.String(), stderr.String(), -1, dockerError(resp)
+	}
+	var inspect struct {
+		ExitCode int `json:"ExitCode"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&inspect); err != nil {
+		return stdout.String(), stderr.String(), -1, fmt.Errorf("docker API: invalid exec inspect response: %w", err)
+	}
+	return stdout.String(), stderr.String(), inspect.ExitCode, nil
+}
+
+// demuxDockerStream splits Docker's multiplexed attach stream, in which each
+// frame has an 8-byte header: stream type (1 = stdout, 2 = stderr), three
+// zero bytes, and a big-endian uint32 payload length
+func demuxDockerStream(r io.Reader, stdout, stderr io.Writer) error {
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		w := stdout
+		if header[0] == 2 {
+			w = stderr
+		}
+		if _, err := io.CopyN(w, r, int64(binary.BigEndian.Uint32(header[4:]))); err != nil {
+			return err
+		}
+	}
+}
+
+// dockerExec runs argv in a container via the Docker Engine API
+func (r *Runner) dockerExec(ctx context.Context, e *config.Execution, command string, argv []string) *CommandResult {
+	result := &CommandResult{
+		Command:   command,
+		Timestamp: time.Now(),
+		Host:      "container/" + e.Container,
+	}
+
+	r.trace("docker_exec_start", command, map[string]interface{}{"argv": argv, "container": e.Container, "host": e.Host})
+	start := time.Now()
+	client, err := newDockerClient(e.Host)
+	if err == nil {
+		result.Stdout, result.Stderr, result.ExitCode, err = client.exec(ctx, e.Container, argv)
+	}
+	if err != nil {
+		result.ExitCode = -1
+		if result.Stderr != "" && !strings.HasSuffix(result.Stderr, "\n") {
+			result.Stderr += "\n"
+		}
+		result.Stderr += err.Error()
+	}
+	result.Duration = time.Since(start)
+	result.StdoutHash = hashString(result.Stdout)
+	result.StderrHash = hashString(result.Stderr)
+	r.redactor.apply(result)
+	r.traceExecEnd(result)
+
+	return result
+}
@@ -0,0 +1,54 @@
+package runner
+
+import "time"
+
+// ErrorBudget is what clients saw during the outage, estimated from the
+// health checks: each one stands in for a synthetic user request
+type ErrorBudget struct {
+	Window time.Duration // Disruption issued to confirmed recovery
+	Probes int           // Health checks taken in the window
+	Failed int           // Of those, the ones that failed
+}
+
+// FailedPercent returns the share of health checks in the window that failed
+func (e ErrorBudget) FailedPercent() float64 {
+	if e.Probes == 0 {
+		return 0
+	}
+	return float64(e.Failed) / float64(e.Probes) * 100
+}
+
+// ErrorMinutes returns the estimated user-facing error minutes: the window's
+// length weighted by the share of requests that failed in it
+func (e ErrorBudget) ErrorMinutes() float64 {
+	if e.Probes == 0 {
+		return 0
+	}
+	return e.Window.Minutes() * float64(e.Failed) / float64(e.Probes)
+}
+
+// ErrorBudget returns the client-observed errors from the disruption until
+// recovery was confirmed. It is only defined when the service went down.
+func (d *DrillResult) ErrorBudget() (ErrorBudget, bool) {
+	if d.Disrupt == nil || d.RTOStartTime.IsZero() || d.RTOEndTime.IsZero() {
+		return ErrorBudget{}, false
+	}
+	start := d.Disrupt.Timestamp
+	end := d.RecoveryConfirmedTime
+	if end.IsZero() {
+		end = d.RTOEndTime
+	}
+
+	e := ErrorBudget{Window: max(end.Sub(start), 0)}
+	for i := range d.HealthCheckAttempts {
+		attempt := &d.HealthCheckAttempts[i]
+		if attempt.Timestamp.Before(start) || attempt.Timestamp.After(end) {
+			continue
+		}
+		e.Probes++
+		if !attempt.Up() {
+			e.Failed++
+		}
+	}
+	return e, e.Probes > 0
+}
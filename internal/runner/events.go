@@ -0,0 +1,101 @@
+package runner
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event types passed to Hooks.OnEvent
+const (
+	EventRunStart     = "run_start"
+	EventPhaseStart   = "phase_start"
+	EventCommandStart = "command_start"
+	EventCommandEnd   = "command_end"
+	EventHealthCheck  = "health_check"
+	EventPause        = "pause"
+	EventRunEnd       = "run_end"
+)
+
+// Event is one entry of the drill's event stream
+type Event struct {
+	Time   time.Time              `json:"time"`
+	Type   string                 `json:"type"`
+	Phase  string                 `json:"phase,omitempty"` // Phase in progress
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+// eventStream serializes events from the runner and its background probes
+type eventStream struct {
+	mu      sync.Mutex
+	phase   string
+	secrets []string
+}
+
+// emit sends an event to Hooks.OnEvent, masking known secret values in its
+// string fields; it does nothing without the hook
+func (r *Runner) emit(kind string, fields map[string]interface{}) {
+	if r.hooks.OnEvent == nil {
+		return
+	}
+
+	s := &r.events
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if kind == EventPhaseStart {
+		s.phase, _ = fields["phase"].(string)
+	}
+	for k, v := range fields {
+		if v, ok := v.(string); ok {
+			for _, secret := range s.secrets {
+				v = strings.ReplaceAll(v, secret, "********")
+			}
+			fields[k] = v
+		}
+	}
+	r.hooks.OnEvent(Event{Time: time.Now(), Type: kind, Phase: s.phase, Fields: fields})
+}
+
+// emitRunStart starts the event stream of a run
+func (r *Runner) emitRunStart(result *DrillResult) {
+	r.events.mu.Lock()
+	r.events.phase = ""
+	r.events.secrets = result.Scenario.SecretValues()
+	r.events.mu.Unlock()
+
+	fields := map[string]interface{}{
+		"scenario":   result.Scenario.Name,
+		"rto_target": result.Scenario.RTOTarget,
+		"simulated":  result.Simulated,
+	}
+	if result.Scenario.RPOTarget != "" {
+		fields["rpo_target"] = result.Scenario.RPOTarget
+	}
+	if !result.ResumedAt.IsZero() {
+		fields["resumed_from"] = result.ResumedFrom
+	}
+	r.emit(EventRunStart, fields)
+}
+
+// emitRunEnd ends the event stream of a run with its outcome
+func (r *Runner) emitRunEnd(result *DrillResult) {
+	status := "complete"
+	if result.Incomplete {
+		status = "incomplete"
+	}
+	fields := map[string]interface{}{
+		"status":     status,
+		"rto_passed": result.RTOPassed,
+		"errors":     len(result.Errors),
+	}
+	if !result.RTOStartTime.IsZero() {
+		fields["rta_ms"] = durationMillis(result.RTA)
+		fields["rta_start"] = result.RTOStartTime.Format(time.RFC3339Nano)
+	}
+	r.emit(EventRunEnd, fields)
+}
+
+// durationMillis converts d to fractional milliseconds for events and traces
+func durationMillis(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
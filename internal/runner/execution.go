@@ -0,0 +1,111 @@
+package runner
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// defaultShellArgs returns the local shell used when none is configured
+func defaultShellArgs() []string {
+	if runtime.GOOS == "windows" {
+		return []string{"powershell", "-NoProfile", "-NonInteractive", "-Command"}
+	}
+	return []string{"bash", "-c"}
+}
+
+// targetShellArgs returns the shell used on an execution target when none is configured
+func targetShellArgs(execution *config.Execution) []string {
+	if !execution.IsRemote() {
+		return defaultShellArgs()
+	}
+	switch execution.Type {
+	case "kubectl-exec", "docker":
+		return []string{"sh", "-c"}
+	default:
+		return []string{"bash", "-c"}
+	}
+}
+
+// shellCommandArgs returns the argv that runs a command string through the
+// configured shell on the execution target
+func shellCommandArgs(execution *config.Execution, shell *config.Shell, command string) []string {
+	prefix := shell.Args()
+	if prefix == nil {
+		prefix = targetShellArgs(execution)
+	}
+	return append(append([]string{}, prefix...), command)
+}
+
+// runAsArgs wraps argv so it runs as user. sudo runs non-interactively, so
+// the target needs a NOPASSWD rule for the command.
+func runAsArgs(user string, argv []string) []string {
+	return append([]string{"sudo", "-n", "-u", user, "--"}, argv...)
+}
+
+// StepArgs returns the execution target a scenario step runs on and the argv
+// run there, given the scenario's execution and shell
+func StepArgs(execution *config.Execution, shell *config.Shell, step config.Command) (*config.Execution, []string) {
+	return stepArgs(execution, shell, step, StepCommand(step))
+}
+
+// stepArgs is StepArgs for the step's already rendered command
+func stepArgs(execution *config.Execution, shell *config.Shell, step config.Command, command string) (*config.Execution, []string) {
+	if step.Execution != nil {
+		execution = step.Execution
+	}
+	argv := step.Argv
+	if len(argv) == 0 {
+		if step.Shell != nil {
+			shell = step.Shell
+		}
+		argv = shellCommandArgs(execution, shell, command)
+	}
+	if step.RunAs != "" {
+		argv = runAsArgs(step.RunAs, argv)
+	}
+	return execution, argv
+}
+
+// TargetShellArgs returns the shell used on an execution target when none is configured
+func TargetShellArgs(execution *config.Execution) []string {
+	return targetShellArgs(execution)
+}
+
+// RunAsArgs returns the sudo wrapper that runs a command as user
+func RunAsArgs(user string) []string {
+	return runAsArgs(user, nil)
+}
+
+// executeStepCommand runs a command string through the configured shell on the
+// execution target, recording the command as written
+func (r *Runner) executeStepCommand(ctx context.Context, execution *config.Execution, shell *config.Shell, command string) *CommandResult {
+	return r.executeStepArgs(ctx, execution, command, shellCommandArgs(execution, shell, command))
+}
+
+// executeStepArgs runs argv without a local shell, either locally or on the
+// execution target, recording command rather than the wrapper invocation
+func (r *Runner) executeStepArgs(ctx context.Context, execution *config.Execution, command string, argv []string) *CommandResult {
+	if r.sim != nil {
+		return r.simulate(ctx, command)
+	}
+	if !execution.IsRemote() {
+		return r.executeArgs(ctx, command, argv)
+	}
+
+	switch execution.Type {
+	case "kubectl-exec":
+		return r.kubectlExec(ctx, execution, command, argv)
+	case "docker":
+		return r.dockerExec(ctx, execution, command, argv)
+	}
+
+	if execution.Group != "" {
+		return r.executeGroup(ctx, execution, command, argv)
+	}
+
+	result := r.executeArgs(ctx, command, sshArgs(execution, argv))
+	result.Host = execution.Target()
+	return result
+}
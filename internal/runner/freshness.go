@@ -0,0 +1,89 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// defaultBackupFreshnessTimeout time-boxes the backup freshness command when no timeout is set
+const defaultBackupFreshnessTimeout = time.Minute
+
+// backupTimeLayouts are the accepted formats for the latest backup time
+var backupTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+}
+
+// checkBackupFreshness runs the backup freshness command and compares the age of
+// the latest backup with the RPO target; it returns false if the backup is stale
+// or its time cannot be determined
+func (r *Runner) checkBackupFreshness(ctx context.Context, check *config.BackupFreshness, rpoTarget time.Duration, result *DrillResult) bool {
+	timeout := defaultBackupFreshnessTimeout
+	if check.Timeout != "" {
+		timeout, _ = time.ParseDuration(check.Timeout)
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	result.BackupFreshness = r.runStep(checkCtx, check.Command)
+	cancel()
+
+	fail := func(msg string) bool {
+		result.BackupStale = true
+		result.RPOPassed = false
+		result.Errors = append(result.Errors, msg)
+		fmt.Printf("❌ %s\n", msg)
+		return false
+	}
+
+	if !result.BackupFreshness.Succeeded() {
+		return fail(fmt.Sprintf("backup_freshness command failed with exit code %d", result.BackupFreshness.ExitCode))
+	}
+
+	latest, err := parseBackupTime(result.BackupFreshness.Stdout)
+	if err != nil {
+		return fail(fmt.Sprintf("backup_freshness: %v", err))
+	}
+
+	result.BackupAge = result.BackupFreshness.Timestamp.Sub(latest).Round(time.Second)
+	if result.BackupAge > rpoTarget {
+		return fail(fmt.Sprintf("latest backup is %s old, exceeding the RPO target of %s",
+			formatDuration(result.BackupAge), formatDuration(rpoTarget)))
+	}
+
+	fmt.Printf("✅ Latest backup is %s old (RPO target: %s)\n", formatDuration(result.BackupAge), formatDuration(rpoTarget))
+	return true
+}
+
+// parseBackupTime parses the last non-empty output line as a timestamp or Unix seconds
+func parseBackupTime(output string) (time.Time, error) {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	value := strings.Trim(strings.TrimSpace(lines[len(lines)-1]), `"`)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("command printed no backup time")
+	}
+
+	if t, ok := parseTimeValue(value); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse backup time %q (expected RFC 3339 or Unix seconds)", value)
+}
+
+// parseTimeValue parses Unix seconds or a time in one of backupTimeLayouts
+func parseTimeValue(value string) (time.Time, bool) {
+	if secs, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Unix(0, int64(secs*float64(time.Second))), true
+	}
+	for _, layout := range backupTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,67 @@
+package runner
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// defaultGCPLogLimit caps logging_read output when no limit is set
+const defaultGCPLogLimit = 100
+
+// gcpCommand renders a built-in GCP action as a gcloud invocation. Like the
+// cloudwatch probe, it goes through the CLI so credentials and the active
+// project resolve exactly as they do for the scenario's other commands.
+func gcpCommand(a *config.GCPAction, now time.Time) string {
+	var args []string
+	switch a.Action {
+	case config.GCPInstanceStop:
+		args = []string{"gcloud", "compute", "instances", "stop", a.Instance, "--zone", a.Zone}
+	case config.GCPInstanceReset:
+		args = []string{"gcloud", "compute", "instances", "reset", a.Instance, "--zone", a.Zone}
+	case config.GCPInstanceStart:
+		args = []string{"gcloud", "compute", "instances", "start", a.Instance, "--zone", a.Zone}
+	case config.GCPCloudSQLFailover:
+		args = []string{"gcloud", "sql", "instances", "failover", a.Instance, "--quiet"}
+	case config.GCPLoggingRead:
+		limit := a.Limit
+		if limit == 0 {
+			limit = defaultGCPLogLimit
+		}
+		args = []string{"gcloud", "logging", "read", a.Filter,
+			"--freshness", strconv.Itoa(int(a.GetWindow().Seconds())) + "s",
+			"--limit", strconv.Itoa(limit),
+			"--format", "json"}
+	case config.GCPMonitoringRead:
+		return monitoringReadCommand(a, now)
+	default:
+		return fmt.Sprintf("echo %s >&2; exit 1", shellQuote(fmt.Sprintf("unknown gcp action %q", a.Action)))
+	}
+
+	if a.Project != "" {
+		args = append(args, "--project", a.Project)
+	}
+	return quoteArgs(args)
+}
+
+// monitoringReadCommand fetches time series from the Cloud Monitoring API,
+// which gcloud does not expose, using a gcloud access token
+func monitoringReadCommand(a *config.GCPAction, now time.Time) string {
+	filter := fmt.Sprintf("metric.type = %q", a.Metric)
+	if a.Filter != "" {
+		filter += " AND " + a.Filter
+	}
+
+	query := url.Values{}
+	query.Set("filter", filter)
+	query.Set("interval.startTime", now.Add(-a.GetWindow()).UTC().Format(time.RFC3339))
+	query.Set("interval.endTime", now.UTC().Format(time.RFC3339))
+	endpoint := fmt.Sprintf("https://monitoring.googleapis.com/v3/projects/%s/timeSeries?%s",
+		url.PathEscape(a.Project), query.Encode())
+
+	return fmt.Sprintf(`curl -sS --fail-with-body -H "Authorization: Bearer $(gcloud auth print-access-token)" %s`,
+		shellQuote(endpoint))
+}
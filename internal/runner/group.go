@@ -0,0 +1,84 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// executeGroup runs argv on every member of the execution's host group in
+// parallel. The aggregated result fails with the first failing member's exit
+// code, in inventory order, and keeps each member's result.
+func (r *Runner) executeGroup(ctx context.Context, execution *config.Execution, command string, argv []string) *CommandResult {
+	result := &CommandResult{
+		Command:   command,
+		Timestamp: time.Now(),
+		Host:      execution.Target(),
+	}
+
+	members, err := r.hosts.Members(execution)
+	if err != nil {
+		result.ExitCode = -1
+		result.Stderr = err.Error()
+		result.StdoutHash = hashString(result.Stdout)
+		result.StderrHash = hashString(result.Stderr)
+		return result
+	}
+
+	r.trace("group_exec_start", command, map[string]interface{}{"group": execution.Group, "hosts": len(members)})
+	result.Members = make([]CommandResult, len(members))
+	var wg sync.WaitGroup
+	for i, member := range members {
+		wg.Add(1)
+		go func(i int, member *config.Execution) {
+			defer wg.Done()
+			memberResult := r.executeArgs(ctx, command, sshArgs(member, argv))
+			memberResult.Host = member.Target()
+			result.Members[i] = *memberResult
+		}(i, member)
+	}
+	wg.Wait()
+	result.Duration = time.Since(result.Timestamp)
+
+	var stdout, stderr strings.Builder
+	for _, m := range result.Members {
+		if result.ExitCode == 0 && m.ExitCode != 0 {
+			result.ExitCode = m.ExitCode
+		}
+		writeMemberOutput(&stdout, m.Host, m.Stdout)
+		writeMemberOutput(&stderr, m.Host, m.Stderr)
+	}
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+	result.StdoutHash = hashString(result.Stdout)
+	result.StderrHash = hashString(result.Stderr)
+
+	return result
+}
+
+// writeMemberOutput appends a member's output under a header naming its host
+func writeMemberOutput(b *strings.Builder, host, output string) {
+	if output == "" {
+		return
+	}
+	fmt.Fprintf(b, "==> %s <==\n%s", host, output)
+	if !strings.HasSuffix(output, "\n") {
+		b.WriteString("\n")
+	}
+}
+
+// memberLabel returns the label of a group member's result, e.g.
+// disrupt.ops@db1, keeping it safe to use in output file names
+func memberLabel(label, host string) string {
+	return label + "." + strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_.@", r)) {
+			return '_'
+		}
+		return r
+	}, host)
+}
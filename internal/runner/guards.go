@@ -0,0 +1,80 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// guardTimeout time-boxes each guard command
+const guardTimeout = time.Minute
+
+// GuardResult is the outcome of one precondition guard
+type GuardResult struct {
+	Name      string
+	Condition string // Threshold the printed value was compared with, if any
+	Value     string // Number the command printed, for guards with a condition
+	Passed    bool
+	Reason    string // Why the guard failed
+	Result    *CommandResult
+}
+
+// checkGuards runs every guard and returns false if any failed. All guards
+// run even after a failure, so the report shows every unmet precondition.
+func (r *Runner) checkGuards(ctx context.Context, guards []config.Guard, result *DrillResult) bool {
+	passed := true
+	for _, guard := range guards {
+		guardCtx, cancel := context.WithTimeout(ctx, guardTimeout)
+		g := GuardResult{
+			Name:      guard.Name,
+			Condition: guard.Condition,
+			Result:    r.runStep(guardCtx, guard.Command),
+		}
+		cancel()
+
+		g.Reason = evaluateGuard(&g)
+		g.Passed = g.Reason == ""
+		if g.Passed {
+			fmt.Printf("✅ Guard %q passed\n", g.Name)
+		} else {
+			passed = false
+			result.Errors = append(result.Errors, fmt.Sprintf("guard %q failed: %s", g.Name, g.Reason))
+			fmt.Printf("❌ Guard %q failed: %s\n", g.Name, g.Reason)
+		}
+		result.Guards = append(result.Guards, g)
+	}
+
+	if !passed {
+		result.GuardFailed = true
+	}
+	return passed
+}
+
+// evaluateGuard returns why a guard failed, or "" if it passed
+func evaluateGuard(g *GuardResult) string {
+	if !g.Result.Succeeded() {
+		return fmt.Sprintf("command failed with exit code %d", g.Result.ExitCode)
+	}
+	if g.Condition == "" {
+		return ""
+	}
+
+	condition, err := config.ParseCondition(g.Condition)
+	if err != nil {
+		return err.Error()
+	}
+	lines := strings.Split(strings.TrimSpace(g.Result.Stdout), "\n")
+	g.Value = strings.TrimSpace(lines[len(lines)-1])
+	value, err := strconv.ParseFloat(g.Value, 64)
+	if err != nil {
+		return fmt.Sprintf("command printed %q, not a number", g.Value)
+	}
+	if !condition.Holds(value) {
+		return fmt.Sprintf("value %s is not %s", g.Value, condition)
+	}
+	return ""
+}
@@ -0,0 +1,142 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// Health check states
+const (
+	StateHealthy  = "healthy"
+	StateDegraded = "degraded"
+	StateDown     = "down"
+)
+
+// State returns whether a health check found the service healthy, degraded, or down
+func (c *CommandResult) State() string {
+	switch {
+	case c.Degraded:
+		return StateDegraded
+	case c.ExitCode == 0:
+		return StateHealthy
+	}
+	return StateDown
+}
+
+// Up reports whether a health check found the service up, healthy or degraded.
+// Only a down check counts toward RTA.
+func (c *CommandResult) Up() bool {
+	return c.ExitCode == 0 || c.Degraded
+}
+
+// degradedProbe marks the checks of the probe it wraps that found the
+// service up but impaired
+type degradedProbe struct {
+	Probe
+	exitCodes map[int]bool
+	latency   time.Duration
+	value     *config.Condition
+}
+
+func newDegradedProbe(probe Probe, d *config.Degraded) (*degradedProbe, error) {
+	p := &degradedProbe{Probe: probe, exitCodes: map[int]bool{}}
+	for _, code := range d.ExitCodes {
+		p.exitCodes[code] = true
+	}
+	latency, err := d.GetLatency()
+	if err != nil {
+		return nil, fmt.Errorf("invalid degraded latency: %w", err)
+	}
+	p.latency = latency
+	if d.Value != "" {
+		condition, err := config.ParseCondition(d.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid degraded value: %w", err)
+		}
+		p.value = &condition
+	}
+	return p, nil
+}
+
+func (p *degradedProbe) Check(ctx context.Context) *CommandResult {
+	result := p.Probe.Check(ctx)
+	switch {
+	case p.exitCodes[result.ExitCode]:
+		result.Degraded = true
+	case result.ExitCode != 0:
+	case p.latency > 0 && result.Duration > p.latency:
+		result.Degraded = true
+	case p.value != nil && result.Probe != nil && result.Probe.Value != nil && p.value.Holds(*result.Probe.Value):
+		result.Degraded = true
+	}
+	return result
+}
+
+// Close closes the wrapped probe, for probes that hold a listener
+func (p *degradedProbe) Close() error {
+	if closer, ok := p.Probe.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// waitWhileDegraded keeps taking health checks after recovery while the
+// service is degraded, for at most maxWait, so the time until it is fully
+// healthy is measured
+func (r *Runner) waitWhileDegraded(ctx context.Context, probes *prober, maxWait time.Duration, result *DrillResult) {
+	n := len(result.HealthCheckAttempts)
+	if n == 0 || !result.HealthCheckAttempts[n-1].Degraded {
+		return
+	}
+	fmt.Println("⚠️  Service is up but DEGRADED - waiting for it to be fully healthy")
+
+	deadline := time.After(maxWait)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-deadline:
+			result.Errors = append(result.Errors, fmt.Sprintf("service still degraded %s after recovery", formatDuration(maxWait)))
+			fmt.Printf("⚠️  Service still degraded after %s\n", formatDuration(maxWait))
+			return
+		case attempt := <-probes.results:
+			result.HealthCheckAttempts = append(result.HealthCheckAttempts, *attempt)
+			r.healthChecked(len(result.HealthCheckAttempts), attempt)
+			if attempt.State() == StateHealthy {
+				fmt.Printf("✅ Service is fully healthy (degraded for %s after recovery)\n",
+					formatDuration(attempt.Timestamp.Sub(result.RTOEndTime)))
+				return
+			}
+		}
+	}
+}
+
+// HealthStateTimes returns how many health checks found each state and how
+// long the service spent in it, taking each check's state to last until the
+// next check, and the last one for its own duration
+func (d *DrillResult) HealthStateTimes() (checks map[string]int, durations map[string]time.Duration) {
+	checks = map[string]int{}
+	durations = map[string]time.Duration{}
+
+	attempts := make([]*CommandResult, 0, len(d.HealthCheckAttempts))
+	for i := range d.HealthCheckAttempts {
+		attempts = append(attempts, &d.HealthCheckAttempts[i])
+	}
+	sort.SliceStable(attempts, func(i, j int) bool { return attempts[i].Timestamp.Before(attempts[j].Timestamp) })
+
+	for i, attempt := range attempts {
+		state := attempt.State()
+		checks[state]++
+		lasted := attempt.Duration
+		if i+1 < len(attempts) {
+			lasted = attempts[i+1].Timestamp.Sub(attempt.Timestamp)
+		}
+		durations[state] += lasted
+	}
+	return checks, durations
+}
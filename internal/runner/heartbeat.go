@@ -0,0 +1,39 @@
+package runner
+
+import (
+	"fmt"
+	"time"
+)
+
+// SetHeartbeat makes subsequent runs print a timestamped status line every
+// interval while waiting for the service to recover, so CI jobs with
+// inactivity timeouts see output during long waits; zero disables it
+func (r *Runner) SetHeartbeat(interval time.Duration) {
+	r.heartbeatInterval = interval
+}
+
+// printHeartbeat reports progress of the RTA measurement on one line
+func printHeartbeat(result *DrillResult, rtoTarget time.Duration) {
+	now := time.Now()
+	status := fmt.Sprintf("%d health checks, %d failed", len(result.HealthCheckAttempts), result.FailedProbes)
+	if result.RTOStartTime.IsZero() {
+		fmt.Printf("[%s] 💓 Waiting for health checks: %s, service not down yet\n", now.UTC().Format(time.RFC3339), status)
+		return
+	}
+
+	var budget string
+	paused := result.PausedDowntime()
+	deadline := result.RTOStartTime.Add(rtoTarget + result.DowntimeGrace + paused)
+	switch {
+	case result.MaxFailedProbes != nil:
+		budget = fmt.Sprintf("failed probe budget %d/%d", result.FailedProbes, *result.MaxFailedProbes)
+	case result.AbortAfter > 0 && now.After(deadline):
+		budget = fmt.Sprintf("RTO exceeded, abort in %s", formatDuration(result.RTOStartTime.Add(result.AbortAfter+paused).Sub(now)))
+	case now.After(deadline):
+		budget = "RTO exceeded"
+	default:
+		budget = fmt.Sprintf("RTO remaining %s", formatDuration(deadline.Sub(now)))
+	}
+	fmt.Printf("[%s] 💓 Waiting for recovery: RTA elapsed %s, %s, %s\n",
+		now.UTC().Format(time.RFC3339), formatDuration(now.Sub(result.RTOStartTime)), status, budget)
+}
@@ -0,0 +1,104 @@
+package runner
+
+import (
+	"context"
+	"time"
+)
+
+// Phase names reported to Hooks.OnPhaseStart
+const (
+	PhaseBackupFreshness  = "backup_freshness"
+	PhaseRestore          = "restore"
+	PhaseSeed             = "seed"
+	PhasePreSnapshot      = "pre_snapshot"
+	PhaseGuards           = "guards"
+	PhaseMarkers          = "markers"
+	PhaseDisrupt          = "disrupt"
+	PhasePostDisruptDelay = "post_disrupt_delay"
+	PhaseDetectDowntime   = "detect_downtime"
+	PhaseRecover          = "recover"
+	PhaseRTAMeasurement   = "rta_measurement"
+	PhaseAbort            = "abort"
+	PhasePostSnapshot     = "post_snapshot"
+	PhaseRPOVerify        = "rpo_verify"
+	PhaseConsistency      = "consistency"
+	PhaseSwitchback       = "switchback"
+	PhaseFactors          = "factors"
+)
+
+// Hooks are optional lifecycle callbacks for applications embedding the runner.
+// Callbacks run synchronously on the runner's goroutine and should return quickly.
+type Hooks struct {
+	// OnPhaseStart is called when the drill enters a phase
+	OnPhaseStart func(phase string)
+	// OnHealthCheck is called after every health check with its 1-based attempt number
+	OnHealthCheck func(attempt int, result *CommandResult)
+	// OnComplete is called once with the final result when the drill finishes
+	OnComplete func(result *DrillResult)
+	// OnInterrupt is called when the run is canceled after the disruption was
+	// issued but before recovery completed; returning true runs recover_command.
+	// Without it, an interrupted drill leaves the environment disrupted.
+	OnInterrupt func(phase string) bool
+	// PauseRequested is polled before every phase; while it returns true the
+	// drill holds before entering the phase. Time held while the service is
+	// down is excluded from the RTA compared against the RTO target.
+	PauseRequested func(phase string) bool
+	// OnCheckpoint is called with the drill state at every phase and, at most
+	// once a second, after health checks, so callers can persist it for Resume.
+	// The result must not be retained or modified after the callback returns.
+	OnCheckpoint func(checkpoint *Checkpoint)
+	// OnEvent is called for every event of the run as it happens: the run
+	// starting and ending, phase transitions, scenario commands starting and
+	// finishing, health checks, and pauses. Background health checks call it
+	// from their own goroutines, one call at a time.
+	OnEvent func(event Event)
+}
+
+// SetHooks registers lifecycle callbacks for subsequent runs
+func (r *Runner) SetHooks(hooks Hooks) {
+	r.hooks = hooks
+}
+
+func (r *Runner) phaseStart(ctx context.Context, phase string) {
+	// A pause before the phase is not part of the phase before it
+	if phase != r.phase {
+		r.endPhase(time.Now())
+	}
+	r.waitWhilePaused(ctx, phase)
+	r.phase = phase
+	r.enterPhase(phase)
+	if r.sim != nil {
+		r.sim.setPhase(phase)
+	}
+	r.checkpoint(true)
+	r.trace("phase_start", phase, nil)
+	r.emit(EventPhaseStart, map[string]interface{}{"phase": phase})
+	if r.hooks.OnPhaseStart != nil {
+		r.hooks.OnPhaseStart(phase)
+	}
+}
+
+func (r *Runner) healthChecked(attempt int, result *CommandResult) {
+	r.trace("health_check", result.Command, map[string]interface{}{
+		"attempt":     attempt,
+		"exit_code":   result.ExitCode,
+		"duration_ms": float64(result.Duration.Microseconds()) / 1000,
+	})
+	r.emit(EventHealthCheck, map[string]interface{}{
+		"attempt":     attempt,
+		"command":     result.Command,
+		"exit_code":   result.ExitCode,
+		"state":       result.State(),
+		"duration_ms": durationMillis(result.Duration),
+	})
+	if r.hooks.OnHealthCheck != nil {
+		r.hooks.OnHealthCheck(attempt, result)
+	}
+	r.checkpoint(false)
+}
+
+func (r *Runner) complete(result *DrillResult) {
+	if r.hooks.OnComplete != nil {
+		r.hooks.OnComplete(result)
+	}
+}
@@ -0,0 +1,57 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// kubeEnv returns the KUBECONFIG that pins commands to the scenario's
+// kubeconfig and context. The context is selected by putting a file that only
+// sets current-context first in KUBECONFIG, since kubectl takes
+// current-context from the first file that sets it; the operator's kubeconfig
+// is never modified. The returned cleanup removes that file.
+func kubeEnv(scenario *config.Scenario) ([]string, func(), error) {
+	cleanup := func() {}
+	if scenario.Kubeconfig == "" && scenario.KubeContext == "" {
+		return nil, cleanup, nil
+	}
+
+	var files []string
+	if scenario.Kubeconfig != "" {
+		path, err := filepath.Abs(scenario.Kubeconfig)
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("invalid kubeconfig: %w", err)
+		}
+		if _, err := os.Stat(path); err != nil {
+			return nil, cleanup, fmt.Errorf("invalid kubeconfig: %w", err)
+		}
+		files = []string{path}
+	} else if env := os.Getenv("KUBECONFIG"); env != "" {
+		files = filepath.SplitList(env)
+	} else if home, err := os.UserHomeDir(); err == nil {
+		files = []string{filepath.Join(home, ".kube", "config")}
+	}
+
+	if scenario.KubeContext != "" {
+		f, err := os.CreateTemp("", "drillmeasure-kubecontext-*.yaml")
+		if err != nil {
+			return nil, cleanup, fmt.Errorf("failed to select kube_context: %w", err)
+		}
+		_, err = fmt.Fprintf(f, "apiVersion: v1\nkind: Config\ncurrent-context: %q\n", scenario.KubeContext)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(f.Name())
+			return nil, cleanup, fmt.Errorf("failed to select kube_context: %w", err)
+		}
+		cleanup = func() { os.Remove(f.Name()) }
+		files = append([]string{f.Name()}, files...)
+	}
+
+	return []string{"KUBECONFIG=" + strings.Join(files, string(os.PathListSeparator))}, cleanup, nil
+}
@@ -0,0 +1,175 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// kubectlExec runs argv in a pod through the exec subresource of the
+// Kubernetes API with client-go, so stdout, stderr, and the exit code are the
+// command's own. The kubeconfig is loaded as kubectl loads it, so contexts and
+// exec credential plugins resolve as they do for the operator.
+func (r *Runner) kubectlExec(ctx context.Context, e *config.Execution, command string, argv []string) *CommandResult {
+	result := &CommandResult{
+		Command:   command,
+		Timestamp: time.Now(),
+	}
+
+	r.trace("kubectl_exec_start", command, map[string]interface{}{"argv": argv, "namespace": e.Namespace, "pod": e.Pod, "selector": e.Selector, "container": e.Container})
+	start := time.Now()
+	err := r.streamPodExec(ctx, e, argv, result)
+	if err != nil {
+		result.ExitCode = -1
+		if result.Stderr != "" && !strings.HasSuffix(result.Stderr, "\n") {
+			result.Stderr += "\n"
+		}
+		result.Stderr += err.Error()
+	}
+	result.Duration = time.Since(start)
+	result.StdoutHash = hashString(result.Stdout)
+	result.StderrHash = hashString(result.Stderr)
+	r.redactor.apply(result)
+	r.traceExecEnd(result)
+
+	return result
+}
+
+// streamPodExec resolves the pod, runs argv in it, and fills in the result's
+// host, output, and exit code. A non-zero exit of the command is not an error.
+func (r *Runner) streamPodExec(ctx context.Context, e *config.Execution, argv []string, result *CommandResult) error {
+	restConfig, namespace, err := r.kubeConfig(e)
+	if err != nil {
+		return err
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	pod := e.Pod
+	if pod == "" {
+		if pod, err = findRunningPod(ctx, client, namespace, e.Selector); err != nil {
+			return err
+		}
+	}
+	result.Host = namespace + "/pod/" + pod
+
+	req := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: e.Container,
+			Command:   argv,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+	executor, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to exec in pod %s: %w", pod, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr})
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+
+	var exitErr utilexec.ExitError
+	switch {
+	case err == nil:
+		result.ExitCode = 0
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitStatus()
+	case ctx.Err() == context.DeadlineExceeded:
+		return fmt.Errorf("command timed out: %v", err)
+	case ctx.Err() == context.Canceled:
+		return fmt.Errorf("command canceled: %v", err)
+	default:
+		return fmt.Errorf("exec in pod %s failed: %w", pod, err)
+	}
+	return nil
+}
+
+// kubeConfig loads the client configuration and namespace for the execution
+// from KUBECONFIG, pinned to the scenario's kubeconfig and kube_context when
+// set, or ~/.kube/config. The execution's context and namespace override it.
+func (r *Runner) kubeConfig(e *config.Execution) (*rest.Config, string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig := r.envValue("KUBECONFIG"); kubeconfig != "" {
+		rules.Precedence = filepath.SplitList(kubeconfig)
+	}
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: e.Context}
+	if e.Namespace != "" {
+		overrides.Context.Namespace = e.Namespace
+	}
+
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, overrides)
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	if restConfig.ExecProvider != nil {
+		// Credential plugins such as aws eks get-token see the pinned cloud
+		// accounts, as they do when run by kubectl; the kubeconfig's own env wins
+		var env []clientcmdapi.ExecEnvVar
+		for _, kv := range r.env {
+			if name, value, ok := strings.Cut(kv, "="); ok {
+				env = append(env, clientcmdapi.ExecEnvVar{Name: name, Value: value})
+			}
+		}
+		restConfig.ExecProvider.Env = append(env, restConfig.ExecProvider.Env...)
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	return restConfig, namespace, nil
+}
+
+// envValue returns an environment variable as commands see it: from the
+// runner's pinned environment, else the process environment
+func (r *Runner) envValue(name string) string {
+	for i := len(r.env) - 1; i >= 0; i-- {
+		if value, ok := strings.CutPrefix(r.env[i], name+"="); ok {
+			return value
+		}
+	}
+	return os.Getenv(name)
+}
+
+// findRunningPod returns the first running pod matching the selector that is
+// not being deleted
+func findRunningPod(ctx context.Context, client kubernetes.Interface, namespace, selector string) (string, error) {
+	pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: selector,
+		FieldSelector: "status.phase=Running",
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods for selector %q: %w", selector, err)
+	}
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp == nil {
+			return pod.Name, nil
+		}
+	}
+	return "", fmt.Errorf("no running pod matches selector %q", selector)
+}
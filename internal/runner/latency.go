@@ -0,0 +1,75 @@
+package runner
+
+import "time"
+
+// Probe latency spans, relative to the outage
+const (
+	LatencyAll            = "all"
+	LatencyBeforeDowntime = "before_downtime" // From the disruption to the first failed check
+	LatencyDowntime       = "downtime"
+	LatencyAfterRecovery  = "after_recovery" // From recovery until switchback, if any
+)
+
+// LatencyStats summarizes how long health checks took during one span of the drill
+type LatencyStats struct {
+	Span   string
+	Probes int
+	P50    time.Duration
+	P90    time.Duration
+	P99    time.Duration
+	Max    time.Duration
+}
+
+// ProbeLatencies returns the latency of every health check, in order
+func (d *DrillResult) ProbeLatencies() []time.Duration {
+	latencies := make([]time.Duration, 0, len(d.HealthCheckAttempts))
+	for i := range d.HealthCheckAttempts {
+		latencies = append(latencies, d.HealthCheckAttempts[i].Duration)
+	}
+	return latencies
+}
+
+// ProbeLatencyStats returns latency percentiles for all health checks and for
+// each span that had any. Latency creeping up after recovery, compared with
+// before the downtime, points to a partial recovery.
+func (d *DrillResult) ProbeLatencyStats() []LatencyStats {
+	spans := map[string][]time.Duration{}
+	for i := range d.HealthCheckAttempts {
+		attempt := &d.HealthCheckAttempts[i]
+		spans[LatencyAll] = append(spans[LatencyAll], attempt.Duration)
+		if span := d.latencySpan(attempt.Timestamp); span != "" {
+			spans[span] = append(spans[span], attempt.Duration)
+		}
+	}
+
+	var stats []LatencyStats
+	for _, span := range []string{LatencyAll, LatencyBeforeDowntime, LatencyDowntime, LatencyAfterRecovery} {
+		samples := spans[span]
+		if len(samples) == 0 {
+			continue
+		}
+		stats = append(stats, LatencyStats{
+			Span:   span,
+			Probes: len(samples),
+			P50:    percentile(samples, 50),
+			P90:    percentile(samples, 90),
+			P99:    percentile(samples, 99),
+			Max:    percentile(samples, 100),
+		})
+	}
+	return stats
+}
+
+// latencySpan returns the span a health check taken at t belongs to, or ""
+// for switchback checks
+func (d *DrillResult) latencySpan(t time.Time) string {
+	switch {
+	case !d.SwitchbackStartTime.IsZero() && !t.Before(d.SwitchbackStartTime):
+		return ""
+	case d.RTOStartTime.IsZero() || t.Before(d.RTOStartTime):
+		return LatencyBeforeDowntime
+	case d.RTOEndTime.IsZero() || t.Before(d.RTOEndTime):
+		return LatencyDowntime
+	}
+	return LatencyAfterRecovery
+}
@@ -0,0 +1,275 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// consulLockTTL is how long a Consul run lock outlives a controller that
+// stopped renewing it
+const consulLockTTL = 30 * time.Second
+
+// consulLockPrefix is the Consul KV prefix run locks are kept under
+const consulLockPrefix = "drillmeasure/locks/"
+
+// LockHolder identifies the run holding a run lock
+type LockHolder struct {
+	Scenario string    `json:"scenario"`
+	User     string    `json:"user,omitempty"`
+	Host     string    `json:"host"`
+	PID      int       `json:"pid"`
+	Started  time.Time `json:"started"`
+}
+
+// NewLockHolder describes the current process running scenario
+func NewLockHolder(scenario string) LockHolder {
+	host, _ := os.Hostname()
+	user := os.Getenv("USER")
+	if user == "" {
+		user = os.Getenv("USERNAME")
+	}
+	return LockHolder{Scenario: scenario, User: user, Host: host, PID: os.Getpid(), Started: time.Now()}
+}
+
+// String describes the holder, e.g. "failover-test by alice@bastion (pid 4242) since 14:03:22 UTC"
+func (h LockHolder) String() string {
+	who := h.Host
+	if h.User != "" {
+		who = h.User + "@" + h.Host
+	}
+	return fmt.Sprintf("%s by %s (pid %d) since %s", h.Scenario, who, h.PID, h.Started.Format("2006-01-02 15:04:05 MST"))
+}
+
+// LockedError is returned by AcquireLock when another run holds the lock
+type LockedError struct {
+	Key    string
+	Where  string // Lock file or Consul key
+	Holder *LockHolder
+}
+
+func (e *LockedError) Error() string {
+	if e.Holder == nil {
+		return fmt.Sprintf("another drill against %q is running (lock %s)", e.Key, e.Where)
+	}
+	return fmt.Sprintf("another drill against %q is running: %s (lock %s)", e.Key, e.Holder, e.Where)
+}
+
+// RunLock is a held run lock
+type RunLock struct {
+	release func()
+}
+
+// Release gives up the lock; it is safe to call more than once
+func (l *RunLock) Release() {
+	if l != nil && l.release != nil {
+		l.release()
+		l.release = nil
+	}
+}
+
+// AcquireLock takes the scenario's run lock so drills against the same
+// service cannot overlap. File locks live in the scenario's lock.dir, or
+// defaultDir; a lock left by a process that no longer runs on this host is
+// taken over. Consul locks are tied to a session that expires if the holder
+// stops renewing it.
+func AcquireLock(ctx context.Context, scenario *config.Scenario, defaultDir string, holder LockHolder) (*RunLock, error) {
+	spec := scenario.Lock
+	if spec == nil {
+		spec = &config.Lock{}
+	}
+	key := scenario.LockKey()
+
+	switch scenario.LockBackend() {
+	case "none":
+		return &RunLock{}, nil
+	case "consul":
+		return acquireConsulLock(ctx, spec.URL, key, holder)
+	default:
+		dir := spec.Dir
+		if dir == "" {
+			dir = defaultDir
+		}
+		return acquireFileLock(dir, key, holder)
+	}
+}
+
+func acquireFileLock(dir, key string, holder LockHolder) (*RunLock, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory: %w", err)
+	}
+	path := filepath.Join(dir, lockFileName(key))
+	content, err := json.Marshal(holder)
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, err = f.Write(content)
+			f.Close()
+			if err != nil {
+				os.Remove(path)
+				return nil, fmt.Errorf("failed to write lock file: %w", err)
+			}
+			return &RunLock{release: func() { os.Remove(path) }}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("failed to create lock file: %w", err)
+		}
+
+		raw, _ := os.ReadFile(path)
+		existing := parseLockHolder(raw)
+		if attempt > 0 || !staleLock(existing) {
+			return nil, &LockedError{Key: key, Where: path, Holder: existing}
+		}
+		if err := takeOverStaleLock(path, raw, existing); err != nil {
+			return nil, err
+		}
+	}
+	return nil, &LockedError{Key: key, Where: path}
+}
+
+// takeOverStaleLock removes the stale lock at path if it still holds seen.
+// Takeovers are serialized across processes, so two runs that read the same
+// stale holder cannot both remove a lock: the second finds the lock changed
+// and leaves it, and the exclusive create that follows decides the winner.
+func takeOverStaleLock(path string, seen []byte, holder *LockHolder) error {
+	unlock, err := lockTakeover(path)
+	if err != nil {
+		return fmt.Errorf("failed to take over stale lock %s: %w", path, err)
+	}
+	defer unlock()
+
+	current, err := os.ReadFile(path)
+	if err != nil || !bytes.Equal(current, seen) {
+		return nil
+	}
+	fmt.Printf("⚠️  Taking over stale lock %s (%s is no longer running)\n", path, holder)
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove stale lock %s: %w", path, err)
+	}
+	return nil
+}
+
+// lockFileName makes a lock key safe to use as a file name
+func lockFileName(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+	return b.String() + ".lock"
+}
+
+// parseLockHolder decodes a lock file's content, or returns nil if it is not
+// a lock holder
+func parseLockHolder(raw []byte) *LockHolder {
+	var holder LockHolder
+	if err := json.Unmarshal(raw, &holder); err != nil {
+		return nil
+	}
+	return &holder
+}
+
+// staleLock reports whether a lock was left by a process on this host that
+// is no longer running
+func staleLock(holder *LockHolder) bool {
+	if holder == nil || runtime.GOOS == "windows" {
+		return false
+	}
+	host, _ := os.Hostname()
+	if holder.Host != host || holder.PID <= 0 {
+		return false
+	}
+	process, err := os.FindProcess(holder.PID)
+	if err != nil {
+		return true
+	}
+	return errors.Is(process.Signal(syscall.Signal(0)), os.ErrProcessDone)
+}
+
+func acquireConsulLock(ctx context.Context, addr, key string, holder LockHolder) (*RunLock, error) {
+	kvURL := consulKVURL(addr, consulLockPrefix+key)
+	apiURL := consulAPIURL(addr)
+	header := consulHeader()
+
+	session, err := json.Marshal(map[string]string{
+		"Name":      "drillmeasure " + key,
+		"TTL":       consulLockTTL.String(),
+		"Behavior":  "delete",
+		"LockDelay": "0s",
+	})
+	if err != nil {
+		return nil, err
+	}
+	body, err := httpRequest(ctx, http.MethodPut, apiURL+"/session/create", header, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Consul session: %w", err)
+	}
+	var created struct{ ID string }
+	if err := json.Unmarshal(body, &created); err != nil || created.ID == "" {
+		return nil, fmt.Errorf("failed to create Consul session: unexpected response %q", strings.TrimSpace(string(body)))
+	}
+	destroy := func() {
+		httpRequest(context.Background(), http.MethodPut, apiURL+"/session/destroy/"+created.ID, header, nil)
+	}
+
+	content, err := json.Marshal(holder)
+	if err != nil {
+		destroy()
+		return nil, err
+	}
+	body, err = httpRequest(ctx, http.MethodPut, kvURL+"?acquire="+created.ID, header, content)
+	if err != nil {
+		destroy()
+		return nil, fmt.Errorf("failed to acquire Consul lock %s: %w", consulLockPrefix+key, err)
+	}
+	if strings.TrimSpace(string(body)) != "true" {
+		destroy()
+		locked := &LockedError{Key: key, Where: "consul " + consulLockPrefix + key}
+		if raw, err := httpGet(ctx, kvURL+"?raw", header); err == nil {
+			var existing LockHolder
+			if json.Unmarshal(raw, &existing) == nil {
+				locked.Holder = &existing
+			}
+		}
+		return nil, locked
+	}
+
+	// Renew the session well within its TTL for as long as the drill runs
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(consulLockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				httpRequest(context.Background(), http.MethodPut, apiURL+"/session/renew/"+created.ID, header, nil)
+			}
+		}
+	}()
+
+	return &RunLock{release: func() {
+		close(done)
+		httpRequest(context.Background(), http.MethodPut, kvURL+"?release="+created.ID, header, nil)
+		destroy()
+	}}, nil
+}
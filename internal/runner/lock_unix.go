@@ -0,0 +1,26 @@
+//go:build !windows
+
+package runner
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockTakeover holds an exclusive flock on a file beside the run lock at
+// path while a stale lock is taken over. The file is kept, as removing it
+// would let another process lock a new file of the same name.
+func lockTakeover(path string) (func(), error) {
+	f, err := os.OpenFile(path+".takeover", os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return func() {
+		syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		f.Close()
+	}, nil
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package runner
+
+import "errors"
+
+// lockTakeover is not needed on Windows, where staleLock never reports a
+// lock as stale
+func lockTakeover(path string) (func(), error) {
+	return nil, errors.New("stale lock takeover is not supported on Windows")
+}
@@ -0,0 +1,92 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// markerWriter writes marker records in the background until stopped
+type markerWriter struct {
+	*repeater
+
+	mu      sync.Mutex
+	acked   time.Time // Newest marker whose write succeeded
+	written int
+	failed  int
+	lastErr string
+}
+
+// startMarkers writes a marker every interval until the returned writer is
+// stopped, returning once markers have been written for the configured lead
+func (r *Runner) startMarkers(ctx context.Context, markers *config.Markers) *markerWriter {
+	interval, _ := markers.GetInterval()
+	lead, _ := markers.GetLead()
+	w := &markerWriter{}
+	w.repeater = startRepeater(ctx, interval, func(ctx context.Context) {
+		marker := time.Now().UTC()
+		res := r.runStep(ctx, markers.WithMarker(marker.Format(time.RFC3339Nano)))
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		if res.Succeeded() {
+			w.acked = marker
+			w.written++
+		} else if ctx.Err() == nil {
+			w.failed++
+			w.lastErr = fmt.Sprintf("exit code %d: %s", res.ExitCode, strings.TrimSpace(res.Stderr))
+		}
+	})
+
+	if lead > 0 {
+		fmt.Printf("Writing marker records every %s for %s before the disruption...\n", formatDuration(interval), formatDuration(lead))
+		select {
+		case <-ctx.Done():
+		case <-time.After(lead):
+		}
+	}
+	return w
+}
+
+// finish ends marker writing and records what was written
+func (w *markerWriter) finish(result *DrillResult) {
+	w.stop()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	result.MarkersWritten = w.written
+	result.MarkersFailed = w.failed
+	result.PreSnapshotTime = w.acked
+	if w.failed > 0 {
+		result.Errors = append(result.Errors, fmt.Sprintf("rpo_check.markers: %d marker write(s) failed (last: %s)", w.failed, w.lastErr))
+	}
+	fmt.Printf("Marker records: %d written before the disruption\n", w.written)
+}
+
+// readMarkers finds the newest marker that survived recovery and records
+// the data loss since the newest marker written
+func (r *Runner) readMarkers(ctx context.Context, markers *config.Markers, result *DrillResult) {
+	result.MarkerRead = r.runStep(ctx, markers.ReadCommand)
+	if !result.MarkerRead.Succeeded() {
+		result.Errors = append(result.Errors, fmt.Sprintf("rpo_check.markers read_command failed with exit code %d", result.MarkerRead.ExitCode))
+		return
+	}
+	if result.PreSnapshotTime.IsZero() {
+		result.Errors = append(result.Errors, "rpo_check.markers: no marker was written before the disruption")
+		return
+	}
+
+	newest, err := parseSnapshotTime(result.MarkerRead.Stdout, &config.SnapshotTimestamp{})
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("rpo_check.markers: read_command: %v", err))
+		return
+	}
+
+	result.PostSnapshotTime = newest
+	result.RPOActual = dataLoss(result.PreSnapshotTime, newest)
+	fmt.Printf("Data loss: %s (newest marker written %s, newest surviving %s)\n",
+		formatDuration(result.RPOActual), result.PreSnapshotTime.Format(time.RFC3339Nano), newest.UTC().Format(time.RFC3339Nano))
+}
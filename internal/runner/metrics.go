@@ -0,0 +1,100 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// MetricResult is a custom metric captured before the disruption and after recovery
+type MetricResult struct {
+	Name   string
+	Before *MetricSample
+	After  *MetricSample
+}
+
+// MetricSample is one reading of a metric
+type MetricSample struct {
+	Value  string // Empty when the value could not be read
+	Error  string // Why the value could not be read
+	Result *CommandResult
+}
+
+// Number returns the sample's value as a number, if it is one
+func (s *MetricSample) Number() (float64, bool) {
+	if s == nil || s.Value == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(s.Value, 64)
+	return v, err == nil
+}
+
+// Delta returns the change from before the disruption to after recovery,
+// when both readings are numbers
+func (m *MetricResult) Delta() (float64, bool) {
+	before, ok := m.Before.Number()
+	if !ok {
+		return 0, false
+	}
+	after, ok := m.After.Number()
+	if !ok {
+		return 0, false
+	}
+	return after - before, true
+}
+
+// captureMetrics reads every metric, before the disruption when before is
+// set and otherwise after recovery
+func (r *Runner) captureMetrics(ctx context.Context, metrics []config.Metric, before bool, result *DrillResult) {
+	if len(result.Metrics) != len(metrics) {
+		result.Metrics = make([]MetricResult, len(metrics))
+	}
+	when := "after recovery"
+	if before {
+		when = "before the disruption"
+	}
+	for i, metric := range metrics {
+		m := &result.Metrics[i]
+		m.Name = metric.Name
+		sample := r.readMetric(ctx, metric)
+		if sample.Error != "" {
+			result.Errors = append(result.Errors, fmt.Sprintf("metric %q %s: %s", metric.Name, when, sample.Error))
+		}
+		if before {
+			m.Before = sample
+		} else {
+			m.After = sample
+		}
+	}
+}
+
+// readMetric runs a metric's command and extracts its value
+func (r *Runner) readMetric(ctx context.Context, metric config.Metric) *MetricSample {
+	sample := &MetricSample{Result: r.runStep(ctx, metric.Command)}
+	if !sample.Result.Succeeded() {
+		sample.Error = fmt.Sprintf("command failed with exit code %d", sample.Result.ExitCode)
+		return sample
+	}
+
+	var value string
+	var err error
+	if metric.JSON != "" {
+		// Validated with the scenario
+		path, _ := config.ParseJSONPath(metric.JSON)
+		value, err = path.Lookup(sample.Result.Stdout)
+	} else {
+		value, err = extractValue(sample.Result.Stdout, metric.Regex)
+	}
+	if err != nil {
+		sample.Error = err.Error()
+		return sample
+	}
+	if value == "" {
+		sample.Error = "output is empty"
+		return sample
+	}
+	sample.Value = value
+	return sample
+}
@@ -0,0 +1,81 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// pausePollInterval is how often a paused drill checks whether to continue
+const pausePollInterval = time.Second
+
+// Pause is a time the drill was held before entering a phase
+type Pause struct {
+	Phase string    `json:"phase"` // Phase the drill was about to enter
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// Duration returns how long the drill was held
+func (p Pause) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// PausedDowntime returns how much of the measured outage the drill spent paused
+func (d *DrillResult) PausedDowntime() time.Duration {
+	if d.RTOStartTime.IsZero() {
+		return 0
+	}
+	end := d.RTOEndTime
+	if end.IsZero() {
+		end = time.Now()
+	}
+
+	var total time.Duration
+	for _, p := range d.Pauses {
+		start, stop := p.Start, p.End
+		if start.Before(d.RTOStartTime) {
+			start = d.RTOStartTime
+		}
+		if stop.After(end) {
+			stop = end
+		}
+		if stop.After(start) {
+			total += stop.Sub(start)
+		}
+	}
+	return total
+}
+
+// waitWhilePaused holds the drill before phase for as long as
+// Hooks.PauseRequested reports a pause, recording how long it was held
+func (r *Runner) waitWhilePaused(ctx context.Context, phase string) {
+	if r.hooks.PauseRequested == nil || r.current == nil || !r.hooks.PauseRequested(phase) {
+		return
+	}
+
+	pause := Pause{Phase: phase, Start: time.Now()}
+	fmt.Printf("⏸️  Drill paused before %s\n", phase)
+	ticker := time.NewTicker(pausePollInterval)
+	defer ticker.Stop()
+	for paused := true; paused; {
+		select {
+		case <-ctx.Done():
+			paused = false
+		case <-ticker.C:
+			paused = r.hooks.PauseRequested(phase)
+		}
+	}
+	pause.End = time.Now()
+
+	r.current.Pauses = append(r.current.Pauses, pause)
+	r.trace("pause", phase, map[string]interface{}{
+		"duration_ms": float64(pause.Duration().Microseconds()) / 1000,
+	})
+	r.emit(EventPause, map[string]interface{}{
+		"before":      phase,
+		"start":       pause.Start.Format(time.RFC3339Nano),
+		"duration_ms": durationMillis(pause.Duration()),
+	})
+	fmt.Printf("▶️  Drill continued after %s paused\n", formatDuration(pause.Duration()))
+}
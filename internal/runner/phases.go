@@ -0,0 +1,51 @@
+package runner
+
+import "time"
+
+// PhaseTiming is when a phase of the drill started and ended
+type PhaseTiming struct {
+	Name     string
+	Start    time.Time
+	End      time.Time
+	Duration time.Duration
+}
+
+// enterPhase ends the phase in progress and starts timing phase. Entering
+// the phase already in progress continues it.
+func (r *Runner) enterPhase(phase string) {
+	result := r.current
+	if result == nil {
+		return
+	}
+	if n := len(result.Phases); n > 0 && result.Phases[n-1].Name == phase && result.Phases[n-1].End.IsZero() {
+		return
+	}
+	now := time.Now()
+	r.endPhase(now)
+	result.Phases = append(result.Phases, PhaseTiming{Name: phase, Start: now})
+}
+
+// endPhase ends the phase in progress, if any, at end
+func (r *Runner) endPhase(end time.Time) {
+	result := r.current
+	if result == nil || len(result.Phases) == 0 {
+		return
+	}
+	p := &result.Phases[len(result.Phases)-1]
+	if p.End.IsZero() {
+		p.End = end
+		p.Duration = end.Sub(p.Start)
+	}
+}
+
+// setPhaseEnd corrects the end of the last phase named phase, for a phase
+// that kept running in the background after the next one started
+func (d *DrillResult) setPhaseEnd(phase string, end time.Time) {
+	for i := len(d.Phases) - 1; i >= 0; i-- {
+		if d.Phases[i].Name == phase {
+			d.Phases[i].End = end
+			d.Phases[i].Duration = end.Sub(d.Phases[i].Start)
+			return
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// defaultProbeTimeout is used by built-in probes when no timeout is configured
+const defaultProbeTimeout = 10 * time.Second
+
+// maxProbeBodySize limits how much of a response body is read and stored
+const maxProbeBodySize = 64 * 1024
+
+// ProbeDetails holds structured data captured by a built-in probe
+type ProbeDetails struct {
+	Type       string
+	Target     string
+	StatusCode int
+	Latency    time.Duration
+	Value      *float64 // Metric value for metric-based probes
+	SignaledAt time.Time // When the latest event arrived, for push-based probes
+}
+
+// Probe checks service health; a zero exit code in the result means healthy
+type Probe interface {
+	Check(ctx context.Context) *CommandResult
+}
+
+// newProbe builds the health probe configured by the scenario
+func (r *Runner) newProbe(scenario *config.Scenario) (Probe, error) {
+	if scenario.HealthCheck == nil {
+		return &commandProbe{runner: r, command: scenario.HealthCheckCommand, execution: scenario.Execution, shell: scenario.Shell}, nil
+	}
+	if r.sim != nil {
+		return newSimulatedProbe(r, scenario.HealthCheck), nil
+	}
+
+	timeout, err := scenario.HealthCheck.GetTimeout()
+	if err != nil {
+		return nil, fmt.Errorf("invalid health_check timeout: %w", err)
+	}
+	if timeout == 0 {
+		timeout = defaultProbeTimeout
+	}
+
+	switch scenario.HealthCheck.Type {
+	case "http":
+		return newHTTPProbe(scenario.HealthCheck, timeout)
+	case "cloudwatch":
+		return newCloudWatchProbe(r, scenario.HealthCheck)
+	case "prometheus":
+		return newPrometheusProbe(scenario.HealthCheck, timeout)
+	case "webhook":
+		return newWebhookProbe(scenario.HealthCheck)
+	case "tcp":
+		return &tcpProbe{
+			address: net.JoinHostPort(scenario.HealthCheck.Host, strconv.Itoa(scenario.HealthCheck.Port)),
+			timeout: timeout,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown health_check type %q", scenario.HealthCheck.Type)
+	}
+}
+
+// commandProbe runs health_check_command through the scenario's shell
+type commandProbe struct {
+	runner    *Runner
+	command   string
+	execution *config.Execution
+	shell     *config.Shell
+}
+
+func (p *commandProbe) Check(ctx context.Context) *CommandResult {
+	return p.runner.executeStepCommand(ctx, p.execution, p.shell, p.command)
+}
+
+// httpProbe issues a GET request and checks the status code and body
+type httpProbe struct {
+	url            string
+	expectedStatus int
+	bodyRegex      *regexp.Regexp
+	client         *http.Client
+}
+
+func newHTTPProbe(hc *config.HealthCheck, timeout time.Duration) (*httpProbe, error) {
+	p := &httpProbe{
+		url:            hc.URL,
+		expectedStatus: hc.ExpectedStatus,
+		client:         &http.Client{Timeout: timeout},
+	}
+	if p.expectedStatus == 0 {
+		p.expectedStatus = http.StatusOK
+	}
+	if hc.BodyRegex != "" {
+		re, err := regexp.Compile(hc.BodyRegex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid health_check body_regex: %w", err)
+		}
+		p.bodyRegex = re
+	}
+	return p, nil
+}
+
+func (p *httpProbe) Check(ctx context.Context) *CommandResult {
+	result := &CommandResult{
+		Command:   fmt.Sprintf("http GET %s", p.url),
+		Timestamp: time.Now(),
+		Probe: &ProbeDetails{
+			Type:   "http",
+			Target: p.url,
+		},
+	}
+
+	start := time.Now()
+	defer func() {
+		result.Duration = time.Since(start)
+		result.Probe.Latency = result.Duration
+		result.StdoutHash = hashString(result.Stdout)
+		result.StderrHash = hashString(result.Stderr)
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		result.ExitCode = 1
+		result.Stderr = fmt.Sprintf("invalid request: %v", err)
+		return result
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		result.ExitCode = 1
+		result.Stderr = fmt.Sprintf("request failed: %v", err)
+		return result
+	}
+	defer resp.Body.Close()
+
+	result.Probe.StatusCode = resp.StatusCode
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxProbeBodySize))
+	result.Stdout = string(body)
+	if err != nil {
+		result.ExitCode = 1
+		result.Stderr = fmt.Sprintf("failed to read response body: %v", err)
+		return result
+	}
+
+	if resp.StatusCode != p.expectedStatus {
+		result.ExitCode = 1
+		result.Stderr = fmt.Sprintf("unexpected status %d (expected %d)", resp.StatusCode, p.expectedStatus)
+		return result
+	}
+
+	if p.bodyRegex != nil && !p.bodyRegex.Match(body) {
+		result.ExitCode = 1
+		result.Stderr = fmt.Sprintf("response body does not match %q", p.bodyRegex.String())
+		return result
+	}
+
+	return result
+}
+
+// tcpProbe checks that a TCP connection can be established
+type tcpProbe struct {
+	address string
+	timeout time.Duration
+}
+
+func (p *tcpProbe) Check(ctx context.Context) *CommandResult {
+	result := &CommandResult{
+		Command:   fmt.Sprintf("tcp connect %s", p.address),
+		Timestamp: time.Now(),
+		Probe: &ProbeDetails{
+			Type:   "tcp",
+			Target: p.address,
+		},
+	}
+
+	dialer := net.Dialer{Timeout: p.timeout}
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	result.Duration = time.Since(start)
+	result.Probe.Latency = result.Duration
+
+	if err != nil {
+		result.ExitCode = 1
+		result.Stderr = fmt.Sprintf("connection failed: %v", err)
+	} else {
+		conn.Close()
+		result.Stdout = fmt.Sprintf("connected to %s", p.address)
+	}
+	result.StdoutHash = hashString(result.Stdout)
+	result.StderrHash = hashString(result.Stderr)
+
+	return result
+}
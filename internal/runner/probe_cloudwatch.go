@@ -0,0 +1,195 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// defaultCloudWatchPeriod is used when a cloudwatch probe sets no period
+const defaultCloudWatchPeriod = time.Minute
+
+// cloudwatchStatistics are the standard statistics; anything else (p99, tm90)
+// is passed to CloudWatch as an extended statistic
+var cloudwatchStatistics = map[string]bool{
+	"SampleCount": true,
+	"Average":     true,
+	"Sum":         true,
+	"Minimum":     true,
+	"Maximum":     true,
+}
+
+// cloudwatchProbe evaluates the latest datapoint of a CloudWatch metric
+// against a threshold condition. It queries CloudWatch through the aws CLI so
+// that credentials, profiles, and regions resolve exactly as they do for the
+// scenario's other commands.
+type cloudwatchProbe struct {
+	runner     *Runner
+	namespace  string
+	metric     string
+	statistic  string
+	dimensions map[string]string
+	period     time.Duration
+	region     string
+	condition  config.Condition
+}
+
+func newCloudWatchProbe(r *Runner, hc *config.HealthCheck) (*cloudwatchProbe, error) {
+	condition, err := config.ParseCondition(hc.Condition)
+	if err != nil {
+		return nil, fmt.Errorf("invalid health_check condition: %w", err)
+	}
+
+	period := defaultCloudWatchPeriod
+	if hc.Period != "" {
+		if period, err = time.ParseDuration(hc.Period); err != nil {
+			return nil, fmt.Errorf("invalid health_check period: %w", err)
+		}
+	}
+
+	return &cloudwatchProbe{
+		runner:     r,
+		namespace:  hc.Namespace,
+		metric:     hc.Metric,
+		statistic:  hc.Statistic,
+		dimensions: hc.Dimensions,
+		period:     period,
+		region:     hc.Region,
+		condition:  condition,
+	}, nil
+}
+
+// args builds the aws CLI invocation for the most recent few periods
+func (p *cloudwatchProbe) args(now time.Time) []string {
+	args := []string{
+		"aws", "cloudwatch", "get-metric-statistics",
+		"--namespace", p.namespace,
+		"--metric-name", p.metric,
+		"--period", strconv.Itoa(int(p.period.Seconds())),
+		"--start-time", now.Add(-3 * p.period).UTC().Format(time.RFC3339),
+		"--end-time", now.UTC().Format(time.RFC3339),
+		"--output", "json",
+	}
+	if cloudwatchStatistics[p.statistic] {
+		args = append(args, "--statistics", p.statistic)
+	} else {
+		args = append(args, "--extended-statistics", p.statistic)
+	}
+	if len(p.dimensions) > 0 {
+		names := make([]string, 0, len(p.dimensions))
+		for name := range p.dimensions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		args = append(args, "--dimensions")
+		for _, name := range names {
+			args = append(args, fmt.Sprintf("Name=%s,Value=%s", name, p.dimensions[name]))
+		}
+	}
+	if p.region != "" {
+		args = append(args, "--region", p.region)
+	}
+
+	return args
+}
+
+// cloudwatchDatapoint is the subset of a get-metric-statistics datapoint we use
+type cloudwatchDatapoint struct {
+	Timestamp          time.Time          `json:"Timestamp"`
+	SampleCount        *float64           `json:"SampleCount"`
+	Average            *float64           `json:"Average"`
+	Sum                *float64           `json:"Sum"`
+	Minimum            *float64           `json:"Minimum"`
+	Maximum            *float64           `json:"Maximum"`
+	ExtendedStatistics map[string]float64 `json:"ExtendedStatistics"`
+}
+
+func (d cloudwatchDatapoint) value(statistic string) (float64, bool) {
+	var v *float64
+	switch statistic {
+	case "SampleCount":
+		v = d.SampleCount
+	case "Average":
+		v = d.Average
+	case "Sum":
+		v = d.Sum
+	case "Minimum":
+		v = d.Minimum
+	case "Maximum":
+		v = d.Maximum
+	default:
+		ext, ok := d.ExtendedStatistics[statistic]
+		return ext, ok
+	}
+	if v == nil {
+		return 0, false
+	}
+	return *v, true
+}
+
+func (p *cloudwatchProbe) Check(ctx context.Context) *CommandResult {
+	args := p.args(time.Now())
+	result := p.runner.executeArgs(ctx, quoteArgs(args), args)
+	result.Probe = &ProbeDetails{
+		Type:    "cloudwatch",
+		Target:  fmt.Sprintf("%s/%s %s", p.namespace, p.metric, p.statistic),
+		Latency: result.Duration,
+	}
+	if result.ExitCode != 0 {
+		return result
+	}
+
+	fail := func(format string, args ...interface{}) *CommandResult {
+		result.ExitCode = 1
+		result.Stderr = strings.TrimSpace(result.Stderr + "\n" + fmt.Sprintf(format, args...))
+		result.StderrHash = hashString(result.Stderr)
+		return result
+	}
+
+	var out struct {
+		Datapoints []cloudwatchDatapoint `json:"Datapoints"`
+	}
+	if err := json.Unmarshal([]byte(result.Stdout), &out); err != nil {
+		return fail("invalid aws cloudwatch output: %v", err)
+	}
+	if len(out.Datapoints) == 0 {
+		return fail("no datapoints in the last %s", 3*p.period)
+	}
+
+	sort.Slice(out.Datapoints, func(i, j int) bool {
+		return out.Datapoints[i].Timestamp.After(out.Datapoints[j].Timestamp)
+	})
+	value, ok := out.Datapoints[0].value(p.statistic)
+	if !ok {
+		return fail("latest datapoint has no %s statistic", p.statistic)
+	}
+	result.Probe.Value = &value
+
+	if !p.condition.Holds(value) {
+		return fail("value %g does not satisfy %s", value, p.condition)
+	}
+	return result
+}
+
+// shellQuote quotes s for safe use as a single bash word
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=,@") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// quoteArgs joins args into a bash command line
+func quoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,159 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// prometheusProbe evaluates a PromQL query and checks every returned sample
+// against a threshold condition
+type prometheusProbe struct {
+	endpoint  string
+	query     string
+	condition config.Condition
+	client    *http.Client
+}
+
+func newPrometheusProbe(hc *config.HealthCheck, timeout time.Duration) (*prometheusProbe, error) {
+	condition, err := config.ParseCondition(hc.Condition)
+	if err != nil {
+		return nil, fmt.Errorf("invalid health_check condition: %w", err)
+	}
+	return &prometheusProbe{
+		endpoint:  strings.TrimRight(hc.URL, "/") + "/api/v1/query",
+		query:     hc.Query,
+		condition: condition,
+		client:    &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// promQueryResponse is the subset of the Prometheus query API response we use
+type promQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+func (p *prometheusProbe) Check(ctx context.Context) *CommandResult {
+	result := &CommandResult{
+		Command:   fmt.Sprintf("promql %s %s", p.query, p.condition),
+		Timestamp: time.Now(),
+		Probe: &ProbeDetails{
+			Type:   "prometheus",
+			Target: p.endpoint,
+		},
+	}
+
+	start := time.Now()
+	defer func() {
+		result.Duration = time.Since(start)
+		result.Probe.Latency = result.Duration
+		result.StdoutHash = hashString(result.Stdout)
+		result.StderrHash = hashString(result.Stderr)
+	}()
+
+	fail := func(format string, args ...interface{}) *CommandResult {
+		result.ExitCode = 1
+		result.Stderr = fmt.Sprintf(format, args...)
+		return result
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+"?query="+url.QueryEscape(p.query), nil)
+	if err != nil {
+		return fail("invalid request: %v", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fail("query failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	result.Probe.StatusCode = resp.StatusCode
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxProbeBodySize))
+	result.Stdout = string(body)
+	if err != nil {
+		return fail("failed to read response body: %v", err)
+	}
+
+	var qr promQueryResponse
+	if err := json.Unmarshal(body, &qr); err != nil {
+		return fail("invalid Prometheus response: %v", err)
+	}
+	if qr.Status != "success" {
+		return fail("query error: %s", qr.Error)
+	}
+
+	values, err := promValues(qr.Data.ResultType, qr.Data.Result)
+	if err != nil {
+		return fail("%v", err)
+	}
+	if len(values) == 0 {
+		return fail("query returned no data")
+	}
+
+	result.Probe.Value = &values[0]
+	for _, v := range values {
+		if !p.condition.Holds(v) {
+			result.Probe.Value = &v
+			return fail("value %g does not satisfy %s", v, p.condition)
+		}
+	}
+
+	return result
+}
+
+// promValues extracts sample values from a vector or scalar query result
+func promValues(resultType string, raw json.RawMessage) ([]float64, error) {
+	switch resultType {
+	case "vector":
+		var series []struct {
+			Value [2]interface{} `json:"value"`
+		}
+		if err := json.Unmarshal(raw, &series); err != nil {
+			return nil, fmt.Errorf("invalid vector result: %w", err)
+		}
+		values := make([]float64, 0, len(series))
+		for _, s := range series {
+			v, err := promSampleValue(s.Value)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	case "scalar":
+		var sample [2]interface{}
+		if err := json.Unmarshal(raw, &sample); err != nil {
+			return nil, fmt.Errorf("invalid scalar result: %w", err)
+		}
+		v, err := promSampleValue(sample)
+		if err != nil {
+			return nil, err
+		}
+		return []float64{v}, nil
+	default:
+		return nil, fmt.Errorf("unsupported result type %q (use an instant vector or scalar query)", resultType)
+	}
+}
+
+// promSampleValue parses the string value of a [timestamp, "value"] pair
+func promSampleValue(sample [2]interface{}) (float64, error) {
+	s, ok := sample[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("invalid sample value %v", sample[1])
+	}
+	return strconv.ParseFloat(s, 64)
+}
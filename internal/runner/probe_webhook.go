@@ -0,0 +1,130 @@
+package runner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// webhookProbe listens for health events pushed by an external system, such
+// as an orchestrator reporting that failover completed. The service counts
+// as unhealthy until a healthy event arrives.
+type webhookProbe struct {
+	listener net.Listener
+	server   *http.Server
+	path     string
+	token    string
+
+	mu         sync.Mutex
+	healthy    bool
+	signaledAt time.Time
+	lastEvent  string
+	events     int
+}
+
+// webhookEvent is the optional JSON body of a health event
+type webhookEvent struct {
+	Status string `json:"status"` // healthy (default) or unhealthy
+}
+
+func newWebhookProbe(hc *config.HealthCheck) (*webhookProbe, error) {
+	p := &webhookProbe{path: hc.Path, token: hc.Token}
+	if p.path == "" {
+		p.path = "/"
+	}
+
+	listener, err := net.Listen("tcp", hc.Listen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for health_check webhook: %w", err)
+	}
+	p.listener = listener
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(p.path, p.handle)
+	p.server = &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go p.server.Serve(listener)
+	fmt.Printf("Waiting for health events on POST http://%s%s\n", listener.Addr(), p.path)
+
+	return p, nil
+}
+
+func (p *webhookProbe) handle(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if p.token != "" && req.Header.Get("Authorization") != "Bearer "+p.token {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, maxProbeBodySize))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	event := webhookEvent{Status: "healthy"}
+	if len(strings.TrimSpace(string(body))) > 0 {
+		if err := json.Unmarshal(body, &event); err != nil {
+			http.Error(w, fmt.Sprintf("invalid event: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+	if event.Status != "healthy" && event.Status != "unhealthy" {
+		http.Error(w, fmt.Sprintf("unknown status %q (expected healthy or unhealthy)", event.Status), http.StatusBadRequest)
+		return
+	}
+
+	p.mu.Lock()
+	p.healthy = event.Status == "healthy"
+	p.signaledAt = time.Now()
+	p.lastEvent = string(body)
+	p.events++
+	p.mu.Unlock()
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (p *webhookProbe) Check(ctx context.Context) *CommandResult {
+	target := fmt.Sprintf("http://%s%s", p.listener.Addr(), p.path)
+	result := &CommandResult{
+		Command:   fmt.Sprintf("webhook POST %s", target),
+		Timestamp: time.Now(),
+		Probe: &ProbeDetails{
+			Type:   "webhook",
+			Target: target,
+		},
+	}
+
+	p.mu.Lock()
+	healthy, signaledAt, lastEvent, events := p.healthy, p.signaledAt, p.lastEvent, p.events
+	p.mu.Unlock()
+
+	result.Probe.SignaledAt = signaledAt
+	result.Stdout = lastEvent
+	switch {
+	case events == 0:
+		result.ExitCode = 1
+		result.Stderr = "no health event received yet"
+	case !healthy:
+		result.ExitCode = 1
+		result.Stderr = fmt.Sprintf("unhealthy event received at %s", signaledAt.Format(time.RFC3339))
+	}
+	result.StdoutHash = hashString(result.Stdout)
+	result.StderrHash = hashString(result.Stderr)
+
+	return result
+}
+
+// Close stops listening for health events
+func (p *webhookProbe) Close() error {
+	return p.server.Close()
+}
@@ -0,0 +1,84 @@
+package runner
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// prober runs health checks in the background and delivers their results in
+// completion order
+type prober struct {
+	results chan *CommandResult
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+// startProbing begins checking health. With a concurrency of 1, each check
+// waits for the interval after the previous one completes. With more, a check
+// starts every interval while up to that many are still in flight, so slow
+// probes don't stretch the sampling interval.
+func (r *Runner) startProbing(ctx context.Context, probe Probe) *prober {
+	ctx, cancel := context.WithCancel(ctx)
+	p := &prober{
+		results: make(chan *CommandResult),
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	check := func() {
+		checkCtx, cancelCheck := context.WithTimeout(ctx, r.healthCheckTimeout)
+		attempt := probe.Check(checkCtx)
+		cancelCheck()
+		select {
+		case p.results <- attempt:
+		case <-ctx.Done():
+		}
+	}
+
+	go func() {
+		defer close(p.done)
+		if r.probeConcurrency <= 1 {
+			for {
+				check()
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(r.probeInterval):
+				}
+			}
+		}
+
+		var wg sync.WaitGroup
+		defer wg.Wait()
+		inFlight := make(chan struct{}, r.probeConcurrency)
+		ticker := time.NewTicker(r.probeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case inFlight <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				check()
+				<-inFlight
+			}()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return p
+}
+
+// stop cancels in-flight checks and waits for the prober to exit
+func (p *prober) stop() {
+	p.cancel()
+	<-p.done
+}
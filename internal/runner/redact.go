@@ -0,0 +1,95 @@
+package runner
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// redactedValue replaces secrets in command output
+const redactedValue = "********"
+
+// redactor removes secrets from command output before it is stored
+type redactor struct {
+	values   []string
+	patterns []*regexp.Regexp
+}
+
+// newRedactor builds the redactor for the scenario's secret values and redact
+// patterns, or returns nil when there is nothing to redact
+func newRedactor(scenario *config.Scenario) (*redactor, error) {
+	rd := &redactor{values: scenario.SecretValues()}
+	if scenario.Redact != nil {
+		patterns, err := scenario.Redact.CompilePatterns()
+		if err != nil {
+			return nil, err
+		}
+		rd.patterns = patterns
+	}
+	if len(rd.values) == 0 && len(rd.patterns) == 0 {
+		return nil, nil
+	}
+	return rd, nil
+}
+
+// redact replaces secret values, then pattern matches, in s
+func (rd *redactor) redact(s string) string {
+	for _, v := range rd.values {
+		s = strings.ReplaceAll(s, v, redactedValue)
+	}
+	for _, re := range rd.patterns {
+		if re.NumSubexp() == 0 {
+			s = re.ReplaceAllLiteralString(s, redactedValue)
+			continue
+		}
+		var b strings.Builder
+		last := 0
+		for _, m := range re.FindAllStringSubmatchIndex(s, -1) {
+			if m[2] < 0 {
+				continue
+			}
+			b.WriteString(s[last:m[2]])
+			b.WriteString(redactedValue)
+			last = m[3]
+		}
+		b.WriteString(s[last:])
+		s = b.String()
+	}
+	return s
+}
+
+// apply redacts a command's stdout and stderr, and rehashes them so the
+// stored output still matches its hash; it does nothing on a nil redactor
+func (rd *redactor) apply(result *CommandResult) {
+	if rd == nil {
+		return
+	}
+	result.Stdout = rd.redact(result.Stdout)
+	result.Stderr = rd.redact(result.Stderr)
+	result.StdoutHash = hashString(result.Stdout)
+	result.StderrHash = hashString(result.Stderr)
+}
+
+// redactedProbe redacts the output of built-in probes, such as HTTP
+// response bodies, which don't run through a command
+type redactedProbe struct {
+	Probe
+	redactor *redactor
+}
+
+func (p *redactedProbe) Check(ctx context.Context) *CommandResult {
+	result := p.Probe.Check(ctx)
+	p.redactor.apply(result)
+	return result
+}
+
+// Close stops the wrapped probe if it holds resources
+func (p *redactedProbe) Close() error {
+	if closer, ok := p.Probe.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package runner
+
+import (
+	"context"
+	"time"
+)
+
+// repeater runs a function every interval in the background until stopped
+type repeater struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// startRepeater runs fn once, then again every interval until stop is called
+func startRepeater(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) *repeater {
+	rep := newRepeater(ctx)
+	fn(rep.ctx)
+	go rep.loop(interval, fn, false)
+	return rep
+}
+
+// startRepeaterAsync is startRepeater with the first run in the background
+// too, so the caller doesn't wait for it
+func startRepeaterAsync(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) *repeater {
+	rep := newRepeater(ctx)
+	go rep.loop(interval, fn, true)
+	return rep
+}
+
+func newRepeater(ctx context.Context) *repeater {
+	ctx, cancel := context.WithCancel(ctx)
+	return &repeater{ctx: ctx, cancel: cancel, done: make(chan struct{})}
+}
+
+func (rep *repeater) loop(interval time.Duration, fn func(ctx context.Context), now bool) {
+	defer close(rep.done)
+	if now {
+		fn(rep.ctx)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-rep.ctx.Done():
+			return
+		case <-ticker.C:
+			fn(rep.ctx)
+		}
+	}
+}
+
+// stop cancels the function in progress and waits for the repeater to exit
+func (rep *repeater) stop() {
+	rep.cancel()
+	<-rep.done
+}
@@ -0,0 +1,87 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// RestoreResult is the outcome of restore_check
+type RestoreResult struct {
+	Restore         *CommandResult
+	RestoreTime     time.Duration
+	Target          time.Duration // Zero when no target is set
+	IntegrityChecks []GuardResult
+	Cleanup         *CommandResult
+	Passed          bool
+	Reason          string // Why the restore check failed
+}
+
+// DataVerified reports whether the backup restored and every integrity check
+// passed, regardless of how long the restore took
+func (r *RestoreResult) DataVerified() bool {
+	if r.Restore == nil || !r.Restore.Succeeded() {
+		return false
+	}
+	for _, g := range r.IntegrityChecks {
+		if !g.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// checkRestore restores a backup into the scratch target, times it, runs the
+// integrity checks against the restored data, and cleans up
+func (r *Runner) checkRestore(ctx context.Context, rc *config.RestoreCheck, result *DrillResult) {
+	target, _ := rc.GetTarget()
+	restore := &RestoreResult{Target: target}
+	result.RestoreCheck = restore
+	var reasons []string
+
+	fmt.Println("Restoring backup into the scratch target...")
+	restore.Restore = r.runStep(ctx, rc.Command)
+	restore.RestoreTime = restore.Restore.Duration
+	if !restore.Restore.Succeeded() {
+		reasons = append(reasons, fmt.Sprintf("restore command failed with exit code %d", restore.Restore.ExitCode))
+	} else {
+		fmt.Printf("Restore completed in %s\n", formatDuration(restore.RestoreTime))
+		if target > 0 && restore.RestoreTime > target {
+			reasons = append(reasons, fmt.Sprintf("restore took %s, longer than the target of %s", formatDuration(restore.RestoreTime), formatDuration(target)))
+		}
+
+		// Every integrity check runs, so the report shows each one
+		for _, check := range rc.IntegrityChecks {
+			g := GuardResult{
+				Name:      check.Name,
+				Condition: check.Condition,
+				Result:    r.runStep(ctx, check.Command),
+			}
+			g.Reason = evaluateGuard(&g)
+			g.Passed = g.Reason == ""
+			if g.Passed {
+				fmt.Printf("✅ Integrity check %q passed\n", g.Name)
+			} else {
+				reasons = append(reasons, fmt.Sprintf("integrity check %q failed: %s", g.Name, g.Reason))
+				fmt.Printf("❌ Integrity check %q failed: %s\n", g.Name, g.Reason)
+			}
+			restore.IntegrityChecks = append(restore.IntegrityChecks, g)
+		}
+	}
+
+	if rc.CleanupCommand.IsSet() {
+		restore.Cleanup = r.runStep(ctx, rc.CleanupCommand)
+		if !restore.Cleanup.Succeeded() {
+			result.Errors = append(result.Errors, fmt.Sprintf("restore_check cleanup_command failed with exit code %d", restore.Cleanup.ExitCode))
+		}
+	}
+
+	restore.Passed = len(reasons) == 0
+	restore.Reason = strings.Join(reasons, "; ")
+	for _, reason := range reasons {
+		result.Errors = append(result.Errors, "restore_check: "+reason)
+	}
+}
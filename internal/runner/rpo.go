@@ -0,0 +1,160 @@
+package runner
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// RPOMeasured reports whether data loss was measured from snapshot
+// timestamps, marker records, or verify_command output
+func (d *DrillResult) RPOMeasured() bool {
+	return d.RPOFromOutput || (!d.PreSnapshotTime.IsZero() && !d.PostSnapshotTime.IsZero())
+}
+
+// readVerifyOutput parses the data loss verify_command printed and compares
+// missing rows against max_rows; seconds are compared with the RPO target
+// like any other measured data loss
+func readVerifyOutput(v *config.VerifyOutput, result *DrillResult) {
+	n, err := parseVerifyValue(result.RPOVerify.Stdout, v)
+	if err != nil {
+		result.RPOPassed = false
+		result.Errors = append(result.Errors, fmt.Sprintf("rpo_check.verify_output: %v", err))
+		return
+	}
+
+	if v.Rows() {
+		missing := int(n)
+		result.MissingRows = &missing
+		result.MaxMissingRows = v.MaxRows
+		if missing > v.MaxRows {
+			result.RPOPassed = false
+			result.Errors = append(result.Errors, fmt.Sprintf("%d missing row(s) exceed the maximum of %d", missing, v.MaxRows))
+		}
+		fmt.Printf("Data loss: %d missing row(s) (max: %d)\n", missing, v.MaxRows)
+		return
+	}
+
+	result.RPOActual = time.Duration(n * float64(time.Second))
+	result.RPOFromOutput = true
+	fmt.Printf("Data loss: %s (from verify_command output)\n", formatDuration(result.RPOActual))
+}
+
+// parseVerifyValue extracts the non-negative number verify_command printed
+func parseVerifyValue(output string, v *config.VerifyOutput) (float64, error) {
+	value, err := extractValue(output, v.Regex)
+	if err != nil {
+		return 0, err
+	}
+	if value == "" {
+		return 0, fmt.Errorf("command printed no value")
+	}
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("cannot parse %q as a non-negative number", value)
+	}
+	return n, nil
+}
+
+// measureDataLoss reads the latest write time from both snapshots and records
+// how much of the data written before the disruption was missing afterwards
+func measureDataLoss(ts *config.SnapshotTimestamp, result *DrillResult) {
+	if result.PreSnapshot == nil || result.PostSnapshot == nil {
+		return
+	}
+
+	pre, post, err := snapshotTimes(result.PreSnapshot, result.PostSnapshot, ts)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("rpo_check.timestamp: %v", err))
+		return
+	}
+
+	result.PreSnapshotTime = pre
+	result.PostSnapshotTime = post
+	result.RPOActual = dataLoss(pre, post)
+	fmt.Printf("Data loss: %s (latest write %s before, %s after)\n",
+		formatDuration(result.RPOActual), pre.UTC().Format(time.RFC3339Nano), post.UTC().Format(time.RFC3339Nano))
+}
+
+// snapshotTimes reads the latest write time from both snapshots
+func snapshotTimes(preSnapshot, postSnapshot *CommandResult, ts *config.SnapshotTimestamp) (time.Time, time.Time, error) {
+	pre, err := parseSnapshotTime(preSnapshot.Stdout, ts)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("pre_snapshot: %w", err)
+	}
+	post, err := parseSnapshotTime(postSnapshot.Stdout, ts)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("post_snapshot: %w", err)
+	}
+	return pre, post, nil
+}
+
+// dataLoss returns how far the latest write after recovery is behind the
+// latest write before the disruption
+func dataLoss(pre, post time.Time) time.Duration {
+	if pre.After(post) {
+		return pre.Sub(post)
+	}
+	return 0
+}
+
+// parseSnapshotTime extracts the latest write time from snapshot output
+func parseSnapshotTime(output string, ts *config.SnapshotTimestamp) (time.Time, error) {
+	value, err := extractValue(output, ts.Regex)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if value == "" {
+		return time.Time{}, fmt.Errorf("command printed no timestamp")
+	}
+
+	switch ts.Format {
+	case "":
+		if t, ok := parseTimeValue(value); ok {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("cannot parse timestamp %q (expected RFC 3339 or Unix seconds)", value)
+	case "unix", "unix_ms":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cannot parse timestamp %q as %s", value, ts.Format)
+		}
+		unit := float64(time.Second)
+		if ts.Format == "unix_ms" {
+			unit = float64(time.Millisecond)
+		}
+		return time.Unix(0, int64(n*unit)), nil
+	default:
+		t, err := time.Parse(ts.Format, value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("cannot parse timestamp %q with format %q", value, ts.Format)
+		}
+		return t, nil
+	}
+}
+
+// extractValue returns the first capture group of pattern in output, else the
+// whole match, or the last non-empty line when pattern is empty
+func extractValue(output, pattern string) (string, error) {
+	var value string
+	if pattern != "" {
+		// Validated with the scenario
+		re := regexp.MustCompile(pattern)
+		m := re.FindStringSubmatch(output)
+		if m == nil {
+			return "", fmt.Errorf("output does not match %q", pattern)
+		}
+		value = m[0]
+		if len(m) > 1 {
+			value = m[1]
+		}
+	} else {
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		value = lines[len(lines)-1]
+	}
+	return strings.Trim(strings.TrimSpace(value), `"`), nil
+}
@@ -0,0 +1,152 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// RPOCheckResult is the outcome of one data store's check in rpo_checks
+type RPOCheckResult struct {
+	Name             string
+	Target           time.Duration // Zero when neither the check nor the scenario sets one
+	PreSnapshot      *CommandResult
+	PostSnapshot     *CommandResult
+	Verify           *CommandResult
+	SnapshotDiff     *SnapshotDiff
+	Actual           time.Duration // Measured data loss; set when Measured
+	Measured         bool
+	PreSnapshotTime  time.Time
+	PostSnapshotTime time.Time
+	MissingRows      *int
+	MaxMissingRows   int
+	Passed           bool
+	Reason           string // Why the check failed
+}
+
+// OnlyRPOChecks reports whether data loss was checked solely through
+// rpo_checks, so there is no scenario-wide RPO result of its own
+func (d *DrillResult) OnlyRPOChecks() bool {
+	return len(d.RPOChecks) > 0 && (d.Scenario == nil || d.Scenario.RPOCheck == nil)
+}
+
+// RPOChecksPassed reports whether every rpo_checks entry passed
+func (d *DrillResult) RPOChecksPassed() bool {
+	for _, c := range d.RPOChecks {
+		if !c.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// preSnapshotRPOChecks runs each check's pre_snapshot before the disruption
+func (r *Runner) preSnapshotRPOChecks(ctx context.Context, checks []config.NamedRPOCheck, rpoTarget time.Duration, result *DrillResult) {
+	result.RPOChecks = make([]RPOCheckResult, len(checks))
+	for i, c := range checks {
+		target, _ := c.GetTarget(rpoTarget)
+		result.RPOChecks[i] = RPOCheckResult{Name: c.Name, Target: target}
+		if c.PreSnapshot.IsSet() {
+			res := r.runStep(ctx, c.PreSnapshot)
+			result.RPOChecks[i].PreSnapshot = res
+			if !res.Succeeded() {
+				result.Errors = append(result.Errors, fmt.Sprintf("rpo_checks[%s]: pre_snapshot command failed with exit code %d", c.Name, res.ExitCode))
+			}
+		}
+	}
+}
+
+// postSnapshotRPOChecks runs each check's post_snapshot after recovery
+func (r *Runner) postSnapshotRPOChecks(ctx context.Context, checks []config.NamedRPOCheck, result *DrillResult) {
+	for i, c := range checks {
+		if !c.PostSnapshot.IsSet() {
+			continue
+		}
+		check := &result.RPOChecks[i]
+		res := r.runStep(ctx, c.PostSnapshot)
+		check.PostSnapshot = res
+		if !res.Succeeded() {
+			result.Errors = append(result.Errors, fmt.Sprintf("rpo_checks[%s]: post_snapshot command failed with exit code %d", c.Name, res.ExitCode))
+		}
+		if check.PreSnapshot != nil && check.PreSnapshot.Stdout != "" && res.Stdout != "" {
+			check.SnapshotDiff = diffSnapshots(check.PreSnapshot.Stdout, res.Stdout)
+		}
+	}
+}
+
+// verifyRPOChecks measures and verifies each check's data loss against its own
+// target. Every check runs even after a failure, so the report shows each
+// data store's outcome.
+func (r *Runner) verifyRPOChecks(ctx context.Context, checks []config.NamedRPOCheck, result *DrillResult) {
+	for i, c := range checks {
+		check := &result.RPOChecks[i]
+		var reasons []string
+
+		if c.Timestamp != nil && check.PreSnapshot != nil && check.PostSnapshot != nil {
+			pre, post, err := snapshotTimes(check.PreSnapshot, check.PostSnapshot, c.Timestamp)
+			if err != nil {
+				reasons = append(reasons, fmt.Sprintf("timestamp: %v", err))
+			} else {
+				check.PreSnapshotTime, check.PostSnapshotTime = pre, post
+				check.Actual = dataLoss(pre, post)
+				check.Measured = true
+			}
+		} else if c.Timestamp != nil {
+			reasons = append(reasons, "timestamp: a snapshot is missing")
+		}
+
+		if c.VerifyCommand.IsSet() {
+			check.Verify = r.runStep(ctx, c.VerifyCommand)
+			if !check.Verify.Succeeded() {
+				reasons = append(reasons, fmt.Sprintf("verify_command failed with exit code %d", check.Verify.ExitCode))
+			}
+			if v := c.VerifyOutput; v != nil {
+				n, err := parseVerifyValue(check.Verify.Stdout, v)
+				switch {
+				case err != nil:
+					reasons = append(reasons, fmt.Sprintf("verify_output: %v", err))
+				case v.Rows():
+					missing := int(n)
+					check.MissingRows = &missing
+					check.MaxMissingRows = v.MaxRows
+					if missing > v.MaxRows {
+						reasons = append(reasons, fmt.Sprintf("%d missing row(s) exceed the maximum of %d", missing, v.MaxRows))
+					}
+				default:
+					check.Actual = time.Duration(n * float64(time.Second))
+					check.Measured = true
+				}
+			}
+		}
+
+		if check.Measured && check.Target > 0 && check.Actual > check.Target {
+			reasons = append(reasons, fmt.Sprintf("data loss of %s exceeds the RPO target of %s", formatDuration(check.Actual), formatDuration(check.Target)))
+		}
+
+		check.Passed = len(reasons) == 0
+		check.Reason = strings.Join(reasons, "; ")
+		status := "✅ PASS"
+		if !check.Passed {
+			status = "❌ FAIL"
+			result.Errors = append(result.Errors, fmt.Sprintf("rpo_checks[%s]: %s", c.Name, check.Reason))
+		}
+		fmt.Printf("RPO check %s: %s - %s\n", c.Name, formatRPOCheckActual(check), status)
+	}
+}
+
+// formatRPOCheckActual describes the data loss a check measured
+func formatRPOCheckActual(check *RPOCheckResult) string {
+	switch {
+	case check.MissingRows != nil:
+		return fmt.Sprintf("%d missing row(s) (max: %d)", *check.MissingRows, check.MaxMissingRows)
+	case check.Measured && check.Target > 0:
+		return fmt.Sprintf("%s data loss (target: %s)", formatDuration(check.Actual), formatDuration(check.Target))
+	case check.Measured:
+		return fmt.Sprintf("%s data loss", formatDuration(check.Actual))
+	default:
+		return "verified"
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"regexp"
+	"runtime"
 	"strings"
 	"time"
 
@@ -22,6 +27,38 @@ type CommandResult struct {
 	Timestamp   time.Time
 	StdoutHash  string
 	StderrHash  string
+	Probe       *ProbeDetails  // Set when the result comes from a built-in probe
+	StdoutFile  string  // Compressed full stdout, relative to the output directory
+	StderrFile  string  // Compressed full stderr, relative to the output directory
+	ExpectedExitCodes []int  // Exit codes that count as success; empty means only 0
+	Host        string  // Execution target (ssh destination or pod); empty when run locally
+	RunAs       string  // User the command ran as via sudo; empty when run as the invoking user
+	Members     []CommandResult  // Per-host results when the command targeted a host group
+	ExpectedStdout   []string  // Patterns stdout must match
+	StdoutMismatches []string  // ExpectedStdout patterns stdout did not match
+	Degraded    bool  // A health check that found the service up but impaired, per the scenario's degraded block
+	MaxOutput   int   // The step's max_output; zero means the scenario's
+	StdoutOmitted int  // Bytes of stdout dropped by max_output
+	StderrOmitted int  // Bytes of stderr dropped by max_output
+}
+
+// Succeeded reports whether the exit code is one the step expects
+func (c *CommandResult) Succeeded() bool {
+	if len(c.ExpectedExitCodes) == 0 {
+		return c.ExitCode == 0
+	}
+	for _, code := range c.ExpectedExitCodes {
+		if code == c.ExitCode {
+			return true
+		}
+	}
+	return false
+}
+
+// LabeledCommand pairs a command result with a stable, file-name-safe label
+type LabeledCommand struct {
+	Label  string
+	Result *CommandResult
 }
 
 // DrillResult holds the complete result of a drill execution
@@ -29,28 +66,210 @@ type DrillResult struct {
 	Scenario          *config.Scenario
 	StartTime         time.Time
 	EndTime           time.Time
+	BackupFreshness   *CommandResult  // Pre-drill check of the latest backup time
+	BackupAge         time.Duration
+	BackupStale       bool  // The latest backup was older than the RPO target; the drill was not run
 	PreSnapshot       *CommandResult
+	Guards            []GuardResult  // Preconditions checked before the disruption
+	GuardFailed       bool  // A guard failed; the disruption was not run
 	Disrupt           *CommandResult
 	Recover           *CommandResult
+	Abort             *CommandResult  // Rollback run when the drill aborted
 	PostDisruptDelay  time.Duration
 	RTOStartTime      time.Time  // When service actually went down (first failed health check)
 	RTOEndTime        time.Time  // When service recovered (first successful health check)
-	RTA               time.Duration  // Recovery Time Actual - measured downtime
+	RTA               time.Duration  // Recovery Time Actual - measured downtime, from monotonic clock readings
+	WallClockRTA      time.Duration  // RTOEndTime minus RTOStartTime on the wall clock; differs from RTA if the system clock was stepped
 	RTOTarget         time.Duration  // Recovery Time Objective - maximum acceptable downtime
-	RTOPassed         bool  // Whether RTA <= RTOTarget
+	RTOPassed         bool  // Whether the counted RTA <= RTOTarget, or FailedProbes <= MaxFailedProbes when set
+	DowntimeGrace     time.Duration  // Expected downtime excluded from the RTO comparison
+	FailedProbes      int  // Failed health checks from the first failure until recovery
+	MaxFailedProbes   *int  // Success criterion used instead of RTA when set
+	HealthyAfter      int  // Consecutive passing health checks needed to declare recovery
+	RecoveryConfirmedTime time.Time  // When the last of those checks passed; RTOEndTime is the first
 	PostSnapshot      *CommandResult
 	RPOVerify         *CommandResult
 	RPOTarget         time.Duration
 	RPOPassed         bool
+	RPOActual         time.Duration  // Measured data loss; set when PreSnapshotTime and PostSnapshotTime are, or RPOFromOutput
+	RPOFromOutput     bool  // RPOActual was printed by verify_command
+	MissingRows       *int  // Missing rows printed by verify_command
+	MaxMissingRows    int
+	PreSnapshotTime   time.Time  // Latest write before the disruption, from rpo_check.timestamp or markers
+	PostSnapshotTime  time.Time  // Latest write after recovery
+	SnapshotDiff      *SnapshotDiff  // Set when both snapshots produced output
+	SnapshotSamples   []SnapshotSample  // post_snapshot runs during the drill, with rpo_check.snapshot_interval
+	SystemSamples     []SystemSample  // Host metrics sampled throughout the drill, with factors.system_metrics
+	Phases            []PhaseTiming  // Each phase entered, in order; recover overlaps rta_measurement
+	RPOChecks         []RPOCheckResult  // Per-data-store rpo_checks, in scenario order
+	RestoreCheck      *RestoreResult  // Backup restore into a scratch target
+	ConsistencyChecks []ConsistencyResult  // consistency_checks, in scenario order
+	Metrics           []MetricResult  // Custom metrics, in scenario order
+	Assertions        []AssertionResult  // Scenario assertions, in scenario order
+	SeedsWritten      int  // Successful seed_command runs before the disruption
+	SeedsFailed       int
+	Seed              *CommandResult  // Last seed_command run
+	MarkersWritten    int  // Marker records written before the disruption
+	MarkersFailed     int
+	MarkerRead        *CommandResult  // rpo_check.markers read_command
 	HealthCheckAttempts []CommandResult
 	FactorLogs        []CommandResult
 	Errors            []string
+	AbortAfter        time.Duration  // Downtime after which the drill aborts; zero if not set
+	Aborted           bool  // The service was still down after AbortAfter; measurement stopped and the abort command ran
+	IdempotencyKey    string  // Set by the caller when the run was requested with a key
+	WindowOverride    bool  // Set by the caller when the run was forced outside the scenario's allowed_windows
+	Iteration         int  // Set by the caller for repeated runs: this run's number, from 1
+	Iterations        int  // Number of runs in the repeated series
+	Baseline          *Baseline  // Set by the caller when the scenario has a baseline run in the history
+	Pauses            []Pause  // Times the operator held the drill between phases
+	Simulated         bool  // Commands were not executed; outcomes came from the scenario's simulate block
+	DegradedStartTime time.Time  // When probes were healthy but over the latency budget
+	DegradedEndTime   time.Time
+	DegradedDuration  time.Duration  // Time spent DEGRADED, reported separately from RTA
+	LatencyPercentile time.Duration  // Last evaluated latency percentile for the budget
+	Switchback        *CommandResult  // Failback to the original primary after recovery
+	SwitchbackStartTime time.Time
+	SwitchbackEndTime time.Time  // When the service was healthy again after switchback
+	SwitchbackTime    time.Duration
+	SwitchbackTarget  time.Duration  // Zero when the switchback time is only reported
+	SwitchbackPassed  bool
+	Incomplete        bool  // Run returned an error before completion; results are partial
+	Interrupted       bool  // The run was canceled before completion; results are partial
+	ResumedAt         time.Time  // When the run was resumed from a checkpoint
+	ResumedFrom       string  // Phase the run was resumed in
+	Artifacts         []Artifact  // Files matching the scenario's artifacts, set by the caller once copied into the output directory
+}
+
+// Artifact is a file collected into the output directory after the drill. A
+// pattern that matched no files is recorded with an empty Path.
+type Artifact struct {
+	Pattern      string
+	Source       string  // Path the file was copied from
+	Path         string  // Relative to the output directory
+	SHA256       string
+	SourceSHA256 string  // Hash of the source, when secrets were masked in the copy
+	Size         int64
+}
+
+// endRTA ends the outage at end. RTA comes from the monotonic clock readings
+// that time.Now() carries, so an NTP step or VM clock jump mid-drill cannot
+// corrupt it; the wall-clock difference is kept alongside for the record.
+func (d *DrillResult) endRTA(end time.Time) {
+	d.RTOEndTime = end
+	d.RTA = end.Sub(d.RTOStartTime)
+	d.WallClockRTA = end.Round(0).Sub(d.RTOStartTime.Round(0))
+}
+
+// ClockSkew returns how far the wall clock drifted from the monotonic clock
+// while RTA was measured
+func (d *DrillResult) ClockSkew() time.Duration {
+	return d.WallClockRTA - d.RTA
+}
+
+// CountedRTA returns the RTA compared against the RTO target, which excludes
+// the expected downtime grace and time the drill was paused during the outage
+func (d *DrillResult) CountedRTA() time.Duration {
+	excluded := d.DowntimeGrace + d.PausedDowntime()
+	if d.RTA <= excluded {
+		return 0
+	}
+	return d.RTA - excluded
+}
+
+// recovered reports whether the outage met the success criterion: the failed
+// probe budget when set, otherwise the RTO target
+func (d *DrillResult) recovered() bool {
+	if d.MaxFailedProbes != nil {
+		return d.FailedProbes <= *d.MaxFailedProbes
+	}
+	return d.CountedRTA() <= d.RTOTarget
+}
+
+// LabeledCommands returns every command result in the drill in execution
+// order. Each host of a group execution follows its command, labeled
+// <label>.<host>.
+func (d *DrillResult) LabeledCommands() []LabeledCommand {
+	var cmds []LabeledCommand
+	add := func(label string, result *CommandResult) {
+		if result == nil {
+			return
+		}
+		cmds = append(cmds, LabeledCommand{Label: label, Result: result})
+		for i := range result.Members {
+			cmds = append(cmds, LabeledCommand{Label: memberLabel(label, result.Members[i].Host), Result: &result.Members[i]})
+		}
+	}
+
+	add("backup-freshness", d.BackupFreshness)
+	if d.RestoreCheck != nil {
+		add("restore", d.RestoreCheck.Restore)
+		for i := range d.RestoreCheck.IntegrityChecks {
+			add(fmt.Sprintf("integrity-check-%d", i+1), d.RestoreCheck.IntegrityChecks[i].Result)
+		}
+		add("restore-cleanup", d.RestoreCheck.Cleanup)
+	}
+	add("pre-snapshot", d.PreSnapshot)
+	for i := range d.Guards {
+		add(fmt.Sprintf("guard-%d", i+1), d.Guards[i].Result)
+	}
+	add("disrupt", d.Disrupt)
+	add("recover", d.Recover)
+	add("abort", d.Abort)
+	for i := range d.HealthCheckAttempts {
+		add(fmt.Sprintf("health-check-%d", i+1), &d.HealthCheckAttempts[i])
+	}
+	add("seed", d.Seed)
+	add("post-snapshot", d.PostSnapshot)
+	add("rpo-verify", d.RPOVerify)
+	add("marker-read", d.MarkerRead)
+	for i := range d.ConsistencyChecks {
+		add(fmt.Sprintf("consistency-%d-baseline", i+1), d.ConsistencyChecks[i].Baseline)
+		add(fmt.Sprintf("consistency-%d", i+1), d.ConsistencyChecks[i].Result)
+		add(fmt.Sprintf("consistency-%d-compare", i+1), d.ConsistencyChecks[i].Compare)
+	}
+	for i := range d.RPOChecks {
+		add(fmt.Sprintf("rpo-check-%d-pre-snapshot", i+1), d.RPOChecks[i].PreSnapshot)
+		add(fmt.Sprintf("rpo-check-%d-post-snapshot", i+1), d.RPOChecks[i].PostSnapshot)
+		add(fmt.Sprintf("rpo-check-%d-verify", i+1), d.RPOChecks[i].Verify)
+	}
+	for i := range d.Metrics {
+		if d.Metrics[i].Before != nil {
+			add(fmt.Sprintf("metric-%d-before", i+1), d.Metrics[i].Before.Result)
+		}
+		if d.Metrics[i].After != nil {
+			add(fmt.Sprintf("metric-%d-after", i+1), d.Metrics[i].After.Result)
+		}
+	}
+	add("switchback", d.Switchback)
+	for i := range d.FactorLogs {
+		add(fmt.Sprintf("factor-log-%d", i+1), &d.FactorLogs[i])
+	}
+
+	return cmds
 }
 
 // Runner executes drill scenarios
 type Runner struct {
 	healthCheckInterval time.Duration
 	healthCheckTimeout  time.Duration
+	probeInterval       time.Duration  // Health check interval for the current run
+	probeConcurrency    int  // Maximum health checks in flight for the current run
+	hooks               Hooks
+	execution           *config.Execution  // Scenario-level execution for the current run
+	shell               *config.Shell  // Scenario-level shell for the current run
+	hosts               *config.HostInventory  // Host groups for the current run
+	env                 []string  // Cloud account and cluster selection added to every local command's environment
+	tracer              *Tracer  // Set by EnableTracing for --debug runs
+	phase               string  // Phase the current run is in
+	current             *DrillResult  // Result of the current run, for checkpoints
+	lastCheckpoint      time.Time
+	resume              *Checkpoint  // Set by Resume for the current run
+	simulating          bool  // Set by EnableSimulation
+	sim                 *simulator  // Answers commands for the current run when simulating
+	heartbeatInterval   time.Duration  // Set by SetHeartbeat
+	events              eventStream  // Serializes Hooks.OnEvent calls for the current run
+	redactor            *redactor  // Removes secrets from command output in the current run; nil when there are none
 }
 
 // NewRunner creates a new runner with default settings
@@ -62,129 +281,677 @@ func NewRunner() *Runner {
 	}
 }
 
+// commandWaitDelay is how long a command's output is still read after the
+// command exits or is killed
+const commandWaitDelay = 10 * time.Second
+
 // Run executes a complete drill scenario
-func (r *Runner) Run(ctx context.Context, scenario *config.Scenario) (*DrillResult, error) {
-	result := &DrillResult{
+func (r *Runner) Run(ctx context.Context, scenario *config.Scenario) (result *DrillResult, err error) {
+	result = &DrillResult{
 		Scenario: scenario,
 		StartTime: time.Now(),
 		Errors:    []string{},
 	}
+	if r.resume != nil {
+		// Continue the checkpointed result; the time the controller was
+		// stopped counts as downtime, since nobody was watching
+		result = r.resume.Result
+		result.Scenario = scenario
+		result.ResumedAt = time.Now()
+		result.ResumedFrom = r.resume.Phase
+		result.Incomplete = false
+		result.Interrupted = false
+		result.EndTime = time.Time{}
+		fmt.Printf("Resuming drill from %s (checkpointed at %s)\n", r.resume.Phase, r.resume.Time.Format(time.RFC3339))
+	}
+	r.sim = nil
+	if r.simulating {
+		r.sim = newSimulator(scenario.Simulate)
+		result.Simulated = true
+	}
+	r.current = result
+	r.lastCheckpoint = time.Time{}
+	r.emitRunStart(result)
+	defer func() {
+		r.endPhase(result.EndTime)
+		r.current = nil
+		r.emitRunEnd(result)
+	}()
+	// Whatever was measured before an error is kept, marked incomplete, so
+	// the evidence of the attempted drill isn't lost
+	defer func() {
+		if err == nil {
+			return
+		}
+		result.Incomplete = true
+		if result.EndTime.IsZero() {
+			result.EndTime = time.Now()
+		}
+		if !result.Interrupted {
+			result.Errors = append(result.Errors, fmt.Sprintf("drill did not complete: %v", err))
+		}
+	}()
+
+	if r.redactor, err = newRedactor(scenario); err != nil {
+		return result, err
+	}
+
+	r.env = scenario.CloudEnv()
+	kubeconfig, cleanupKubeconfig, err := kubeEnv(scenario)
+	if err != nil {
+		return result, err
+	}
+	defer cleanupKubeconfig()
+	r.env = append(r.env, kubeconfig...)
+
+	// Centrally governed targets replace the scenario's before anything uses them
+	if err := r.resolveTargets(ctx, scenario); err != nil {
+		return result, err
+	}
 
 	// Parse durations
 	rtoTarget, err := scenario.GetRTOTargetDuration()
 	if err != nil {
-		return nil, fmt.Errorf("invalid RTO target: %w", err)
+		return result, fmt.Errorf("invalid RTO target: %w", err)
 	}
 	result.RTOTarget = rtoTarget
 
+	grace, err := scenario.GetExpectedDowntimeGrace()
+	if err != nil {
+		return result, fmt.Errorf("invalid expected_downtime_grace: %w", err)
+	}
+	result.DowntimeGrace = grace
+
+	abortAfter, err := scenario.GetAbortAfter(rtoTarget)
+	if err != nil {
+		return result, fmt.Errorf("invalid abort_after: %w", err)
+	}
+	if abortAfter > 0 && abortAfter <= rtoTarget+grace {
+		return result, fmt.Errorf("abort_after (%s) must be longer than the RTO target plus grace (%s)", abortAfter, rtoTarget+grace)
+	}
+	result.AbortAfter = abortAfter
+	result.MaxFailedProbes = scenario.MaxFailedProbes
+	result.HealthyAfter = scenario.HealthyAfter
+	if result.HealthyAfter < 1 {
+		result.HealthyAfter = 1
+	}
+
 	rpoTarget, err := scenario.GetRPOTargetDuration()
 	if err != nil {
-		return nil, fmt.Errorf("invalid RPO target: %w", err)
+		return result, fmt.Errorf("invalid RPO target: %w", err)
 	}
 	result.RPOTarget = rpoTarget
 
 	postDisruptDelay, err := scenario.GetPostDisruptDelay()
 	if err != nil {
-		return nil, fmt.Errorf("invalid post_disrupt_delay: %w", err)
+		return result, fmt.Errorf("invalid post_disrupt_delay: %w", err)
 	}
 	result.PostDisruptDelay = postDisruptDelay
 
+	probeInterval, err := scenario.GetProbeInterval()
+	if err != nil {
+		return result, fmt.Errorf("invalid probe_interval: %w", err)
+	}
+	r.probeInterval = r.healthCheckInterval
+	if probeInterval > 0 {
+		r.probeInterval = probeInterval
+	}
+	r.probeConcurrency = scenario.ProbeConcurrency
+
+	r.execution = scenario.Execution
+	r.shell = scenario.Shell
+	r.hosts = scenario.Hosts
+	if r.tracer != nil {
+		r.tracer.secrets = scenario.SecretValues()
+		r.trace("run_start", scenario.Name, map[string]interface{}{
+			"rto_target":            scenario.RTOTarget,
+			"rpo_target":            scenario.RPOTarget,
+			"health_check_interval": r.probeInterval.String(),
+			"probe_concurrency":     r.probeConcurrency,
+			"health_check_timeout":  r.healthCheckTimeout.String(),
+		})
+	}
+
+	probe, err := r.newProbe(scenario)
+	if err != nil {
+		return result, err
+	}
+	// Command probes are redacted as they run
+	if r.redactor != nil && scenario.HealthCheck != nil {
+		probe = &redactedProbe{Probe: probe, redactor: r.redactor}
+	}
+	if scenario.Degraded != nil {
+		if probe, err = newDegradedProbe(probe, scenario.Degraded); err != nil {
+			return result, err
+		}
+	}
+	if closer, ok := probe.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	// An interrupt must not abandon a disrupted environment or lose the
+	// measurements taken so far
+	r.phase = ""
+	completed := false
+	defer func() {
+		if ctx.Err() != nil {
+			r.interrupted(ctx, scenario, result)
+			completed = true
+		}
+		if completed {
+			r.complete(result)
+		}
+	}()
+
+	// System metrics are sampled for the whole drill, and recorded before the
+	// result is completed
+	if scenario.Factors != nil && scenario.Factors.SystemMetrics != nil {
+		sampler := r.startSystemMetrics(ctx, scenario.Factors.SystemMetrics)
+		defer sampler.finish(result)
+	}
+
+	// Step 0: Backup freshness - fail fast if the latest backup already violates the RPO target
+	if scenario.RPOCheck != nil && scenario.RPOCheck.BackupFreshness != nil && r.resume == nil {
+		r.phaseStart(ctx, PhaseBackupFreshness)
+		if !r.checkBackupFreshness(ctx, scenario.RPOCheck.BackupFreshness, rpoTarget, result) {
+			fmt.Println("Skipping disruption: backups are already out of RPO compliance")
+			result.EndTime = time.Now()
+			completed = true
+			return result, nil
+		}
+	}
+
+	// Restore check: restore a backup into a scratch target before disrupting
+	if scenario.RestoreCheck != nil && r.resume == nil {
+		r.phaseStart(ctx, PhaseRestore)
+		r.checkRestore(ctx, scenario.RestoreCheck, result)
+	}
+
+	// Seed test data continuously up to the moment of the disruption
+	var seeding *seeder
+	if scenario.SeedCommand.IsSet() && r.resume == nil {
+		r.phaseStart(ctx, PhaseSeed)
+		seeding = r.startSeeding(ctx, scenario)
+		defer seeding.stop()
+	}
+
 	// Step 1: Pre-snapshot (if present)
-	if scenario.RPOCheck != nil && scenario.RPOCheck.PreSnapshot != "" {
-		result.PreSnapshot = r.executeCommand(ctx, scenario.RPOCheck.PreSnapshot)
-		if result.PreSnapshot.ExitCode != 0 {
+	if scenario.RPOCheck != nil && scenario.RPOCheck.PreSnapshot.IsSet() && r.resume == nil {
+		r.phaseStart(ctx, PhasePreSnapshot)
+		result.PreSnapshot = r.runStep(ctx, scenario.RPOCheck.PreSnapshot)
+		if !result.PreSnapshot.Succeeded() {
 			result.Errors = append(result.Errors, fmt.Sprintf("pre_snapshot command failed with exit code %d", result.PreSnapshot.ExitCode))
 		}
 	}
 
+	// Per-data-store RPO checks snapshot alongside rpo_check
+	if len(scenario.RPOChecks) > 0 && r.resume == nil {
+		if result.PreSnapshot == nil {
+			r.phaseStart(ctx, PhasePreSnapshot)
+		}
+		r.preSnapshotRPOChecks(ctx, scenario.RPOChecks, rpoTarget, result)
+	}
+
+	// Consistency checks that match a baseline take it with the pre-snapshot
+	if hasBaselines(scenario.ConsistencyChecks) && r.resume == nil {
+		if result.PreSnapshot == nil && result.RPOChecks == nil {
+			r.phaseStart(ctx, PhasePreSnapshot)
+		}
+		r.consistencyBaselines(ctx, scenario.ConsistencyChecks, result)
+	}
+
+	// Custom metrics are read with the pre-snapshot and again after recovery
+	if len(scenario.Metrics) > 0 && r.resume == nil {
+		if r.phase != PhasePreSnapshot {
+			r.phaseStart(ctx, PhasePreSnapshot)
+		}
+		r.captureMetrics(ctx, scenario.Metrics, true, result)
+	}
+
+	// Guards: abort safely if any precondition for disrupting is not met
+	if len(scenario.Guards) > 0 && r.resume == nil {
+		r.phaseStart(ctx, PhaseGuards)
+		if !r.checkGuards(ctx, scenario.Guards, result) {
+			fmt.Println("Skipping disruption: a guard failed")
+			result.EndTime = time.Now()
+			completed = true
+			return result, nil
+		}
+	}
+
+	// Marker records are written up to the moment of the disruption
+	var markers *markerWriter
+	if scenario.RPOCheck != nil && scenario.RPOCheck.Markers != nil && r.resume == nil {
+		r.phaseStart(ctx, PhaseMarkers)
+		markers = r.startMarkers(ctx, scenario.RPOCheck.Markers)
+	}
+
 	// Step 2: Disrupt
-	result.Disrupt = r.executeCommand(ctx, scenario.DisruptCommand)
-	if result.Disrupt.ExitCode != 0 {
-		result.Errors = append(result.Errors, fmt.Sprintf("disrupt_command failed with exit code %d", result.Disrupt.ExitCode))
+	// Health probing starts as the disruption is issued and continues through
+	// the post-disrupt delay, so RTA starts when the service actually went down
+	r.phaseStart(ctx, PhaseDisrupt)
+	if seeding != nil {
+		seeding.finish(result)
+	}
+	if markers != nil {
+		markers.finish(result)
+	}
+	probes := r.startProbing(ctx, probe)
+	defer probes.stop()
+
+	// Continuous snapshots run from the disruption until the final post-snapshot
+	var snapshots *snapshotter
+	if scenario.RPOCheck != nil && scenario.RPOCheck.SnapshotInterval != "" && !r.resumedPast(PhasePostSnapshot) {
+		snapshots = r.startSnapshots(ctx, scenario.RPOCheck)
+		defer snapshots.stop()
+	}
+
+	if r.resume == nil {
+		var disruptResult *CommandResult
+		disrupted := make(chan struct{})
+		go func() {
+			defer close(disrupted)
+			disruptResult = r.runStep(ctx, scenario.DisruptCommand)
+		}()
+		err := r.watchForDowntime(ctx, probes, disrupted, result)
+		<-disrupted
+		result.Disrupt = disruptResult
+		if err != nil {
+			return result, err
+		}
+		if !result.Disrupt.Succeeded() {
+			result.Errors = append(result.Errors, fmt.Sprintf("disrupt_command failed with exit code %d", result.Disrupt.ExitCode))
+		}
+	} else if result.Disrupt == nil {
+		// The disruption is never issued again on resume
+		result.Errors = append(result.Errors, "disrupt_command was still running when the drill was checkpointed; its result was lost")
 	}
 
 	// Step 3: Post-disrupt delay
-	if postDisruptDelay > 0 {
-		select {
-		case <-ctx.Done():
-			return result, ctx.Err()
-		case <-time.After(postDisruptDelay):
+	if postDisruptDelay > 0 && r.resume == nil {
+		r.phaseStart(ctx, PhasePostDisruptDelay)
+		delayed := make(chan struct{})
+		timer := time.AfterFunc(postDisruptDelay, func() { close(delayed) })
+		if err := r.watchForDowntime(ctx, probes, delayed, result); err != nil {
+			timer.Stop()
+			return result, err
 		}
 	}
 
-	// Step 4: Check health immediately after disruption to detect if service went down
+	// Step 4: Unless a check during the disruption already failed, take the
+	// next health check to detect whether the service went down
 	// This establishes when RTA starts (when service actually goes down)
-	fmt.Println("Checking if disruption caused service downtime...")
-	postDisruptCheck := r.executeCommand(ctx, scenario.HealthCheckCommand)
-	result.HealthCheckAttempts = append(result.HealthCheckAttempts, *postDisruptCheck)
-	
-	if postDisruptCheck.ExitCode != 0 {
-		// Service is down - RTA starts now
-		result.RTOStartTime = postDisruptCheck.Timestamp
-		fmt.Printf("Service is down - RTA measurement started at %s\n", result.RTOStartTime.Format(time.RFC3339))
+	r.phaseStart(ctx, PhaseDetectDowntime)
+	if result.RTOStartTime.IsZero() && !r.resumedPast(PhaseDetectDowntime) {
+		fmt.Println("Checking if disruption caused service downtime...")
+		var postDisruptCheck *CommandResult
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case postDisruptCheck = <-probes.results:
+		}
+		r.recordDowntimeCheck(postDisruptCheck, result)
 	}
 
 	// Step 5: Recover (if recover_command is present)
-	if scenario.RecoverCommand != "" {
-		fmt.Println("Executing recovery command...")
-		result.Recover = r.executeCommand(ctx, scenario.RecoverCommand)
-		if result.Recover.ExitCode != 0 {
+	// While the service is down, recovery runs concurrently with the health checks
+	// below so RTA ends at the first healthy check, even mid-recovery
+	finishRecover := func(recoverResult *CommandResult) {
+		result.Recover = recoverResult
+		// Recovery runs alongside RTA measurement; its phase ends with the command
+		result.setPhaseEnd(PhaseRecover, recoverResult.Timestamp.Add(recoverResult.Duration))
+		if !result.Recover.Succeeded() {
 			result.Errors = append(result.Errors, fmt.Sprintf("recover_command failed with exit code %d", result.Recover.ExitCode))
 		} else {
 			fmt.Println("Recovery command completed successfully")
 		}
 	}
+	var recoverDone chan *CommandResult
+	recoverCtx, cancelRecover := context.WithCancel(ctx)
+	defer cancelRecover()
+	// A resumed drill runs recover_command again unless it had completed
+	if scenario.RecoverCommand.IsSet() && result.Recover == nil {
+		r.phaseStart(ctx, PhaseRecover)
+		fmt.Println("Executing recovery command...")
+		if result.RTOStartTime.IsZero() {
+			finishRecover(r.runStep(ctx, scenario.RecoverCommand))
+		} else {
+			recoverDone = make(chan *CommandResult, 1)
+			go func() {
+				recoverDone <- r.runStep(recoverCtx, scenario.RecoverCommand)
+			}()
+		}
+	}
 
 	// Step 6: RTA measurement - continue checking health until service recovers
 	// If RTA already started (service was down), continue until it's healthy
 	// If RTA hasn't started (service still healthy), wait for it to go down or stay healthy
-	r.waitForHealthCheck(ctx, scenario.HealthCheckCommand, rtoTarget, result)
+	r.phaseStart(ctx, PhaseRTAMeasurement)
+	var healthy bool
+	if result.RTOEndTime.IsZero() && !r.resumedPast(PhaseRTAMeasurement) {
+		healthy = r.waitForHealthCheck(ctx, probes, rtoTarget, result)
+		if healthy && scenario.Degraded != nil {
+			maxWait, err := scenario.Degraded.GetMaxWait(rtoTarget)
+			if err != nil {
+				return result, fmt.Errorf("invalid degraded max_wait: %w", err)
+			}
+			r.waitWhileDegraded(ctx, probes, maxWait, result)
+		}
+	} else {
+		healthy = result.RTOStartTime.IsZero() || result.RTOPassed
+	}
+	probes.stop()
+	if recoverDone != nil {
+		if result.Aborted {
+			// A recovery still running at abort_after is stuck; the abort command replaces it
+			cancelRecover()
+		}
+		finishRecover(<-recoverDone)
+	}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	// Step 6a: Abort - force recovery when the outage outlasted abort_after
+	if result.Aborted && result.Abort == nil {
+		r.phaseStart(ctx, PhaseAbort)
+		r.abort(ctx, scenario, result)
+	}
+
+	// Step 6b: Latency budget - a healthy but slow service is DEGRADED, not recovered
+	if scenario.LatencyBudget != nil && healthy && !r.resumedPast(PhaseRTAMeasurement) {
+		budget, err := newLatencyBudget(scenario.LatencyBudget, rtoTarget)
+		if err != nil {
+			return result, err
+		}
+		r.measureDegradation(ctx, probe, budget, result)
+	}
+
+	if snapshots != nil {
+		snapshots.finish(result)
+	}
 
 	// Step 6: Post-snapshot (if present)
-	if scenario.RPOCheck != nil && scenario.RPOCheck.PostSnapshot != "" {
-		result.PostSnapshot = r.executeCommand(ctx, scenario.RPOCheck.PostSnapshot)
-		if result.PostSnapshot.ExitCode != 0 {
+	if scenario.RPOCheck != nil && scenario.RPOCheck.PostSnapshot.IsSet() && !result.Aborted && !r.resumedPast(PhasePostSnapshot) {
+		r.phaseStart(ctx, PhasePostSnapshot)
+		result.PostSnapshot = r.runStep(ctx, scenario.RPOCheck.PostSnapshot)
+		if !result.PostSnapshot.Succeeded() {
 			result.Errors = append(result.Errors, fmt.Sprintf("post_snapshot command failed with exit code %d", result.PostSnapshot.ExitCode))
 		}
+		if result.PreSnapshot != nil && result.PreSnapshot.Stdout != "" && result.PostSnapshot.Stdout != "" {
+			result.SnapshotDiff = diffSnapshots(result.PreSnapshot.Stdout, result.PostSnapshot.Stdout)
+		}
+		if scenario.RPOCheck.Timestamp != nil {
+			measureDataLoss(scenario.RPOCheck.Timestamp, result)
+		}
+	}
+
+	if len(result.RPOChecks) > 0 && !result.Aborted && !r.resumedPast(PhasePostSnapshot) {
+		if result.PostSnapshot == nil {
+			r.phaseStart(ctx, PhasePostSnapshot)
+		}
+		r.postSnapshotRPOChecks(ctx, scenario.RPOChecks, result)
+	}
+
+	if len(scenario.Metrics) > 0 && !r.resumedPast(PhasePostSnapshot) {
+		if r.phase != PhasePostSnapshot {
+			r.phaseStart(ctx, PhasePostSnapshot)
+		}
+		r.captureMetrics(ctx, scenario.Metrics, false, result)
+	}
+
+	// Step 6c: Find the newest marker record that survived
+	if scenario.RPOCheck != nil && scenario.RPOCheck.Markers != nil && !result.Aborted && !r.resumedPast(PhaseRPOVerify) {
+		r.phaseStart(ctx, PhaseRPOVerify)
+		r.readMarkers(ctx, scenario.RPOCheck.Markers, result)
 	}
 
 	// Step 7: RPO verification (if present)
-	if scenario.RPOCheck != nil && scenario.RPOCheck.VerifyCommand != "" {
-		result.RPOVerify = r.executeCommand(ctx, scenario.RPOCheck.VerifyCommand)
-		if result.RPOVerify.ExitCode == 0 {
+	if scenario.RPOCheck != nil && scenario.RPOCheck.VerifyCommand.IsSet() && !result.Aborted && !r.resumedPast(PhaseRPOVerify) {
+		if scenario.RPOCheck.Markers == nil {
+			r.phaseStart(ctx, PhaseRPOVerify)
+		}
+		result.RPOVerify = r.runStep(ctx, scenario.RPOCheck.VerifyCommand)
+		if result.RPOVerify.Succeeded() {
 			result.RPOPassed = true
 		} else {
 			result.RPOPassed = false
 			result.Errors = append(result.Errors, fmt.Sprintf("rpo verify_command failed with exit code %d", result.RPOVerify.ExitCode))
 		}
-	} else if rpoTarget > 0 {
+		if scenario.RPOCheck.VerifyOutput != nil {
+			readVerifyOutput(scenario.RPOCheck.VerifyOutput, result)
+		}
+	} else if rpoTarget > 0 && !result.Aborted && !r.resumedPast(PhaseRPOVerify) && len(scenario.RPOChecks) == 0 && (scenario.RPOCheck == nil || (scenario.RPOCheck.Timestamp == nil && scenario.RPOCheck.Markers == nil)) {
 		// If RPO target is set but no verify command, we can't measure it
 		result.Errors = append(result.Errors, "RPO target specified but no verify_command provided")
 	}
 
+	// Step 7a: Measured data loss - compared against the RPO target along with verify_command
+	if scenario.RPOCheck != nil && (scenario.RPOCheck.Timestamp != nil || scenario.RPOCheck.Markers != nil || result.RPOFromOutput) && rpoTarget > 0 && !result.Aborted && !r.resumedPast(PhaseRPOVerify) {
+		measured := result.RPOMeasured()
+		within := measured && result.RPOActual <= rpoTarget
+		if scenario.RPOCheck.VerifyCommand.IsSet() {
+			result.RPOPassed = result.RPOPassed && within
+		} else {
+			result.RPOPassed = within
+		}
+		if measured && !within {
+			result.Errors = append(result.Errors, fmt.Sprintf("data loss of %s exceeds the RPO target of %s",
+				formatDuration(result.RPOActual), formatDuration(rpoTarget)))
+		}
+	}
+
+	// Step 7b: Per-data-store RPO checks; RPOPassed requires every one to pass
+	if len(result.RPOChecks) > 0 && !result.Aborted && !r.resumedPast(PhaseRPOVerify) {
+		if result.RPOVerify == nil && result.MarkerRead == nil {
+			r.phaseStart(ctx, PhaseRPOVerify)
+		}
+		r.verifyRPOChecks(ctx, scenario.RPOChecks, result)
+		if scenario.RPOCheck != nil && (scenario.RPOCheck.VerifyCommand.IsSet() || scenario.RPOCheck.Timestamp != nil || scenario.RPOCheck.Markers != nil) {
+			result.RPOPassed = result.RPOPassed && result.RPOChecksPassed()
+		} else {
+			result.RPOPassed = result.RPOChecksPassed()
+		}
+	}
+
+	// Step 7c: Consistency checks against the recovered data
+	if len(scenario.ConsistencyChecks) > 0 && !result.Aborted && !r.resumedPast(PhaseConsistency) {
+		r.phaseStart(ctx, PhaseConsistency)
+		r.checkConsistency(ctx, scenario.ConsistencyChecks, result)
+	}
+
+	// Step 7d: Switchback to the original primary (if configured and recovered)
+	if scenario.Switchback != nil && !r.resumedPast(PhaseSwitchback) {
+		if !result.SwitchbackStartTime.IsZero() {
+			// Failing back twice could disrupt production again
+			result.SwitchbackPassed = false
+			result.Errors = append(result.Errors, "switchback was in progress when the drill was checkpointed and was not issued again")
+		} else if healthy {
+			target, err := scenario.GetSwitchbackTarget()
+			if err != nil {
+				return result, fmt.Errorf("invalid switchback target: %w", err)
+			}
+			maxWait := target
+			if maxWait == 0 {
+				maxWait = rtoTarget
+			}
+			r.phaseStart(ctx, PhaseSwitchback)
+			r.measureSwitchback(ctx, probe, scenario.Switchback, target, maxWait, result)
+		} else {
+			result.Errors = append(result.Errors, "switchback skipped because the service did not recover")
+		}
+	}
+
 	// Step 8: Collect factor logs
-	if scenario.Factors != nil && len(scenario.Factors.LogCommands) > 0 {
+	if scenario.Factors != nil && len(scenario.Factors.LogCommands) > 0 && !r.resumedPast(PhaseFactors) {
+		r.phaseStart(ctx, PhaseFactors)
+		result.FactorLogs = nil
 		for _, logCmd := range scenario.Factors.LogCommands {
-			logResult := r.executeCommand(ctx, logCmd)
+			logResult := r.runStep(ctx, logCmd)
 			result.FactorLogs = append(result.FactorLogs, *logResult)
 		}
 	}
 
+	// Output assertions are reported apart from exit code failures, since a
+	// snapshot can exit 0 while capturing nothing useful
+	for _, cmd := range result.LabeledCommands() {
+		for _, pattern := range cmd.Result.StdoutMismatches {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s output assertion failed: stdout does not match %q", cmd.Label, pattern))
+		}
+	}
+
 	result.EndTime = time.Now()
 	// RTOEndTime is already set in waitForHealthCheck, but ensure it's set if we didn't run health checks
 	if result.RTOEndTime.IsZero() {
 		result.RTOEndTime = result.EndTime
 		if !result.RTOStartTime.IsZero() {
-			result.RTA = result.RTOEndTime.Sub(result.RTOStartTime)
-			result.RTOPassed = result.RTA <= result.RTOTarget
+			result.endRTA(result.EndTime)
+			result.RTOPassed = result.recovered()
 		}
 	}
 
+	// Step 9: Assertions over the finished result
+	if len(scenario.Assertions) > 0 {
+		checkAssertions(scenario.Assertions, result)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	r.trace("run_end", scenario.Name, map[string]interface{}{"errors": len(result.Errors)})
+	completed = true
 	return result, nil
 }
 
-// executeCommand runs a shell command and returns the result
+// interrupted finalizes a canceled run and, if the disruption was issued but
+// recovery never completed, offers to run recover_command
+func (r *Runner) interrupted(ctx context.Context, scenario *config.Scenario, result *DrillResult) {
+	phase := r.phase
+	if phase == "" {
+		phase = "setup"
+	}
+	fmt.Printf("\n⚠️  Drill interrupted during %s\n", phase)
+	result.Interrupted = true
+	result.Incomplete = true
+	result.Errors = append(result.Errors, fmt.Sprintf("drill interrupted during %s", phase))
+	now := time.Now()
+	if !result.RTOStartTime.IsZero() && result.RTOEndTime.IsZero() {
+		result.endRTA(now)
+	}
+	// A drill that was stopped part-way cannot demonstrate recovery
+	result.RTOPassed = false
+	defer func() { result.EndTime = time.Now() }()
+
+	disrupted := result.Disrupt != nil || r.resume != nil
+	if !disrupted || !scenario.RecoverCommand.IsSet() || (result.Recover != nil && result.Recover.Succeeded()) {
+		return
+	}
+	if r.hooks.OnInterrupt == nil || !r.hooks.OnInterrupt(phase) {
+		result.Errors = append(result.Errors, "environment left disrupted: recover_command was not run after the interrupt")
+		return
+	}
+
+	// The run's context is canceled, so recovery runs without it
+	fmt.Println("Executing recovery command after interrupt...")
+	result.Recover = r.runStep(context.WithoutCancel(ctx), scenario.RecoverCommand)
+	if !result.Recover.Succeeded() {
+		result.Errors = append(result.Errors, fmt.Sprintf("recover_command after interrupt failed with exit code %d", result.Recover.ExitCode))
+	} else {
+		fmt.Println("Recovery command completed successfully")
+	}
+}
+
+// StepCommand returns the command line a scenario step runs
+func StepCommand(step config.Command) string {
+	switch {
+	case len(step.Argv) > 0:
+		return quoteArgs(step.Argv)
+	case step.GCP != nil:
+		return gcpCommand(step.GCP, time.Now())
+	case step.Azure != nil:
+		return azureCommand(step.Azure)
+	case step.VSphere != nil:
+		return vsphereCommand(step.VSphere)
+	}
+	return step.Run
+}
+
+// abort runs abort_command, or recover_command without one, after the
+// service stayed down past abort_after
+func (r *Runner) abort(ctx context.Context, scenario *config.Scenario, result *DrillResult) {
+	result.Errors = append(result.Errors, fmt.Sprintf("drill aborted: service still down %s after it went down (abort_after)", formatDuration(result.AbortAfter)))
+
+	step := scenario.AbortCommand
+	if !step.IsSet() {
+		step = scenario.RecoverCommand
+	}
+	if !step.IsSet() {
+		fmt.Println("⚠️  No abort_command or recover_command to run; the environment may still be disrupted")
+		return
+	}
+
+	fmt.Println("Executing abort command...")
+	result.Abort = r.runStep(ctx, step)
+	if !result.Abort.Succeeded() {
+		result.Errors = append(result.Errors, fmt.Sprintf("abort command failed with exit code %d", result.Abort.ExitCode))
+	} else {
+		fmt.Println("Abort command completed successfully")
+	}
+}
+
+// runStep executes a scenario step and records which exit codes it accepts
+func (r *Runner) runStep(ctx context.Context, step config.Command) *CommandResult {
+	command := StepCommand(step)
+	execution, argv := stepArgs(r.execution, r.shell, step, command)
+
+	r.emit(EventCommandStart, map[string]interface{}{"command": command})
+	var result *CommandResult
+	if step.RunAs != "" && !execution.IsRemote() && runtime.GOOS == "windows" {
+		result = &CommandResult{
+			Command:   command,
+			Timestamp: time.Now(),
+			ExitCode:  -1,
+			Stderr:    "run_as is not supported on Windows",
+		}
+		result.StdoutHash = hashString(result.Stdout)
+		result.StderrHash = hashString(result.Stderr)
+	} else {
+		result = r.executeStepArgs(ctx, execution, command, argv)
+	}
+	result.RunAs = step.RunAs
+	result.ExpectedExitCodes = step.ExpectExitCodes
+	result.ExpectedStdout = step.ExpectStdout
+	result.MaxOutput = step.MaxOutput
+	for i := range result.Members {
+		result.Members[i].MaxOutput = step.MaxOutput
+	}
+	for _, pattern := range step.ExpectStdout {
+		if re, err := regexp.Compile(pattern); err != nil || !re.MatchString(result.Stdout) {
+			result.StdoutMismatches = append(result.StdoutMismatches, pattern)
+		}
+	}
+	r.emit(EventCommandEnd, map[string]interface{}{
+		"command":     command,
+		"exit_code":   result.ExitCode,
+		"succeeded":   result.Succeeded(),
+		"duration_ms": durationMillis(result.Duration),
+	})
+	return result
+}
+
+// executeCommand runs a command string through the local default shell
 func (r *Runner) executeCommand(ctx context.Context, command string) *CommandResult {
+	return r.executeArgs(ctx, command, append(defaultShellArgs(), command))
+}
+
+// executeArgs runs argv without a shell, recording command as what was run
+func (r *Runner) executeArgs(ctx context.Context, command string, argv []string) *CommandResult {
+	return r.executeArgsEnv(ctx, command, argv, nil)
+}
+
+// executeArgsEnv is executeArgs with env added to the command's environment
+func (r *Runner) executeArgsEnv(ctx context.Context, command string, argv []string, env []string) *CommandResult {
 	result := &CommandResult{
 		Command:   command,
 		Timestamp: time.Now(),
@@ -192,8 +959,14 @@ func (r *Runner) executeCommand(ctx context.Context, command string) *CommandRes
 
 	start := time.Now()
 
-	// Execute command via bash
-	cmd := exec.CommandContext(ctx, "bash", "-c", command)
+	r.traceExec(command, argv)
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	if len(r.env) > 0 || len(env) > 0 {
+		cmd.Env = append(append(os.Environ(), r.env...), env...)
+	}
+	// Background processes that outlive the command still hold its output;
+	// stop waiting for them so a killed or finished command can't block the drill
+	cmd.WaitDelay = commandWaitDelay
 	
 	// Capture both stdout and stderr separately for better debugging
 	var stdout, stderr strings.Builder
@@ -201,6 +974,9 @@ func (r *Runner) executeCommand(ctx context.Context, command string) *CommandRes
 	cmd.Stderr = &stderr
 	
 	err := cmd.Run()
+	if errors.Is(err, exec.ErrWaitDelay) && cmd.ProcessState.Success() {
+		err = nil
+	}
 	result.Stdout = stdout.String()
 	result.Stderr = stderr.String()
 	result.StdoutHash = hashString(result.Stdout)
@@ -230,6 +1006,8 @@ func (r *Runner) executeCommand(ctx context.Context, command string) *CommandRes
 	}
 
 	result.Duration = time.Since(start)
+	r.redactor.apply(result)
+	r.traceExecEnd(result)
 
 	return result
 }
@@ -237,31 +1015,72 @@ func (r *Runner) executeCommand(ctx context.Context, command string) *CommandRes
 // waitForHealthCheck repeatedly checks health until it passes or RTO target is exceeded
 // If RTA already started (RTOStartTime is set), continue checking until service recovers
 // If RTA hasn't started, check if service goes down or stays healthy
-func (r *Runner) waitForHealthCheck(ctx context.Context, healthCheckCommand string, rtoTarget time.Duration, result *DrillResult) bool {
+func (r *Runner) waitForHealthCheck(ctx context.Context, probes *prober, rtoTarget time.Duration, result *DrillResult) bool {
 	// Check if RTA already started (service was detected as down after disruption)
 	rtaStarted := !result.RTOStartTime.IsZero()
 	attemptNum := len(result.HealthCheckAttempts)  // Continue from existing attempts
 
+	var lastReported time.Time
+	exceededReported := false
+	// Passing checks in a row, and when the first of them passed
+	streak := 0
+	var streakStart time.Time
+
+	var heartbeat <-chan time.Time
+	if r.heartbeatInterval > 0 {
+		ticker := time.NewTicker(r.heartbeatInterval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
 	for {
 		attemptNum++
-		
-		// Create timeout context for this health check
-		checkCtx, cancel := context.WithTimeout(ctx, r.healthCheckTimeout)
-		attempt := r.executeCommand(checkCtx, healthCheckCommand)
-		cancel()
+
+		var attempt *CommandResult
+		for attempt == nil {
+			select {
+			case <-ctx.Done():
+				if rtaStarted {
+					result.endRTA(time.Now())
+					result.RTOPassed = result.recovered()
+				}
+				return false
+			case <-heartbeat:
+				printHeartbeat(result, rtoTarget)
+			case attempt = <-probes.results:
+			}
+		}
 
 		result.HealthCheckAttempts = append(result.HealthCheckAttempts, *attempt)
+		r.healthChecked(attemptNum, attempt)
 
-		if attempt.ExitCode == 0 {
+		if attempt.Up() {
 			// Service is healthy
 			if rtaStarted {
-				// RTA ends when service becomes healthy again (first successful health check)
-				result.RTOEndTime = time.Now()
-				result.RTA = result.RTOEndTime.Sub(result.RTOStartTime)
+				// RTA ends when service becomes healthy again (first successful health check
+				// of the streak that confirms recovery), or when a push-based probe was told so
+				if streak == 0 {
+					streakStart = time.Now()
+					if attempt.Probe != nil && attempt.Probe.SignaledAt.After(result.RTOStartTime) {
+						streakStart = attempt.Probe.SignaledAt
+					}
+				}
+				streak++
+				if streak < result.HealthyAfter {
+					fmt.Printf("[Health Check #%d] ✅ Health check passed (%d/%d consecutive needed to confirm recovery)\n",
+						attemptNum, streak, result.HealthyAfter)
+					continue
+				}
+				result.endRTA(streakStart)
+				result.RecoveryConfirmedTime = time.Now()
 				// Compare RTA vs RTO target
-				result.RTOPassed = result.RTA <= rtoTarget
-				fmt.Printf("[Health Check #%d] ✅ Service is healthy! RTA: %s (target RTO: %s) - %s\n", 
-					attemptNum, formatDuration(result.RTA), formatDuration(rtoTarget), 
+				result.RTOPassed = result.recovered()
+				state := "healthy"
+				if attempt.Degraded {
+					state = "up but degraded"
+				}
+				fmt.Printf("[Health Check #%d] ✅ Service is %s! RTA: %s (target RTO: %s) - %s\n", 
+					attemptNum, state, formatDuration(result.RTA), formatDuration(rtoTarget), 
 					map[bool]string{true: "✅ PASS", false: "❌ FAIL"}[result.RTOPassed])
 				return result.RTOPassed
 			} else {
@@ -278,42 +1097,101 @@ func (r *Runner) waitForHealthCheck(ctx context.Context, healthCheckCommand stri
 				rtaStarted = true
 				fmt.Printf("[Health Check #%d] ❌ Service is down - RTA measurement started\n", attemptNum)
 			}
+			if streak > 0 {
+				fmt.Printf("[Health Check #%d] ❌ Service is down again after %d passing check(s) - recovery not confirmed\n", attemptNum, streak)
+				streak = 0
+			}
+			result.FailedProbes++
 
-			// Check if we've exceeded RTO target (from when service went down)
+			if result.MaxFailedProbes != nil && result.FailedProbes > *result.MaxFailedProbes {
+				result.endRTA(time.Now())
+				result.RTOPassed = false
+				fmt.Printf("[Health Check #%d] ❌ Failed probe budget exceeded! %d failed (max: %d) - ❌ FAIL\n",
+					attemptNum, result.FailedProbes, *result.MaxFailedProbes)
+				return false
+			}
+
+			// Check if we've exceeded RTO target (from when service went down).
+			// With abort_after, measurement continues past it until the abort deadline.
+			// Time paused during the outage counts against neither
 			now := time.Now()
-			deadline := result.RTOStartTime.Add(rtoTarget)
-			if now.After(deadline) {
-				result.RTA = now.Sub(result.RTOStartTime)
-				result.RTOEndTime = now
+			paused := result.PausedDowntime()
+			deadline := result.RTOStartTime.Add(rtoTarget + result.DowntimeGrace + paused)
+			if result.AbortAfter > 0 {
+				if now.After(result.RTOStartTime.Add(result.AbortAfter + paused)) {
+					result.endRTA(now)
+					result.RTOPassed = false
+					result.Aborted = true
+					fmt.Printf("[Health Check #%d] ❌ Service still down after %s (abort_after) - aborting the drill\n",
+						attemptNum, formatDuration(result.AbortAfter))
+					return false
+				}
+				if now.After(deadline) && !exceededReported {
+					exceededReported = true
+					fmt.Printf("[Health Check #%d] ❌ RTO target exceeded (target RTO: %s); measuring until abort_after (%s)\n",
+						attemptNum, formatDuration(rtoTarget), formatDuration(result.AbortAfter))
+				}
+				if exceededReported {
+					deadline = result.RTOStartTime.Add(result.AbortAfter + paused)
+				}
+			} else if now.After(deadline) {
+				result.endRTA(now)
 				result.RTOPassed = false  // RTA exceeded RTO target
 				fmt.Printf("[Health Check #%d] ❌ RTO target exceeded! RTA: %s (target RTO: %s) - ❌ FAIL\n", 
 					attemptNum, formatDuration(result.RTA), formatDuration(rtoTarget))
 				return false
 			}
 
-			elapsed := now.Sub(result.RTOStartTime)
-			remaining := deadline.Sub(now)
-			fmt.Printf("[Health Check #%d] ❌ Health check failed (exit code: %d). RTA elapsed: %s, RTO remaining: %s. Retrying in %s...\n", 
-				attemptNum, attempt.ExitCode, formatDuration(elapsed), formatDuration(remaining), r.healthCheckInterval)
-			if attempt.Stderr != "" {
-				fmt.Printf("  Error: %s\n", strings.TrimSpace(attempt.Stderr))
-			}
-
-			// Wait before next attempt
-			select {
-			case <-ctx.Done():
-				if rtaStarted {
-					result.RTA = time.Since(result.RTOStartTime)
-					result.RTOEndTime = time.Now()
-					result.RTOPassed = result.RTA <= rtoTarget
+			// Sub-second probing reports progress at most once per second
+			if now.Sub(lastReported) >= time.Second {
+				lastReported = now
+				elapsed := now.Sub(result.RTOStartTime)
+				remaining := deadline.Sub(now)
+				limit := "RTO remaining"
+				if exceededReported {
+					limit = "abort in"
+				}
+				fmt.Printf("[Health Check #%d] ❌ Health check failed (exit code: %d). RTA elapsed: %s, %s: %s. Retrying in %s...\n", 
+					attemptNum, attempt.ExitCode, formatDuration(elapsed), limit, formatDuration(remaining), r.probeInterval)
+				if attempt.Stderr != "" {
+					fmt.Printf("  Error: %s\n", strings.TrimSpace(attempt.Stderr))
 				}
-				return false
-			case <-time.After(r.healthCheckInterval):
 			}
 		}
 	}
 }
 
+// watchForDowntime records health checks until done is closed, starting RTA
+// at the first failed check
+func (r *Runner) watchForDowntime(ctx context.Context, probes *prober, done <-chan struct{}, result *DrillResult) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-done:
+			return nil
+		case attempt := <-probes.results:
+			r.recordDowntimeCheck(attempt, result)
+		}
+	}
+}
+
+// recordDowntimeCheck records a health check taken before recovery began
+func (r *Runner) recordDowntimeCheck(attempt *CommandResult, result *DrillResult) {
+	result.HealthCheckAttempts = append(result.HealthCheckAttempts, *attempt)
+	defer r.healthChecked(len(result.HealthCheckAttempts), attempt)
+	if attempt.Up() {
+		return
+	}
+
+	result.FailedProbes++
+	if result.RTOStartTime.IsZero() {
+		// Service is down - RTA starts now
+		result.RTOStartTime = attempt.Timestamp
+		fmt.Printf("Service is down - RTA measurement started at %s\n", result.RTOStartTime.Format(time.RFC3339))
+	}
+}
+
 // hashString computes SHA256 hash of a string
 func hashString(s string) string {
 	h := sha256.Sum256([]byte(s))
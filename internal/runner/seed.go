@@ -0,0 +1,65 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// seeder runs seed_command in the background until the disruption
+type seeder struct {
+	*repeater
+
+	mu      sync.Mutex
+	seq     int
+	written int
+	failed  int
+	lastErr string
+	last    *CommandResult
+}
+
+// startSeeding runs seed_command once, then every seed_interval until the
+// returned seeder is finished
+func (r *Runner) startSeeding(ctx context.Context, scenario *config.Scenario) *seeder {
+	interval, _ := scenario.GetSeedInterval()
+	s := &seeder{}
+	fmt.Printf("Seeding test data every %s until the disruption...\n", formatDuration(interval))
+	s.repeater = startRepeater(ctx, interval, func(ctx context.Context) {
+		s.mu.Lock()
+		s.seq++
+		seq := s.seq
+		s.mu.Unlock()
+
+		res := r.runStep(ctx, scenario.SeedCommandFor(seq))
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if ctx.Err() == nil {
+			s.last = res
+		}
+		if res.Succeeded() {
+			s.written++
+		} else if ctx.Err() == nil {
+			s.failed++
+			s.lastErr = fmt.Sprintf("exit code %d: %s", res.ExitCode, strings.TrimSpace(res.Stderr))
+		}
+	})
+	return s
+}
+
+// finish stops seeding and records how many writes were made
+func (s *seeder) finish(result *DrillResult) {
+	s.stop()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result.SeedsWritten = s.written
+	result.SeedsFailed = s.failed
+	result.Seed = s.last
+	if s.failed > 0 {
+		result.Errors = append(result.Errors, fmt.Sprintf("seed_command: %d of %d write(s) failed (last: %s)", s.failed, s.written+s.failed, s.lastErr))
+	}
+	fmt.Printf("Seeded test data: %d write(s) before the disruption\n", s.written)
+}
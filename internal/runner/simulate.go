@@ -0,0 +1,151 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// EnableSimulation makes subsequent runs report the outcomes declared in the
+// scenario's simulate block instead of executing commands or probing health
+func (r *Runner) EnableSimulation() {
+	r.simulating = true
+}
+
+// simulator answers commands for a simulated run
+type simulator struct {
+	config *config.Simulation
+	start  time.Time
+
+	mu          sync.Mutex
+	phase       string
+	calls       map[int]int    // Calls answered by each simulate.commands entry
+	scriptCalls map[string]int // Calls passed to the fault script, by command
+}
+
+func newSimulator(simulation *config.Simulation) *simulator {
+	if simulation == nil {
+		simulation = &config.Simulation{}
+	}
+	return &simulator{
+		config:      simulation,
+		start:       time.Now(),
+		calls:       map[int]int{},
+		scriptCalls: map[string]int{},
+	}
+}
+
+func (s *simulator) setPhase(phase string) {
+	s.mu.Lock()
+	s.phase = phase
+	s.mu.Unlock()
+}
+
+// simulate reports the outcome of command without running it: the first
+// matching simulate.commands entry, else the fault script, else success
+func (r *Runner) simulate(ctx context.Context, command string) *CommandResult {
+	s := r.sim
+	s.mu.Lock()
+	for i := range s.config.Commands {
+		entry := &s.config.Commands[i]
+		if !entry.Matches(command) {
+			continue
+		}
+		outcome := entry.Outcome(s.calls[i])
+		s.calls[i]++
+		s.mu.Unlock()
+		return simulatedResult(ctx, command, outcome)
+	}
+	if s.config.Script == "" {
+		s.mu.Unlock()
+		return simulatedResult(ctx, command, config.SimulatedOutcome{})
+	}
+	call := s.scriptCalls[command]
+	s.scriptCalls[command]++
+	phase := s.phase
+	s.mu.Unlock()
+
+	env := []string{
+		"DRILLMEASURE_SIMULATED_COMMAND=" + command,
+		"DRILLMEASURE_SIMULATED_CALL=" + strconv.Itoa(call),
+		"DRILLMEASURE_SIMULATED_PHASE=" + phase,
+		fmt.Sprintf("DRILLMEASURE_SIMULATED_ELAPSED=%.3f", time.Since(s.start).Seconds()),
+	}
+	return r.executeArgsEnv(ctx, command, append(defaultShellArgs(), s.config.Script), env)
+}
+
+// simulatedResult waits out the outcome's latency and reports it
+func simulatedResult(ctx context.Context, command string, outcome config.SimulatedOutcome) *CommandResult {
+	result := &CommandResult{
+		Command:   command,
+		Timestamp: time.Now(),
+		ExitCode:  outcome.ExitCode,
+		Stdout:    outcome.Stdout,
+		Stderr:    outcome.Stderr,
+	}
+
+	// Validated with the scenario
+	latency, _ := outcome.GetLatency()
+	timer := time.NewTimer(latency)
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		timer.Stop()
+		result.ExitCode = -1
+		if ctx.Err() == context.DeadlineExceeded {
+			result.Stderr = fmt.Sprintf("command timed out: %v", ctx.Err())
+		} else {
+			result.Stderr = fmt.Sprintf("command canceled: %v", ctx.Err())
+		}
+	}
+
+	result.Duration = time.Since(result.Timestamp)
+	result.StdoutHash = hashString(result.Stdout)
+	result.StderrHash = hashString(result.Stderr)
+	return result
+}
+
+// simulatedProbe stands in for a built-in health probe, answering with the
+// outcome simulated for its description, e.g. "http GET https://example.com/health"
+type simulatedProbe struct {
+	runner  *Runner
+	command string
+	details ProbeDetails
+}
+
+func newSimulatedProbe(r *Runner, hc *config.HealthCheck) *simulatedProbe {
+	p := &simulatedProbe{runner: r, details: ProbeDetails{Type: hc.Type}}
+	switch hc.Type {
+	case "http":
+		p.details.Target = hc.URL
+		p.command = fmt.Sprintf("http GET %s", hc.URL)
+	case "tcp":
+		p.details.Target = net.JoinHostPort(hc.Host, strconv.Itoa(hc.Port))
+		p.command = fmt.Sprintf("tcp connect %s", p.details.Target)
+	case "prometheus":
+		p.details.Target = hc.URL
+		p.command = fmt.Sprintf("promql %s %s", hc.Query, hc.Condition)
+	case "cloudwatch":
+		p.details.Target = hc.Namespace + "/" + hc.Metric
+		p.command = fmt.Sprintf("cloudwatch %s %s", p.details.Target, hc.Condition)
+	case "webhook":
+		p.details.Target = hc.Path
+		p.command = fmt.Sprintf("webhook POST %s", hc.Path)
+	default:
+		p.command = hc.Type
+	}
+	return p
+}
+
+func (p *simulatedProbe) Check(ctx context.Context) *CommandResult {
+	result := p.runner.simulate(ctx, p.command)
+	details := p.details
+	details.Latency = result.Duration
+	result.Probe = &details
+	return result
+}
@@ -0,0 +1,189 @@
+package runner
+
+import (
+	"regexp"
+	"strings"
+)
+
+// maxDiffLines caps how many changed lines a snapshot diff keeps
+const maxDiffLines = 200
+
+// maxLCSCells bounds the line-diff table; larger snapshots fall back to
+// comparing lines as unordered sets
+const maxLCSCells = 4_000_000
+
+// checksumPattern matches an MD5, SHA-1, SHA-256, or SHA-512 hex digest
+var checksumPattern = regexp.MustCompile(`\b(?:[0-9a-fA-F]{128}|[0-9a-fA-F]{64}|[0-9a-fA-F]{40}|[0-9a-fA-F]{32})\b`)
+
+// SnapshotDiff compares the output of pre_snapshot and post_snapshot
+type SnapshotDiff struct {
+	PreRecords   int // Non-empty lines before the disruption
+	PostRecords  int // Non-empty lines after recovery
+	LinesAdded   int
+	LinesRemoved int
+	Lines        []string         // Changed lines prefixed with "-" or "+", capped at maxDiffLines
+	Truncated    bool             // More lines changed than Lines holds
+	Checksums    []ChecksumChange // Digests that differ, keyed by the rest of their line
+}
+
+// ChecksumChange is a checksum that differs between the two snapshots; Before
+// or After is empty when the key only appears in one of them
+type ChecksumChange struct {
+	Key    string
+	Before string
+	After  string
+}
+
+// RecordDelta returns the change in record count from before to after
+func (d *SnapshotDiff) RecordDelta() int {
+	return d.PostRecords - d.PreRecords
+}
+
+// Identical reports whether both snapshots had the same lines
+func (d *SnapshotDiff) Identical() bool {
+	return d.LinesAdded == 0 && d.LinesRemoved == 0
+}
+
+// diffSnapshots computes a line diff, record count delta, and checksum
+// changes between two snapshot outputs
+func diffSnapshots(pre, post string) *SnapshotDiff {
+	a, b := snapshotLines(pre), snapshotLines(post)
+	diff := &SnapshotDiff{PreRecords: len(a), PostRecords: len(b)}
+
+	add := func(prefix, line string) {
+		if prefix == "-" {
+			diff.LinesRemoved++
+		} else {
+			diff.LinesAdded++
+		}
+		if len(diff.Lines) < maxDiffLines {
+			diff.Lines = append(diff.Lines, prefix+line)
+		} else {
+			diff.Truncated = true
+		}
+	}
+	if len(a)*len(b) <= maxLCSCells {
+		diffLCS(a, b, add)
+	} else {
+		diffSets(a, b, add)
+	}
+
+	diff.Checksums = diffChecksums(a, b)
+	return diff
+}
+
+// snapshotLines returns the non-empty lines of a snapshot output
+func snapshotLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// diffLCS reports lines removed from a and added in b, in order, using the
+// longest common subsequence
+func diffLCS(a, b []string, add func(prefix, line string)) {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			add("-", a[i])
+			i++
+		default:
+			add("+", b[j])
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		add("-", a[i])
+	}
+	for ; j < m; j++ {
+		add("+", b[j])
+	}
+}
+
+// diffSets reports lines that occur more often in a than b as removed and
+// the reverse as added, ignoring order
+func diffSets(a, b []string, add func(prefix, line string)) {
+	counts := make(map[string]int)
+	for _, line := range b {
+		counts[line]++
+	}
+	for _, line := range a {
+		if counts[line] > 0 {
+			counts[line]--
+		} else {
+			add("-", line)
+		}
+	}
+	for _, line := range b {
+		if counts[line] > 0 {
+			counts[line]--
+			add("+", line)
+		}
+	}
+}
+
+// diffChecksums pairs the first checksum on each line by the rest of the line
+// and returns those that changed, appeared, or disappeared
+func diffChecksums(a, b []string) []ChecksumChange {
+	before, keys := checksumsByKey(a, nil)
+	after, keys := checksumsByKey(b, keys)
+
+	var changes []ChecksumChange
+	for _, key := range keys {
+		if before[key] != after[key] {
+			changes = append(changes, ChecksumChange{Key: key, Before: before[key], After: after[key]})
+		}
+	}
+	return changes
+}
+
+// checksumsByKey maps each line's checksum to the line without it, appending
+// keys not yet seen to keys in order
+func checksumsByKey(lines []string, keys []string) (map[string]string, []string) {
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		seen[key] = true
+	}
+	sums := make(map[string]string)
+	for _, line := range lines {
+		loc := checksumPattern.FindStringIndex(line)
+		if loc == nil {
+			continue
+		}
+		key := strings.TrimSpace(line[:loc[0]] + " " + line[loc[1]:])
+		key = strings.TrimSpace(strings.TrimRight(key, ":= \t"))
+		if key == "" {
+			key = "(checksum)"
+		}
+		sums[key] = strings.ToLower(line[loc[0]:loc[1]])
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return sums, keys
+}
@@ -0,0 +1,79 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// SnapshotSample is one post_snapshot run taken during the drill
+type SnapshotSample struct {
+	Time       time.Time
+	Duration   time.Duration
+	ExitCode   int
+	StdoutHash string
+}
+
+// snapshotter runs post_snapshot in the background from the disruption
+// until the final post-snapshot
+type snapshotter struct {
+	*repeater
+
+	mu      sync.Mutex
+	samples []SnapshotSample
+}
+
+// startSnapshots runs post_snapshot every interval until finished
+func (r *Runner) startSnapshots(ctx context.Context, rpo *config.RPOCheck) *snapshotter {
+	interval, _ := rpo.GetSnapshotInterval()
+	s := &snapshotter{}
+	s.repeater = startRepeaterAsync(ctx, interval, func(ctx context.Context) {
+		res := r.runStep(ctx, rpo.PostSnapshot)
+		if ctx.Err() != nil {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.samples = append(s.samples, SnapshotSample{
+			Time:       res.Timestamp,
+			Duration:   res.Duration,
+			ExitCode:   res.ExitCode,
+			StdoutHash: res.StdoutHash,
+		})
+	})
+	return s
+}
+
+// finish stops snapshotting and records the samples taken
+func (s *snapshotter) finish(result *DrillResult) {
+	s.stop()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result.SnapshotSamples = append(result.SnapshotSamples, s.samples...)
+	fmt.Printf("Continuous snapshots: %d taken during the drill\n", len(s.samples))
+}
+
+// DataStableAt returns when the snapshot output last changed before settling
+// on the final post-snapshot's output: the first sample of the unbroken run of
+// samples matching it. It is zero when no sample matched.
+func (d *DrillResult) DataStableAt() time.Time {
+	if d.PostSnapshot == nil || !d.PostSnapshot.Succeeded() {
+		return time.Time{}
+	}
+	stable := d.PostSnapshot.Timestamp
+	for i := len(d.SnapshotSamples) - 1; i >= 0; i-- {
+		s := d.SnapshotSamples[i]
+		if s.ExitCode != d.PostSnapshot.ExitCode || s.StdoutHash != d.PostSnapshot.StdoutHash {
+			break
+		}
+		stable = s.Time
+	}
+	if stable.Equal(d.PostSnapshot.Timestamp) {
+		return time.Time{}
+	}
+	return stable
+}
@@ -0,0 +1,24 @@
+package runner
+
+import (
+	"strconv"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// sshArgs returns the ssh invocation that runs remoteArgv on the remote host.
+// The ssh CLI is used so that agent keys, known_hosts, and ~/.ssh/config
+// (jump hosts, ControlMaster) apply exactly as they do for the operator.
+func sshArgs(e *config.Execution, remoteArgv []string) []string {
+	args := []string{"ssh", "-o", "BatchMode=yes"}
+	if e.Port != 0 {
+		args = append(args, "-p", strconv.Itoa(e.Port))
+	}
+	if e.Key != "" {
+		args = append(args, "-i", e.Key, "-o", "IdentitiesOnly=yes")
+	}
+	// ssh joins remote arguments into one string for the remote login shell,
+	// so each argument is quoted for that shell. The destination follows "--"
+	// so a host starting with "-" cannot be read as an option.
+	return append(args, "--", e.Target(), quoteArgs(remoteArgv))
+}
@@ -0,0 +1,61 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// measureSwitchback fails back to the original primary and times it from the
+// start of the switchback command until the service is healthy again. It
+// waits at most maxWait for the service to become healthy.
+func (r *Runner) measureSwitchback(ctx context.Context, probe Probe, sb *config.Switchback, target, maxWait time.Duration, result *DrillResult) {
+	result.SwitchbackTarget = target
+	result.SwitchbackStartTime = time.Now()
+	fmt.Println("Executing switchback command...")
+	result.Switchback = r.runStep(ctx, sb.Command)
+	if !result.Switchback.Succeeded() {
+		result.Errors = append(result.Errors, fmt.Sprintf("switchback command failed with exit code %d", result.Switchback.ExitCode))
+	}
+
+	deadline := result.SwitchbackStartTime.Add(maxWait)
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, r.healthCheckTimeout)
+		attempt := probe.Check(checkCtx)
+		cancel()
+		result.HealthCheckAttempts = append(result.HealthCheckAttempts, *attempt)
+		r.healthChecked(len(result.HealthCheckAttempts), attempt)
+
+		if attempt.Up() {
+			result.SwitchbackEndTime = time.Now()
+			if attempt.Probe != nil && attempt.Probe.SignaledAt.After(result.SwitchbackStartTime) {
+				result.SwitchbackEndTime = attempt.Probe.SignaledAt
+			}
+			result.SwitchbackTime = result.SwitchbackEndTime.Sub(result.SwitchbackStartTime)
+			result.SwitchbackPassed = target == 0 || result.SwitchbackTime <= target
+			fmt.Printf("✅ Switchback complete: %s\n", formatDuration(result.SwitchbackTime))
+			return
+		}
+
+		now := time.Now()
+		if now.After(deadline) {
+			result.SwitchbackEndTime = now
+			result.SwitchbackTime = now.Sub(result.SwitchbackStartTime)
+			result.SwitchbackPassed = false
+			result.Errors = append(result.Errors, fmt.Sprintf("service not healthy %s after switchback", formatDuration(maxWait)))
+			fmt.Printf("❌ Service not healthy %s after switchback\n", formatDuration(maxWait))
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			result.SwitchbackEndTime = time.Now()
+			result.SwitchbackTime = result.SwitchbackEndTime.Sub(result.SwitchbackStartTime)
+			result.SwitchbackPassed = false
+			return
+		case <-time.After(r.probeInterval):
+		}
+	}
+}
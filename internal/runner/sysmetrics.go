@@ -0,0 +1,261 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// Built-in system metric names
+const (
+	SystemCPU    = "cpu_percent"    // CPU busy since the previous sample
+	SystemMemory = "memory_percent" // Memory in use, excluding reclaimable caches
+	SystemLoad   = "load1"          // One-minute load average
+	SystemDisk   = "disk_percent"   // Space used on factors.system_metrics.disk_path
+)
+
+// SystemSample is one reading of the host's system metrics
+type SystemSample struct {
+	Time   time.Time
+	Values map[string]float64
+	Error  string // Why some or all values could not be read
+}
+
+// SystemMetricNames returns the names of every sampled system metric, with
+// the built-in metrics first
+func (d *DrillResult) SystemMetricNames() []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, s := range d.SystemSamples {
+		for name := range s.Values {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	builtin := map[string]int{SystemCPU: 0, SystemMemory: 1, SystemLoad: 2, SystemDisk: 3}
+	sort.Slice(names, func(i, j int) bool {
+		bi, iok := builtin[names[i]]
+		bj, jok := builtin[names[j]]
+		if iok != jok {
+			return iok
+		}
+		if iok {
+			return bi < bj
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// systemSampler samples system metrics in the background for the whole drill
+type systemSampler struct {
+	*repeater
+
+	mu      sync.Mutex
+	samples []SystemSample
+	lastCPU *cpuTimes
+}
+
+// startSystemMetrics samples system metrics every interval until finished
+func (r *Runner) startSystemMetrics(ctx context.Context, m *config.SystemMetrics) *systemSampler {
+	interval, _ := m.GetInterval()
+	s := &systemSampler{}
+	s.repeater = startRepeaterAsync(ctx, interval, func(ctx context.Context) {
+		var sample SystemSample
+		if m.Command.IsSet() {
+			sample = r.sampleCommand(ctx, m.Command)
+		} else {
+			sample = s.sampleHost(ctx, m.GetDiskPath())
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.samples = append(s.samples, sample)
+	})
+	return s
+}
+
+// finish stops sampling and records the samples taken
+func (s *systemSampler) finish(result *DrillResult) {
+	s.stop()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result.SystemSamples = append(result.SystemSamples, s.samples...)
+	s.samples = nil
+}
+
+// sampleCommand runs the user's command and reads its name=value lines
+func (r *Runner) sampleCommand(ctx context.Context, cmd config.Command) SystemSample {
+	res := r.runStep(ctx, cmd)
+	sample := SystemSample{Time: res.Timestamp, Values: map[string]float64{}}
+	if !res.Succeeded() {
+		sample.Error = fmt.Sprintf("command failed with exit code %d", res.ExitCode)
+		return sample
+	}
+	for _, line := range strings.Split(res.Stdout, "\n") {
+		name, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			continue
+		}
+		sample.Values[strings.TrimSpace(name)] = v
+	}
+	if len(sample.Values) == 0 {
+		sample.Error = "output has no name=value lines with numeric values"
+	}
+	return sample
+}
+
+// sampleHost reads the built-in metrics on the host running drillmeasure.
+// CPU, memory, and load come from /proc, so they are only available on Linux.
+func (s *systemSampler) sampleHost(ctx context.Context, diskPath string) SystemSample {
+	sample := SystemSample{Time: time.Now(), Values: map[string]float64{}}
+	var errs []string
+
+	if cpu, err := readCPUTimes(); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		if s.lastCPU != nil {
+			if busy, ok := cpu.busyPercentSince(s.lastCPU); ok {
+				sample.Values[SystemCPU] = busy
+			}
+		}
+		s.lastCPU = cpu
+	}
+	if mem, err := readMemoryPercent(); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		sample.Values[SystemMemory] = mem
+	}
+	if load, err := readLoad1(); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		sample.Values[SystemLoad] = load
+	}
+	if disk, err := readDiskPercent(ctx, diskPath); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		sample.Values[SystemDisk] = disk
+	}
+
+	sample.Error = strings.Join(errs, "; ")
+	return sample
+}
+
+// cpuTimes are the cumulative CPU times from /proc/stat
+type cpuTimes struct {
+	idle  uint64
+	total uint64
+}
+
+func readCPUTimes() (*cpuTimes, error) {
+	data, err := os.ReadFile("/proc/stat")
+	if err != nil {
+		return nil, fmt.Errorf("cpu: %w", err)
+	}
+	line, _, _ := strings.Cut(string(data), "\n")
+	fields := strings.Fields(line)
+	if len(fields) < 5 || fields[0] != "cpu" {
+		return nil, fmt.Errorf("cpu: unexpected /proc/stat format")
+	}
+	t := &cpuTimes{}
+	for i, field := range fields[1:] {
+		v, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("cpu: unexpected /proc/stat format")
+		}
+		t.total += v
+		// idle and iowait
+		if i == 3 || i == 4 {
+			t.idle += v
+		}
+	}
+	return t, nil
+}
+
+// busyPercentSince returns the share of CPU time spent busy since prev
+func (t *cpuTimes) busyPercentSince(prev *cpuTimes) (float64, bool) {
+	if t.total <= prev.total || t.idle < prev.idle {
+		return 0, false
+	}
+	total := t.total - prev.total
+	return float64(total-(t.idle-prev.idle)) / float64(total) * 100, true
+}
+
+func readMemoryPercent() (float64, error) {
+	data, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return 0, fmt.Errorf("memory: %w", err)
+	}
+	values := map[string]float64{}
+	for _, line := range strings.Split(string(data), "\n") {
+		name, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		if v, err := strconv.ParseFloat(fields[0], 64); err == nil {
+			values[name] = v
+		}
+	}
+	total, available := values["MemTotal"], values["MemAvailable"]
+	if total <= 0 {
+		return 0, fmt.Errorf("memory: MemTotal missing from /proc/meminfo")
+	}
+	return (total - available) / total * 100, nil
+}
+
+func readLoad1() (float64, error) {
+	data, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return 0, fmt.Errorf("load: %w", err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("load: /proc/loadavg is empty")
+	}
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("load: %w", err)
+	}
+	return load, nil
+}
+
+// readDiskPercent returns the space used on the filesystem holding path, as
+// df reports it: used over used plus available
+func readDiskPercent(ctx context.Context, path string) (float64, error) {
+	out, err := exec.CommandContext(ctx, "df", "-Pk", path).Output()
+	if err != nil {
+		return 0, fmt.Errorf("disk: df %s: %w", path, err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	fields := strings.Fields(lines[len(lines)-1])
+	if len(lines) < 2 || len(fields) < 4 {
+		return 0, fmt.Errorf("disk: unexpected df output")
+	}
+	used, err1 := strconv.ParseFloat(fields[2], 64)
+	available, err2 := strconv.ParseFloat(fields[3], 64)
+	if err1 != nil || err2 != nil || used+available == 0 {
+		return 0, fmt.Errorf("disk: unexpected df output")
+	}
+	return used / (used + available) * 100, nil
+}
@@ -0,0 +1,190 @@
+package runner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// targetSourceTimeout bounds each lookup of a centrally governed target
+const targetSourceTimeout = 30 * time.Second
+
+// resolveTargets replaces the scenario's rto_target and rpo_target with the
+// values its target_source returns. A source that cannot be read fails the
+// run rather than silently falling back to the scenario's values.
+func (r *Runner) resolveTargets(ctx context.Context, scenario *config.Scenario) error {
+	source := scenario.TargetSource
+	if source == nil {
+		return nil
+	}
+
+	var rto, rpo string
+	var err error
+	switch source.Type {
+	case "http":
+		rto, rpo, err = fetchHTTPTargets(ctx, source.URL)
+	case "consul":
+		rto, rpo, err = r.lookupTargets(ctx, source, fetchConsulKey)
+	case "ssm":
+		rto, rpo, err = r.lookupTargets(ctx, source, r.fetchSSMParameter)
+	default:
+		err = fmt.Errorf("unknown type %q", source.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resolve targets from %s: %w", source, err)
+	}
+
+	if rto != "" {
+		if _, err := time.ParseDuration(rto); err != nil {
+			return fmt.Errorf("invalid rto_target %q from %s: %w", rto, source, err)
+		}
+		scenario.RTOTarget = rto
+	}
+	if rpo != "" {
+		if _, err := time.ParseDuration(rpo); err != nil {
+			return fmt.Errorf("invalid rpo_target %q from %s: %w", rpo, source, err)
+		}
+		scenario.RPOTarget = rpo
+	}
+	if scenario.RTOTarget == "" {
+		return fmt.Errorf("%s returned no rto_target and the scenario sets none", source)
+	}
+
+	fmt.Printf("Targets resolved from %s: RTO %s", source, scenario.RTOTarget)
+	if scenario.RPOTarget != "" {
+		fmt.Printf(", RPO %s", scenario.RPOTarget)
+	}
+	fmt.Println()
+	return nil
+}
+
+// lookupTargets reads the RTO and RPO keys that are set through fetch
+func (r *Runner) lookupTargets(ctx context.Context, source *config.TargetSource, fetch func(context.Context, *config.TargetSource, string) (string, error)) (string, string, error) {
+	var values [2]string
+	for i, key := range []string{source.RTOKey, source.RPOKey} {
+		if key == "" {
+			continue
+		}
+		value, err := fetch(ctx, source, key)
+		if err != nil {
+			return "", "", err
+		}
+		values[i] = value
+	}
+	return values[0], values[1], nil
+}
+
+// fetchHTTPTargets reads {"rto_target": "5m", "rpo_target": "15m"} from an endpoint
+func fetchHTTPTargets(ctx context.Context, endpoint string) (string, string, error) {
+	body, err := httpGet(ctx, endpoint, nil)
+	if err != nil {
+		return "", "", err
+	}
+
+	var targets struct {
+		RTOTarget string `json:"rto_target"`
+		RPOTarget string `json:"rpo_target"`
+	}
+	if err := json.Unmarshal(body, &targets); err != nil {
+		return "", "", fmt.Errorf("invalid response: %w", err)
+	}
+	return targets.RTOTarget, targets.RPOTarget, nil
+}
+
+// fetchConsulKey reads a raw value from the Consul KV store
+func fetchConsulKey(ctx context.Context, source *config.TargetSource, key string) (string, error) {
+	body, err := httpGet(ctx, consulKVURL(source.URL, key)+"?raw", consulHeader())
+	if err != nil {
+		return "", fmt.Errorf("key %q: %w", key, err)
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// consulAPIURL returns the HTTP API base of the agent at addr, which defaults
+// to CONSUL_HTTP_ADDR or the local agent
+func consulAPIURL(addr string) string {
+	if addr == "" {
+		addr = os.Getenv("CONSUL_HTTP_ADDR")
+	}
+	if addr == "" {
+		addr = "http://127.0.0.1:8500"
+	}
+	if !strings.Contains(addr, "://") {
+		addr = "http://" + addr
+	}
+	return strings.TrimRight(addr, "/") + "/v1"
+}
+
+// consulKVURL returns the KV endpoint for key on the agent at addr
+func consulKVURL(addr, key string) string {
+	return consulAPIURL(addr) + "/kv/" + (&url.URL{Path: strings.TrimLeft(key, "/")}).EscapedPath()
+}
+
+// consulHeader authenticates Consul requests with CONSUL_HTTP_TOKEN, if set
+func consulHeader() http.Header {
+	header := http.Header{}
+	if token := os.Getenv("CONSUL_HTTP_TOKEN"); token != "" {
+		header.Set("X-Consul-Token", token)
+	}
+	return header
+}
+
+// fetchSSMParameter reads a parameter from AWS Systems Manager Parameter Store
+func (r *Runner) fetchSSMParameter(ctx context.Context, source *config.TargetSource, name string) (string, error) {
+	args := []string{"aws", "ssm", "get-parameter", "--name", name, "--with-decryption",
+		"--query", "Parameter.Value", "--output", "text"}
+	if source.Region != "" {
+		args = append(args, "--region", source.Region)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, targetSourceTimeout)
+	defer cancel()
+	result := r.executeArgs(ctx, quoteArgs(args), args)
+	if result.ExitCode != 0 {
+		return "", fmt.Errorf("parameter %q: aws ssm get-parameter failed with exit code %d: %s", name, result.ExitCode, strings.TrimSpace(result.Stderr))
+	}
+	return strings.TrimSpace(result.Stdout), nil
+}
+
+// httpGet returns the body of a successful GET request
+func httpGet(ctx context.Context, endpoint string, header http.Header) ([]byte, error) {
+	return httpRequest(ctx, http.MethodGet, endpoint, header, nil)
+}
+
+// httpRequest returns the body of a successful request
+func httpRequest(ctx context.Context, method, endpoint string, header http.Header, body []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, targetSourceTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return respBody, nil
+}
@@ -0,0 +1,104 @@
+package runner
+
+import (
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceEvent is one entry of a debug execution trace
+type TraceEvent struct {
+	Time      time.Time              `json:"time"`
+	ElapsedMs float64                `json:"elapsed_ms"` // Since the trace started
+	Kind      string                 `json:"kind"`
+	Message   string                 `json:"message"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+}
+
+// Tracer records verbose execution events when debugging is enabled
+type Tracer struct {
+	mu      sync.Mutex
+	start   time.Time
+	secrets []string
+	events  []TraceEvent
+}
+
+// EnableTracing starts recording a debug trace of subsequent runs
+func (r *Runner) EnableTracing() *Tracer {
+	r.tracer = &Tracer{start: time.Now()}
+	return r.tracer
+}
+
+// Events returns the recorded events in order
+func (t *Tracer) Events() []TraceEvent {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TraceEvent(nil), t.events...)
+}
+
+// mask hides known secret values in s
+func (t *Tracer) mask(s string) string {
+	for _, secret := range t.secrets {
+		s = strings.ReplaceAll(s, secret, "********")
+	}
+	return s
+}
+
+// trace records an event; it does nothing when tracing is disabled
+func (r *Runner) trace(kind, message string, fields map[string]interface{}) {
+	t := r.tracer
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	now := time.Now()
+	for k, v := range fields {
+		switch v := v.(type) {
+		case string:
+			fields[k] = t.mask(v)
+		case []string:
+			masked := make([]string, len(v))
+			for i, s := range v {
+				masked[i] = t.mask(s)
+			}
+			fields[k] = masked
+		}
+	}
+	t.events = append(t.events, TraceEvent{
+		Time:      now,
+		ElapsedMs: float64(now.Sub(t.start).Microseconds()) / 1000,
+		Kind:      kind,
+		Message:   t.mask(message),
+		Fields:    fields,
+	})
+}
+
+// traceExec records a process about to start, including where its program resolves
+func (r *Runner) traceExec(command string, argv []string) {
+	if r.tracer == nil {
+		return
+	}
+	fields := map[string]interface{}{"argv": argv}
+	if path, err := exec.LookPath(argv[0]); err == nil {
+		fields["program_path"] = path
+	} else {
+		fields["program_path_error"] = err.Error()
+	}
+	r.trace("exec_start", command, fields)
+}
+
+// traceExecEnd records a finished process
+func (r *Runner) traceExecEnd(result *CommandResult) {
+	if r.tracer == nil {
+		return
+	}
+	r.trace("exec_end", result.Command, map[string]interface{}{
+		"exit_code":    result.ExitCode,
+		"duration_ms":  float64(result.Duration.Microseconds()) / 1000,
+		"stdout_bytes": len(result.Stdout),
+		"stderr_bytes": len(result.Stderr),
+	})
+}
@@ -0,0 +1,57 @@
+package runner
+
+import (
+	"fmt"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+)
+
+// vsphereCommand renders a built-in vSphere action as a govc invocation.
+// govc reads GOVC_URL, GOVC_USERNAME, GOVC_PASSWORD, and the other GOVC_*
+// variables, so credentials stay out of the scenario and the reports.
+func vsphereCommand(v *config.VSphereAction) string {
+	var args []string
+	switch v.Action {
+	case config.VSphereVMPowerOff:
+		args = []string{"govc", "vm.power", "-off", "-force"}
+	case config.VSphereVMPowerOn:
+		args = []string{"govc", "vm.power", "-on"}
+	case config.VSphereVMReset:
+		args = []string{"govc", "vm.power", "-reset"}
+	case config.VSphereVMMigrate:
+		args = []string{"govc", "vm.migrate"}
+		if v.Host != "" {
+			args = append(args, "-host", v.Host)
+		}
+		if v.Datastore != "" {
+			args = append(args, "-ds", v.Datastore)
+		}
+	case config.VSphereHostReboot:
+		// A forced reboot of a host in an HA cluster makes HA restart its VMs elsewhere
+		args = []string{"govc", "host.shutdown", "-f", "-r"}
+	case config.VSphereHostMaintenanceEnter:
+		args = []string{"govc", "host.maintenance.enter"}
+	case config.VSphereHostMaintenanceExit:
+		args = []string{"govc", "host.maintenance.exit"}
+	default:
+		return fmt.Sprintf("echo %s >&2; exit 1", shellQuote(fmt.Sprintf("unknown vsphere action %q", v.Action)))
+	}
+
+	if v.URL != "" {
+		args = append(args, "-u", v.URL)
+	}
+	if v.Datacenter != "" {
+		args = append(args, "-dc", v.Datacenter)
+	}
+	if v.Insecure {
+		args = append(args, "-k")
+	}
+
+	switch v.Action {
+	case config.VSphereHostReboot, config.VSphereHostMaintenanceEnter, config.VSphereHostMaintenanceExit:
+		args = append(args, v.Host)
+	default:
+		args = append(args, v.VM)
+	}
+	return quoteArgs(args)
+}
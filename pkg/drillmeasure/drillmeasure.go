@@ -0,0 +1,96 @@
+// Package drillmeasure is the public API for embedding drillmeasure in other
+// applications. It runs the same drills as the CLI and exposes lifecycle
+// callbacks so callers can surface live progress in their own UIs.
+package drillmeasure
+
+import (
+	"context"
+
+	"github.com/drillmeasure/drillmeasure/internal/config"
+	"github.com/drillmeasure/drillmeasure/internal/runner"
+)
+
+// Scenario is a parsed drill scenario
+type Scenario = config.Scenario
+
+// Result holds the complete result of a drill execution
+type Result = runner.DrillResult
+
+// CommandResult holds the result of a single command or probe
+type CommandResult = runner.CommandResult
+
+// PhaseTiming is when a phase of the drill started and ended
+type PhaseTiming = runner.PhaseTiming
+
+// Hooks are optional lifecycle callbacks invoked while a drill runs
+type Hooks = runner.Hooks
+
+// Checkpoint is the state of a drill in progress, passed to
+// Hooks.OnCheckpoint and accepted by Resume. It encodes as JSON, so callers
+// can persist it wherever suits them.
+type Checkpoint = runner.Checkpoint
+
+// Event is one entry of the event stream passed to Hooks.OnEvent
+type Event = runner.Event
+
+// Event types
+const (
+	EventRunStart     = runner.EventRunStart
+	EventPhaseStart   = runner.EventPhaseStart
+	EventCommandStart = runner.EventCommandStart
+	EventCommandEnd   = runner.EventCommandEnd
+	EventHealthCheck  = runner.EventHealthCheck
+	EventPause        = runner.EventPause
+	EventRunEnd       = runner.EventRunEnd
+)
+
+// Phase names passed to Hooks.OnPhaseStart
+const (
+	PhaseBackupFreshness  = runner.PhaseBackupFreshness
+	PhaseRestore          = runner.PhaseRestore
+	PhaseSeed             = runner.PhaseSeed
+	PhasePreSnapshot      = runner.PhasePreSnapshot
+	PhaseGuards           = runner.PhaseGuards
+	PhaseMarkers          = runner.PhaseMarkers
+	PhaseDisrupt          = runner.PhaseDisrupt
+	PhasePostDisruptDelay = runner.PhasePostDisruptDelay
+	PhaseDetectDowntime   = runner.PhaseDetectDowntime
+	PhaseRecover          = runner.PhaseRecover
+	PhaseRTAMeasurement   = runner.PhaseRTAMeasurement
+	PhaseAbort            = runner.PhaseAbort
+	PhasePostSnapshot     = runner.PhasePostSnapshot
+	PhaseRPOVerify        = runner.PhaseRPOVerify
+	PhaseConsistency      = runner.PhaseConsistency
+	PhaseSwitchback       = runner.PhaseSwitchback
+	PhaseFactors          = runner.PhaseFactors
+)
+
+// ParseScenario reads a scenario file, resolving variables from vars
+func ParseScenario(path string, vars map[string]string) (*Scenario, error) {
+	return config.ParseScenarioWithVariables(path, vars)
+}
+
+// Run validates and executes a drill scenario, invoking hooks as it progresses
+func Run(ctx context.Context, scenario *Scenario, hooks Hooks) (*Result, error) {
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+
+	r := runner.NewRunner()
+	r.SetHooks(hooks)
+	return r.Run(ctx, scenario)
+}
+
+// Resume validates a scenario and continues its drill from a checkpoint,
+// invoking hooks as it progresses. Steps completed before the checkpoint are
+// kept and the disruption is never issued again; a checkpoint taken before
+// the disruption runs the drill from the start.
+func Resume(ctx context.Context, scenario *Scenario, checkpoint *Checkpoint, hooks Hooks) (*Result, error) {
+	if err := scenario.Validate(); err != nil {
+		return nil, err
+	}
+
+	r := runner.NewRunner()
+	r.SetHooks(hooks)
+	return r.Resume(ctx, scenario, checkpoint)
+}
@@ -0,0 +1,54 @@
+package drillmeasure
+
+import (
+	"os"
+
+	"github.com/drillmeasure/drillmeasure/internal/report"
+)
+
+// ReportSchemaVersion is the version of the report.json structure. Fields
+// may be added within a version; removing, renaming, or changing one bumps it.
+const ReportSchemaVersion = report.ReportSchemaVersion
+
+// Report is the structure of report.json
+type Report = report.ReportData
+
+// Types nested in Report
+type (
+	ReportCommandResult  = report.CommandResultData
+	ReportMemberResult   = report.MemberResultData
+	ReportProbe          = report.ProbeData
+	ReportPhase          = report.PhaseData
+	ReportPause          = report.PauseData
+	ReportGuard          = report.GuardData
+	ReportRPOCheck       = report.RPOCheckData
+	ReportRestore        = report.RestoreData
+	ReportConsistency    = report.ConsistencyData
+	ReportMetric         = report.MetricData
+	ReportMetricSample   = report.MetricSampleData
+	ReportAssertion      = report.AssertionData
+	ReportSnapshotDiff   = report.SnapshotDiffData
+	ReportChecksumChange = report.ChecksumChangeData
+	ReportSnapshotSample = report.SnapshotSampleData
+	ReportSystemSample   = report.SystemSampleData
+	ReportErrorBudget    = report.ErrorBudgetData
+	ReportProbeLatency   = report.ProbeLatencyData
+	ReportLatencyStats   = report.LatencyStatsData
+	ReportLatencyBucket  = report.LatencyBucketData
+	ReportHealthState    = report.HealthStateData
+)
+
+// ParseReport decodes report.json data, upgrading reports written by older
+// versions and rejecting reports from a newer schema version
+func ParseReport(data []byte) (*Report, error) {
+	return report.ParseReport(data)
+}
+
+// ReadReport reads and decodes a report.json file
+func ReadReport(path string) (*Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReport(data)
+}